@@ -2,6 +2,9 @@ package exoscale
 
 import (
 	"context"
+	"errors"
+	"sync"
+	"time"
 
 	"github.com/hashicorp/vault/sdk/framework"
 	"github.com/hashicorp/vault/sdk/logical"
@@ -12,26 +15,94 @@ import (
 type exoscaleBackend struct {
 	exo *Exoscale
 	*framework.Backend
+
+	// accounts caches the clients of the accounts configured with config/account/<name>
+	accounts     map[string]*Exoscale
+	accountsLock sync.RWMutex
+
+	rootConfigLock       sync.Mutex
+	rolesLock            sync.Mutex
+	staticRolesLock      sync.Mutex
+	dbaasStaticRolesLock sync.Mutex
+	tidyLock             sync.Mutex
+	usageLock            sync.Mutex
+
+	// storage is the storage of the mount, used by background tasks outliving requests
+	storage logical.Storage
+
+	// poolLock protects the pools of pre-created keys, refilling holds the roles
+	// whose pool is being refilled
+	poolLock  sync.Mutex
+	refilling map[string]bool
+
+	// bgCtx is cancelled when the backend is unloaded, bgWG tracks the background tasks
+	bgCtx    context.Context
+	bgCancel context.CancelFunc
+	bgWG     sync.WaitGroup
+
+	// lastRevokedPurge is only accessed by the periodic function
+	lastRevokedPurge time.Time
 }
 
 func Factory(ctx context.Context, config *logical.BackendConfig) (logical.Backend, error) {
-	backend := exoscaleBackend{exo: &Exoscale{}}
+	backend := exoscaleBackend{
+		exo:       &Exoscale{},
+		accounts:  make(map[string]*Exoscale),
+		storage:   config.StorageView,
+		refilling: make(map[string]bool),
+	}
+	backend.bgCtx, backend.bgCancel = context.WithCancel(context.Background())
 	backend.Backend = &framework.Backend{
 		BackendType: logical.TypeLogical,
 		Help:        "Dynamically create Exoscale IAM API Keys",
 		Paths: framework.PathAppend(
 			backend.pathRole(),
+			backend.pathStaticRole(),
+			backend.pathConfigAccount(),
+			backend.pathTidy(),
+			backend.pathIssued(),
+			backend.pathSOSRole(),
+			backend.pathSKSRole(),
+			backend.pathDBaaSRole(),
+			backend.pathDBaaSStaticRole(),
+			backend.pathInstancePasswordRole(),
+			backend.pathSSHKeyRole(),
+			backend.pathAccessRole(),
 			[]*framework.Path{
+				backend.pathRoleCheck(),
 				backend.pathConfigRoot(),
+				backend.pathConfigRotateRoot(),
 				backend.pathConfigLease(),
 				backend.pathAPIKey(),
+				backend.pathStaticCreds(),
+				backend.pathQuota(),
+				backend.pathLookup(),
+				backend.pathSOSURL(),
+				backend.pathKubeconfig(),
+				backend.pathDBaaSCreds(),
+				backend.pathDBaaSStaticCreds(),
+				backend.pathInstancePassword(),
+				backend.pathSSHKey(),
+				backend.pathAccess(),
 			},
 		),
-		Secrets:        []*framework.Secret{backend.secretAPIKey()},
+		Secrets: []*framework.Secret{
+			backend.secretAPIKey(),
+			backend.secretKubeconfig(),
+			backend.secretDBaaSUser(),
+			backend.secretInstancePassword(),
+			backend.secretSSHKey(),
+			backend.secretAccess(),
+		},
 		RunningVersion: version.Version,
 		InitializeFunc: func(ctx context.Context, ir *logical.InitializationRequest) error {
+			if err := initInventory(ctx, ir.Storage); err != nil {
+				return err
+			}
 			return backend.exo.LoadConfigFromStorage(ctx, ir.Storage)
 		},
+		PeriodicFunc: backend.periodicFunc,
+		Clean:        backend.cleanup,
 	}
 
 	if err := backend.Setup(ctx, config); err != nil {
@@ -40,3 +111,24 @@ func Factory(ctx context.Context, config *logical.BackendConfig) (logical.Backen
 
 	return &backend, nil
 }
+
+// periodicFunc is invoked by Vault on a regular basis (every minute by default), each task
+// only handles the resources whose account, or config/root, is configured
+func (b *exoscaleBackend) periodicFunc(ctx context.Context, req *logical.Request) error {
+	return errors.Join(
+		b.rotateRootIfDue(ctx, req.Storage),
+		b.rotateStaticRoles(ctx, req.Storage),
+		b.rotateDBaaSStaticRoles(ctx, req.Storage),
+		b.checkRolesDrift(ctx, req.Storage),
+		b.tidyIfDue(ctx, req.Storage),
+		b.refillPools(ctx, req.Storage),
+		b.purgeRevokedKeysIfDue(ctx, req.Storage),
+	)
+}
+
+// cleanup is invoked by Vault when the backend is unloaded (unmount, seal, plugin reload),
+// the pooled keys are kept as they are handed out again once the backend is reloaded
+func (b *exoscaleBackend) cleanup(_ context.Context) {
+	b.bgCancel()
+	b.bgWG.Wait()
+}
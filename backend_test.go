@@ -28,6 +28,11 @@ func (ts *testSuite) storeEntry(k string, v interface{}) {
 }
 
 func (ts *testSuite) SetupTest() {
+	mockClient := new(mockEgoscaleClient)
+	newEgoscaleClient = func(ExoscaleConfig) (egoscaleClient, error) {
+		return mockClient, nil
+	}
+
 	config := logical.TestBackendConfig()
 	config.StorageView = new(logical.InmemStorage)
 
@@ -57,7 +62,6 @@ func (ts *testSuite) SetupTest() {
 	if err != nil {
 		ts.T().Fatal(err)
 	}
-	backend.(*exoscaleBackend).exo.egoscaleClient = new(mockEgoscaleClient)
 
 	ts.backend = backend
 	ts.storage = config.StorageView
@@ -79,3 +83,7 @@ func (ts *testSuite) randomID() string {
 func TestSuite(t *testing.T) {
 	suite.Run(t, new(testSuite))
 }
+
+func ptr[T any](v T) *T {
+	return &v
+}
@@ -0,0 +1,158 @@
+package exoscale
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"strings"
+	"syscall"
+
+	exoapi "github.com/exoscale/egoscale/v2/api"
+)
+
+// Classes of errors returned by the Exoscale API, errors returned by the
+// Exoscale methods can be tested against them using errors.Is
+var (
+	ErrNotFound    = errors.New("resource not found")
+	ErrForbidden   = errors.New("permission denied")
+	ErrRateLimited = errors.New("rate limited")
+	ErrTransient   = errors.New("transient error")
+	ErrInvalid     = errors.New("invalid request")
+)
+
+// APIError is an error response returned by the Exoscale API
+type APIError struct {
+	StatusCode int
+	Message    string
+}
+
+func (e *APIError) Error() string {
+	if e.Message == "" {
+		return fmt.Sprintf("%s (HTTP %d)", e.class(), e.StatusCode)
+	}
+	return fmt.Sprintf("%s (HTTP %d): %s", e.class(), e.StatusCode, e.Message)
+}
+
+func (e *APIError) Is(target error) bool {
+	return target == e.class()
+}
+
+func (e *APIError) class() error {
+	switch {
+	case e.StatusCode == http.StatusUnauthorized || e.StatusCode == http.StatusForbidden:
+		return ErrForbidden
+	case e.StatusCode == http.StatusNotFound:
+		return ErrNotFound
+	case e.StatusCode == http.StatusTooManyRequests:
+		return ErrRateLimited
+	case e.StatusCode >= http.StatusInternalServerError:
+		return ErrTransient
+	default:
+		return ErrInvalid
+	}
+}
+
+// isMissingResourceMessage reports whether a client error describes a missing resource
+func isMissingResourceMessage(msg string) bool {
+	msg = strings.ToLower(msg)
+	return strings.Contains(msg, "not in organization") || strings.Contains(msg, "not found")
+}
+
+// classifiedError attaches an error class to an error that isn't an APIError
+type classifiedError struct {
+	class error
+	err   error
+}
+
+func (e *classifiedError) Error() string {
+	return e.err.Error()
+}
+
+func (e *classifiedError) Unwrap() []error {
+	return []error{e.class, e.err}
+}
+
+// classifyError returns err annotated with its error class when it can be determined
+func classifyError(err error) error {
+	if err == nil {
+		return nil
+	}
+
+	var apiErr *APIError
+	if errors.As(err, &apiErr) {
+		return err
+	}
+
+	var class error
+	var netErr net.Error
+	switch {
+	// errors returned by the egoscale error handling middleware
+	case errors.Is(err, exoapi.ErrNotFound):
+		class = ErrNotFound
+	case errors.Is(err, exoapi.ErrInvalidRequest):
+		class = ErrInvalid
+	case errors.Is(err, exoapi.ErrAPIError):
+		class = ErrTransient
+
+	case errors.Is(err, context.DeadlineExceeded),
+		errors.Is(err, syscall.ECONNREFUSED),
+		errors.Is(err, syscall.ECONNRESET),
+		errors.Is(err, io.ErrUnexpectedEOF),
+		errors.As(err, &netErr) && netErr.Timeout():
+		class = ErrTransient
+
+	default:
+		return err
+	}
+
+	return &classifiedError{class: class, err: err}
+}
+
+// classifyAPIKeyError is classifyError for the API key get and delete calls: the IAM API
+// reports API keys outside of the organization, which includes deleted keys, with a 400
+// instead of a 404.
+func classifyAPIKeyError(err error) error {
+	var apiErr *APIError
+	if errors.As(err, &apiErr) && apiErr.StatusCode == http.StatusBadRequest && isMissingResourceMessage(apiErr.Message) {
+		return &classifiedError{class: ErrNotFound, err: err}
+	}
+
+	return classifyError(err)
+}
+
+// apiErrorTransport turns Exoscale API error responses into APIError values,
+// it must be placed before the egoscale error handling middleware which
+// discards the response status code.
+type apiErrorTransport struct {
+	next http.RoundTripper
+}
+
+func (t *apiErrorTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	resp, err := t.next.RoundTrip(req)
+	if err != nil || resp.StatusCode < 400 {
+		return resp, err
+	}
+	defer resp.Body.Close()
+
+	apiErr := &APIError{StatusCode: resp.StatusCode}
+
+	data, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("error reading response body: %w", err)
+	}
+
+	var body struct {
+		Message string `json:"message"`
+	}
+	if json.Valid(data) && json.Unmarshal(data, &body) == nil {
+		apiErr.Message = body.Message
+	} else {
+		apiErr.Message = strings.TrimSpace(string(data))
+	}
+
+	return nil, apiErr
+}
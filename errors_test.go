@@ -0,0 +1,184 @@
+package exoscale
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+
+	exoapi "github.com/exoscale/egoscale/v2/api"
+)
+
+func (ts *testSuite) TestAPIErrorClass() {
+	tests := []struct {
+		name    string
+		err     *APIError
+		want    error
+		wantMsg string
+	}{
+		{
+			name:    "not found",
+			err:     &APIError{StatusCode: http.StatusNotFound},
+			want:    ErrNotFound,
+			wantMsg: "resource not found (HTTP 404)",
+		},
+		{
+			name:    "forbidden mentioning a missing resource",
+			err:     &APIError{StatusCode: http.StatusForbidden, Message: "API Key not in organization"},
+			want:    ErrForbidden,
+			wantMsg: "permission denied (HTTP 403): API Key not in organization",
+		},
+		{
+			name: "forbidden",
+			err:  &APIError{StatusCode: http.StatusForbidden, Message: "Forbidden"},
+			want: ErrForbidden,
+		},
+		{
+			name: "unauthorized",
+			err:  &APIError{StatusCode: http.StatusUnauthorized},
+			want: ErrForbidden,
+		},
+		{
+			name: "rate limited",
+			err:  &APIError{StatusCode: http.StatusTooManyRequests},
+			want: ErrRateLimited,
+		},
+		{
+			name: "server error",
+			err:  &APIError{StatusCode: http.StatusServiceUnavailable},
+			want: ErrTransient,
+		},
+		{
+			name: "invalid",
+			err:  &APIError{StatusCode: http.StatusBadRequest, Message: "invalid name"},
+			want: ErrInvalid,
+		},
+	}
+
+	for _, tt := range tests {
+		ts.Run(tt.name, func() {
+			err := fmt.Errorf("failed to delete api key: %w", tt.err)
+			ts.Require().ErrorIs(err, tt.want)
+			for _, class := range []error{ErrNotFound, ErrForbidden, ErrRateLimited, ErrTransient, ErrInvalid} {
+				if class != tt.want {
+					ts.Require().NotErrorIs(err, class)
+				}
+			}
+			if tt.wantMsg != "" {
+				ts.Require().EqualError(tt.err, tt.wantMsg)
+			}
+		})
+	}
+}
+
+func (ts *testSuite) TestClassifyError() {
+	tests := []struct {
+		name string
+		err  error
+		want error
+	}{
+		{
+			name: "egoscale not found",
+			err:  exoapi.ErrNotFound,
+			want: ErrNotFound,
+		},
+		{
+			name: "egoscale invalid request",
+			err:  fmt.Errorf("%w: bad", exoapi.ErrInvalidRequest),
+			want: ErrInvalid,
+		},
+		{
+			name: "egoscale API error",
+			err:  fmt.Errorf("%w: oops", exoapi.ErrAPIError),
+			want: ErrTransient,
+		},
+		{
+			name: "timeout",
+			err:  context.DeadlineExceeded,
+			want: ErrTransient,
+		},
+	}
+
+	for _, tt := range tests {
+		ts.Run(tt.name, func() {
+			err := classifyError(tt.err)
+			ts.Require().ErrorIs(err, tt.want)
+			ts.Require().ErrorIs(err, tt.err)
+			ts.Require().EqualError(err, tt.err.Error())
+		})
+	}
+
+	ts.Require().NoError(classifyError(nil))
+
+	err := errors.New("boom")
+	ts.Require().Equal(err, classifyError(err))
+}
+
+func (ts *testSuite) TestClassifyAPIKeyError() {
+	tests := []struct {
+		name string
+		err  error
+		want error
+	}{
+		{
+			name: "key not in organization",
+			err:  &APIError{StatusCode: http.StatusBadRequest, Message: "API Key not in organization"},
+			want: ErrNotFound,
+		},
+		{
+			name: "invalid",
+			err:  &APIError{StatusCode: http.StatusBadRequest, Message: "invalid key"},
+			want: ErrInvalid,
+		},
+		{
+			name: "forbidden",
+			err:  &APIError{StatusCode: http.StatusForbidden, Message: "API Key not in organization"},
+			want: ErrForbidden,
+		},
+	}
+
+	for _, tt := range tests {
+		ts.Run(tt.name, func() {
+			err := classifyAPIKeyError(fmt.Errorf("delete: %w", tt.err))
+			ts.Require().ErrorIs(err, tt.want)
+			if tt.want != ErrNotFound {
+				ts.Require().NotErrorIs(err, ErrNotFound)
+			}
+		})
+	}
+}
+
+func (ts *testSuite) TestAPIErrorTransport() {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/missing":
+			w.WriteHeader(http.StatusBadRequest)
+			_, _ = w.Write([]byte(`{"message":"API Key not in organization"}`))
+		case "/text":
+			w.WriteHeader(http.StatusTooManyRequests)
+			_, _ = w.Write([]byte("slow down\n"))
+		default:
+			_, _ = w.Write([]byte(`{}`))
+		}
+	}))
+	defer server.Close()
+
+	client := &http.Client{Transport: &apiErrorTransport{next: http.DefaultTransport}}
+
+	resp, err := client.Get(server.URL + "/ok")
+	ts.Require().NoError(err)
+	ts.Require().NoError(resp.Body.Close())
+
+	_, err = client.Get(server.URL + "/missing")
+	ts.Require().ErrorIs(err, ErrInvalid)
+	ts.Require().ErrorIs(classifyAPIKeyError(err), ErrNotFound)
+	var apiErr *APIError
+	ts.Require().ErrorAs(err, &apiErr)
+	ts.Require().Equal(&APIError{StatusCode: http.StatusBadRequest, Message: "API Key not in organization"}, apiErr)
+
+	_, err = client.Get(server.URL + "/text")
+	ts.Require().ErrorIs(err, ErrRateLimited)
+	ts.Require().ErrorAs(err, &apiErr)
+	ts.Require().Equal("slow down", apiErr.Message)
+}
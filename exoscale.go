@@ -2,8 +2,10 @@ package exoscale
 
 import (
 	"context"
+	"encoding/base64"
 	"errors"
 	"fmt"
+	"strconv"
 	"strings"
 	"sync"
 	"time"
@@ -29,8 +31,53 @@ type egoscaleClient interface {
 
 	CreateApiKeyWithResponse(ctx context.Context, body oapi.CreateApiKeyJSONRequestBody, reqEditors ...oapi.RequestEditorFn) (*oapi.CreateApiKeyResponse, error)
 	DeleteApiKeyWithResponse(ctx context.Context, id string, reqEditors ...oapi.RequestEditorFn) (*oapi.DeleteApiKeyResponse, error)
+	GetApiKeyWithResponse(ctx context.Context, id string, reqEditors ...oapi.RequestEditorFn) (*oapi.GetApiKeyResponse, error)
 	GetIamRoleWithResponse(ctx context.Context, id string, reqEditors ...oapi.RequestEditorFn) (*oapi.GetIamRoleResponse, error)
 	ListIamRolesWithResponse(ctx context.Context, reqEditors ...oapi.RequestEditorFn) (*oapi.ListIamRolesResponse, error)
+	CreateIamRoleWithResponse(ctx context.Context, body oapi.CreateIamRoleJSONRequestBody, reqEditors ...oapi.RequestEditorFn) (*oapi.CreateIamRoleResponse, error)
+	DeleteIamRoleWithResponse(ctx context.Context, id string, reqEditors ...oapi.RequestEditorFn) (*oapi.DeleteIamRoleResponse, error)
+	GetOperationWithResponse(ctx context.Context, id string, reqEditors ...oapi.RequestEditorFn) (*oapi.GetOperationResponse, error)
+	ListApiKeysWithResponse(ctx context.Context, reqEditors ...oapi.RequestEditorFn) (*oapi.ListApiKeysResponse, error)
+	ListAccessKeysWithResponse(ctx context.Context, reqEditors ...oapi.RequestEditorFn) (*oapi.ListAccessKeysResponse, error)
+	ListQuotasWithResponse(ctx context.Context, reqEditors ...oapi.RequestEditorFn) (*oapi.ListQuotasResponse, error)
+	GetQuotaWithResponse(ctx context.Context, entity string, reqEditors ...oapi.RequestEditorFn) (*oapi.GetQuotaResponse, error)
+	GenerateSksClusterKubeconfigWithResponse(ctx context.Context, id string, body oapi.GenerateSksClusterKubeconfigJSONRequestBody, reqEditors ...oapi.RequestEditorFn) (*oapi.GenerateSksClusterKubeconfigResponse, error)
+	CreateDbaasPostgresUserWithResponse(ctx context.Context, serviceName oapi.DbaasServiceName, body oapi.CreateDbaasPostgresUserJSONRequestBody, reqEditors ...oapi.RequestEditorFn) (*oapi.CreateDbaasPostgresUserResponse, error)
+	CreateDbaasMysqlUserWithResponse(ctx context.Context, serviceName oapi.DbaasServiceName, body oapi.CreateDbaasMysqlUserJSONRequestBody, reqEditors ...oapi.RequestEditorFn) (*oapi.CreateDbaasMysqlUserResponse, error)
+	CreateDbaasKafkaUserWithResponse(ctx context.Context, serviceName oapi.DbaasServiceName, body oapi.CreateDbaasKafkaUserJSONRequestBody, reqEditors ...oapi.RequestEditorFn) (*oapi.CreateDbaasKafkaUserResponse, error)
+	CreateDbaasOpensearchUserWithResponse(ctx context.Context, serviceName oapi.DbaasServiceName, body oapi.CreateDbaasOpensearchUserJSONRequestBody, reqEditors ...oapi.RequestEditorFn) (*oapi.CreateDbaasOpensearchUserResponse, error)
+	DeleteDbaasPostgresUserWithResponse(ctx context.Context, serviceName oapi.DbaasServiceName, username oapi.DbaasUserUsername, reqEditors ...oapi.RequestEditorFn) (*oapi.DeleteDbaasPostgresUserResponse, error)
+	DeleteDbaasMysqlUserWithResponse(ctx context.Context, serviceName oapi.DbaasServiceName, username oapi.DbaasUserUsername, reqEditors ...oapi.RequestEditorFn) (*oapi.DeleteDbaasMysqlUserResponse, error)
+	DeleteDbaasKafkaUserWithResponse(ctx context.Context, serviceName oapi.DbaasServiceName, username oapi.DbaasUserUsername, reqEditors ...oapi.RequestEditorFn) (*oapi.DeleteDbaasKafkaUserResponse, error)
+	DeleteDbaasOpensearchUserWithResponse(ctx context.Context, serviceName oapi.DbaasServiceName, username oapi.DbaasUserUsername, reqEditors ...oapi.RequestEditorFn) (*oapi.DeleteDbaasOpensearchUserResponse, error)
+	ResetDbaasPostgresUserPasswordWithResponse(ctx context.Context, serviceName oapi.DbaasServiceName, username oapi.DbaasUserUsername, body oapi.ResetDbaasPostgresUserPasswordJSONRequestBody, reqEditors ...oapi.RequestEditorFn) (*oapi.ResetDbaasPostgresUserPasswordResponse, error)
+	ResetDbaasMysqlUserPasswordWithResponse(ctx context.Context, serviceName oapi.DbaasServiceName, username oapi.DbaasUserUsername, body oapi.ResetDbaasMysqlUserPasswordJSONRequestBody, reqEditors ...oapi.RequestEditorFn) (*oapi.ResetDbaasMysqlUserPasswordResponse, error)
+	ResetDbaasKafkaUserPasswordWithResponse(ctx context.Context, serviceName oapi.DbaasServiceName, username oapi.DbaasUserUsername, body oapi.ResetDbaasKafkaUserPasswordJSONRequestBody, reqEditors ...oapi.RequestEditorFn) (*oapi.ResetDbaasKafkaUserPasswordResponse, error)
+	ResetDbaasOpensearchUserPasswordWithResponse(ctx context.Context, serviceName oapi.DbaasServiceName, username oapi.DbaasUserUsername, body oapi.ResetDbaasOpensearchUserPasswordJSONRequestBody, reqEditors ...oapi.RequestEditorFn) (*oapi.ResetDbaasOpensearchUserPasswordResponse, error)
+	CreateDbaasKafkaTopicAclConfigWithResponse(ctx context.Context, name oapi.DbaasServiceName, body oapi.CreateDbaasKafkaTopicAclConfigJSONRequestBody, reqEditors ...oapi.RequestEditorFn) (*oapi.CreateDbaasKafkaTopicAclConfigResponse, error)
+	DeleteDbaasKafkaTopicAclConfigWithResponse(ctx context.Context, name oapi.DbaasServiceName, aclId oapi.DbaasKafkaAclId, reqEditors ...oapi.RequestEditorFn) (*oapi.DeleteDbaasKafkaTopicAclConfigResponse, error)
+	GetDbaasKafkaAclConfigWithResponse(ctx context.Context, name oapi.DbaasServiceName, reqEditors ...oapi.RequestEditorFn) (*oapi.GetDbaasKafkaAclConfigResponse, error)
+	GetDbaasServicePgWithResponse(ctx context.Context, name oapi.DbaasServiceName, reqEditors ...oapi.RequestEditorFn) (*oapi.GetDbaasServicePgResponse, error)
+	GetDbaasServiceMysqlWithResponse(ctx context.Context, name oapi.DbaasServiceName, reqEditors ...oapi.RequestEditorFn) (*oapi.GetDbaasServiceMysqlResponse, error)
+	GetDbaasServiceKafkaWithResponse(ctx context.Context, name oapi.DbaasServiceName, reqEditors ...oapi.RequestEditorFn) (*oapi.GetDbaasServiceKafkaResponse, error)
+	GetDbaasServiceOpensearchWithResponse(ctx context.Context, name oapi.DbaasServiceName, reqEditors ...oapi.RequestEditorFn) (*oapi.GetDbaasServiceOpensearchResponse, error)
+	GetSosPresignedUrlWithResponse(ctx context.Context, bucket string, params *oapi.GetSosPresignedUrlParams, reqEditors ...oapi.RequestEditorFn) (*oapi.GetSosPresignedUrlResponse, error)
+	GetInstanceWithResponse(ctx context.Context, id string, reqEditors ...oapi.RequestEditorFn) (*oapi.GetInstanceResponse, error)
+	RevealInstancePasswordWithResponse(ctx context.Context, id string, reqEditors ...oapi.RequestEditorFn) (*oapi.RevealInstancePasswordResponse, error)
+	RegisterSshKeyWithResponse(ctx context.Context, body oapi.RegisterSshKeyJSONRequestBody, reqEditors ...oapi.RequestEditorFn) (*oapi.RegisterSshKeyResponse, error)
+	DeleteSshKeyWithResponse(ctx context.Context, name string, reqEditors ...oapi.RequestEditorFn) (*oapi.DeleteSshKeyResponse, error)
+	GetSecurityGroupWithResponse(ctx context.Context, id string, reqEditors ...oapi.RequestEditorFn) (*oapi.GetSecurityGroupResponse, error)
+	AddRuleToSecurityGroupWithResponse(ctx context.Context, id string, body oapi.AddRuleToSecurityGroupJSONRequestBody, reqEditors ...oapi.RequestEditorFn) (*oapi.AddRuleToSecurityGroupResponse, error)
+	DeleteRuleFromSecurityGroupWithResponse(ctx context.Context, id string, ruleId string, reqEditors ...oapi.RequestEditorFn) (*oapi.DeleteRuleFromSecurityGroupResponse, error)
+}
+
+// userAgentOnce ensures the plugin identifies itself only once in the egoscale User-Agent
+var userAgentOnce sync.Once
+
+// newEgoscaleClient returns the client used to perform API calls, it is
+// replaced by a mock for testing
+var newEgoscaleClient = func(cfg ExoscaleConfig) (egoscaleClient, error) {
+	return egoscale.NewClient(cfg.RootAPIKey, cfg.RootAPISecret, egoscale.ClientOptWithHTTPClient(newHTTPClient(cfg)))
 }
 
 // Exoscale is an abstraction over the Exoscale API
@@ -44,26 +91,31 @@ type Exoscale struct {
 
 	configured       bool
 	apiKeyNamePrefix string
+
+	operationTimeout      time.Duration
+	operationPollInterval time.Duration
+
+	quotaWarningThreshold int
+	nameTemplate          string
 }
 
-func (e *Exoscale) LoadConfigFromStorage(ctx context.Context, storage logical.Storage) error {
-	var config ExoscaleConfig
+const (
+	defaultOperationTimeout      = 2 * time.Minute
+	defaultOperationPollInterval = time.Second
+	maxOperationPollInterval     = 10 * time.Second
+)
 
-	entry, err := storage.Get(ctx, configRootStoragePath)
+func (e *Exoscale) LoadConfigFromStorage(ctx context.Context, storage logical.Storage) error {
+	config, err := getRootConfig(ctx, storage)
 	if err != nil {
-		return fmt.Errorf("unable to retrieve backend config from storage: %w", err)
-
+		return err
 	}
 
-	if entry == nil {
+	if config == nil {
 		return nil
 	}
 
-	if err := entry.DecodeJSON(&config); err != nil {
-		return fmt.Errorf("failed to decode backend config %w", err)
-	}
-
-	if err := e.LoadConfig(config); err != nil {
+	if err := e.LoadConfig(*config); err != nil {
 		return err
 	}
 
@@ -71,7 +123,7 @@ func (e *Exoscale) LoadConfigFromStorage(ctx context.Context, storage logical.St
 }
 
 func (e *Exoscale) LoadConfig(cfg ExoscaleConfig) error {
-	exo, err := egoscale.NewClient(cfg.RootAPIKey, cfg.RootAPISecret)
+	exo, err := newEgoscaleClient(cfg)
 	if err != nil {
 		return fmt.Errorf("unable to initialize Exoscale client: %w", err)
 	}
@@ -79,19 +131,92 @@ func (e *Exoscale) LoadConfig(cfg ExoscaleConfig) error {
 	reqEndpoint := exoapi.NewReqEndpoint(cfg.APIEnvironment, cfg.Zone)
 
 	e.Lock()
-	egoscale.UserAgent = fmt.Sprintf("Exoscale-Vault-Plugin-Secrets/%s (%s) %s",
-		version.Version, version.Commit, egoscale.UserAgent)
+	userAgentOnce.Do(func() {
+		egoscale.UserAgent = fmt.Sprintf("Exoscale-Vault-Plugin-Secrets/%s (%s) %s",
+			version.Version, version.Commit, egoscale.UserAgent)
+	})
 	e.egoscaleClient = exo
 	e.reqEndpoint = reqEndpoint
 	e.configured = true
 	e.apiKey = cfg.RootAPIKey
 	e.apiSecret = cfg.RootAPISecret
 	e.apiKeyNamePrefix = cfg.APIKeyNamePrefix
+	e.operationTimeout = cfg.OperationTimeout
+	if e.operationTimeout == 0 {
+		e.operationTimeout = defaultOperationTimeout
+	}
+	e.operationPollInterval = cfg.OperationPollInterval
+	if e.operationPollInterval == 0 {
+		e.operationPollInterval = defaultOperationPollInterval
+	}
+	e.quotaWarningThreshold = cfg.QuotaWarningThreshold
+	e.nameTemplate = cfg.NameTemplate
 	e.Unlock()
 
 	return nil
 }
 
+// isConfigured returns whether a configuration has been loaded
+func (e *Exoscale) isConfigured() bool {
+	e.RLock()
+	defer e.RUnlock()
+
+	return e.configured
+}
+
+// keyNamePrefix returns the api_key_name_prefix of the configuration
+func (e *Exoscale) keyNamePrefix() string {
+	e.RLock()
+	defer e.RUnlock()
+
+	return e.apiKeyNamePrefix
+}
+
+// v2KeyNameSuffix distinguishes the names of IAMv2 Access Keys
+const v2KeyNameSuffix = "-deprecated"
+
+// resourceName returns the name of a resource created by Vault, generated with the name
+// template of the role if set, or else with the one of the configuration
+func (e *Exoscale) resourceName(data nameData, roleNameTemplate string) (string, error) {
+	e.RLock()
+	data.Prefix = e.apiKeyNamePrefix
+	text := e.nameTemplate
+	e.RUnlock()
+
+	if roleNameTemplate != "" {
+		text = roleNameTemplate
+	}
+
+	return renderName(text, data)
+}
+
+// parseResourceName returns the creation time of a resource named by the default name template,
+// ok is false if the name doesn't follow the naming scheme of this backend.
+func (e *Exoscale) parseResourceName(name string) (created time.Time, ok bool) {
+	e.RLock()
+	defer e.RUnlock()
+
+	var prefix string
+	if e.apiKeyNamePrefix != "" {
+		prefix = e.apiKeyNamePrefix + "-"
+	}
+
+	if !strings.HasPrefix(name, "vault-"+prefix) {
+		return time.Time{}, false
+	}
+
+	i := strings.LastIndex(name, "-")
+	if i < 0 || len(name)-i-1 != 19 {
+		return time.Time{}, false
+	}
+	ns, err := strconv.ParseInt(name[i+1:], 10, 64)
+	if err != nil {
+		return time.Time{}, false
+	}
+
+	return time.Unix(0, ns), true
+}
+
 // v2ParseIAMResource parses a string-encoded IAM access key resource formatted such as
 // DOMAIN/TYPE:NAME and deserializes it into an egoscale.IAMAccessKeyResource struct.
 func V2ParseIAMResource(v string) (*egoscale.IAMAccessKeyResource, error) {
@@ -120,12 +245,10 @@ func V2ParseIAMResource(v string) (*egoscale.IAMAccessKeyResource, error) {
 }
 
 // V2CreateAccessKey creates a IAMv2 Access Key
-func (e *Exoscale) V2CreateAccessKey(ctx context.Context, roleName string, reqDisplayName string, role Role) (*egoscale.IAMAccessKey, error) {
-	e.RLock()
-	defer e.RUnlock()
-
-	if !e.configured {
-		return nil, ErrorBackendNotConfigured
+func (e *Exoscale) V2CreateAccessKey(ctx context.Context, name string, role Role) (*egoscale.IAMAccessKey, error) {
+	c, err := e.client("")
+	if err != nil {
+		return nil, err
 	}
 
 	opts := make([]egoscale.CreateIAMAccessKeyOpt, 0)
@@ -149,19 +272,14 @@ func (e *Exoscale) V2CreateAccessKey(ctx context.Context, roleName string, reqDi
 		opts = append(opts, egoscale.CreateIAMAccessKeyWithTags(role.Tags))
 	}
 
-	var prefix string
-	if e.apiKeyNamePrefix != "" {
-		prefix = e.apiKeyNamePrefix + "-"
-	}
-
-	iamAPIKey, err := e.CreateIAMAccessKey(
-		exoapi.WithEndpoint(ctx, e.reqEndpoint),
-		e.reqEndpoint.Zone(),
-		fmt.Sprintf("vault-%s%s-%s-%d-deprecated", prefix, roleName, reqDisplayName, time.Now().UnixNano()),
+	iamAPIKey, err := c.CreateIAMAccessKey(
+		exoapi.WithEndpoint(ctx, c.reqEndpoint),
+		c.reqEndpoint.Zone(),
+		name+v2KeyNameSuffix,
 		opts...,
 	)
 	if err != nil {
-		return nil, fmt.Errorf("unable to create a new API key: %w", err)
+		return nil, fmt.Errorf("unable to create a new API key: %w", classifyError(err))
 	}
 
 	return iamAPIKey, nil
@@ -169,36 +287,28 @@ func (e *Exoscale) V2CreateAccessKey(ctx context.Context, roleName string, reqDi
 
 // V2RevokeAccessKey revokes a IAMv2 Access Key
 func (e *Exoscale) V2RevokeAccessKey(ctx context.Context, key string) error {
-	e.RLock()
-	defer e.RUnlock()
-
-	if !e.configured {
-		return ErrorBackendNotConfigured
+	c, err := e.client("")
+	if err != nil {
+		return err
 	}
 
-	return e.RevokeIAMAccessKey(exoapi.WithEndpoint(ctx, e.reqEndpoint), e.reqEndpoint.Zone(), &egoscale.IAMAccessKey{Key: &key})
+	err = c.RevokeIAMAccessKey(exoapi.WithEndpoint(ctx, c.reqEndpoint), c.reqEndpoint.Zone(), &egoscale.IAMAccessKey{Key: &key})
+	return classifyError(err)
 }
 
 // V3CreateAPIKey creates a IAMv3 API Key
-func (e *Exoscale) V3CreateAPIKey(ctx context.Context, roleName string, reqDisplayName string, role Role) (*oapi.IamApiKeyCreated, error) {
-	e.RLock()
-	defer e.RUnlock()
-
-	var prefix string
-	if e.apiKeyNamePrefix != "" {
-		prefix = e.apiKeyNamePrefix + "-"
-	}
-
-	if !e.configured {
-		return nil, ErrorBackendNotConfigured
+func (e *Exoscale) V3CreateAPIKey(ctx context.Context, name string, role Role) (*oapi.IamApiKeyCreated, error) {
+	c, err := e.client("")
+	if err != nil {
+		return nil, err
 	}
 
-	resp, err := e.CreateApiKeyWithResponse(exoapi.WithEndpoint(ctx, e.reqEndpoint), oapi.CreateApiKeyJSONRequestBody{
-		Name:   fmt.Sprintf("vault-%s%s-%s-%d", prefix, roleName, reqDisplayName, time.Now().UnixNano()),
+	resp, err := c.CreateApiKeyWithResponse(exoapi.WithEndpoint(ctx, c.reqEndpoint), oapi.CreateApiKeyJSONRequestBody{
+		Name:   name,
 		RoleId: role.IAMRoleID,
 	})
 	if err != nil {
-		return nil, fmt.Errorf("failed to create api key: %w", err)
+		return nil, fmt.Errorf("failed to create api key: %w", classifyError(err))
 	}
 
 	return resp.JSON200, nil
@@ -206,19 +316,17 @@ func (e *Exoscale) V3CreateAPIKey(ctx context.Context, roleName string, reqDispl
 
 // V3DeleteAPIKey deletes a IAMv3 API Key
 func (e *Exoscale) V3DeleteAPIKey(ctx context.Context, key string) error {
-	e.RLock()
-	defer e.RUnlock()
-
-	if !e.configured {
-		return ErrorBackendNotConfigured
+	c, err := e.client("")
+	if err != nil {
+		return err
 	}
 
-	resp, err := e.DeleteApiKeyWithResponse(exoapi.WithEndpoint(ctx, e.reqEndpoint), key)
+	resp, err := c.DeleteApiKeyWithResponse(exoapi.WithEndpoint(ctx, c.reqEndpoint), key)
 	if err != nil {
-		return fmt.Errorf("failed to delete api key %q: %w", key, err)
+		return fmt.Errorf("failed to delete api key %q: %w", key, classifyAPIKeyError(err))
 	}
-	if *resp.JSON200.State != oapi.OperationStateSuccess { // TODO(antoine): check if the state is "pending" and poll
-		return errors.New(*resp.JSON200.Message)
+	if _, err := c.waitOperation(ctx, resp.JSON200); err != nil {
+		return fmt.Errorf("failed to delete api key %q: %w", key, classifyError(err))
 	}
 
 	return nil
@@ -226,25 +334,22 @@ func (e *Exoscale) V3DeleteAPIKey(ctx context.Context, key string) error {
 
 // V3GetRole takes a role ID or name and returns a role ID if that role exists
 func (e *Exoscale) V3GetRole(ctx context.Context, role string) (*oapi.IamRole, error) {
-	e.RLock()
-	defer e.RUnlock()
-
-	if !e.configured {
-		return nil, ErrorBackendNotConfigured
+	c, err := e.client("")
+	if err != nil {
+		return nil, err
 	}
 
-	_, err := uuid.ParseUUID(role)
-	if err == nil {
-		rolebyid, err := e.GetIamRoleWithResponse(exoapi.WithEndpoint(ctx, e.reqEndpoint), role)
+	if _, err := uuid.ParseUUID(role); err == nil {
+		rolebyid, err := c.GetIamRoleWithResponse(exoapi.WithEndpoint(ctx, c.reqEndpoint), role)
 		if err != nil {
-			return nil, fmt.Errorf("failed to fetch role %q by ID: %w", role, err)
+			return nil, fmt.Errorf("failed to fetch role %q by ID: %w", role, classifyError(err))
 		}
 		return rolebyid.JSON200, nil
 	}
 
-	allroles, err := e.ListIamRolesWithResponse(exoapi.WithEndpoint(ctx, e.reqEndpoint))
+	allroles, err := c.ListIamRolesWithResponse(exoapi.WithEndpoint(ctx, c.reqEndpoint))
 	if err != nil {
-		return nil, fmt.Errorf("failed to list roles: %w", err)
+		return nil, fmt.Errorf("failed to list roles: %w", classifyError(err))
 	}
 
 	if allroles.JSON200 != nil && allroles.JSON200.IamRoles != nil {
@@ -255,5 +360,304 @@ func (e *Exoscale) V3GetRole(ctx context.Context, role string) (*oapi.IamRole, e
 		}
 	}
 
-	return nil, fmt.Errorf("role %q not found", role)
+	return nil, fmt.Errorf("role %q: %w", role, ErrNotFound)
+}
+
+// V3CreateRole creates a IAMv3 Role dedicated to a single API key and returns its ID
+func (e *Exoscale) V3CreateRole(ctx context.Context, name string, roleName string, policy oapi.IamPolicy) (string, error) {
+	c, err := e.client("")
+	if err != nil {
+		return "", err
+	}
+
+	description := fmt.Sprintf("Managed by Vault for the %q role, deleted along with its API key", roleName)
+	resp, err := c.CreateIamRoleWithResponse(exoapi.WithEndpoint(ctx, c.reqEndpoint), oapi.CreateIamRoleJSONRequestBody{
+		Name:        name,
+		Description: &description,
+		Policy:      &policy,
+	})
+	if err != nil {
+		return "", fmt.Errorf("failed to create role: %w", classifyError(err))
+	}
+	op, err := c.waitOperation(ctx, resp.JSON200)
+	if err != nil {
+		return "", fmt.Errorf("failed to create role: %w", classifyError(err))
+	}
+	if op.Reference == nil || op.Reference.Id == nil {
+		return "", errors.New("failed to create role: missing role ID in API response")
+	}
+
+	return *op.Reference.Id, nil
+}
+
+// V3DeleteRole deletes a IAMv3 Role
+func (e *Exoscale) V3DeleteRole(ctx context.Context, id string) error {
+	c, err := e.client("")
+	if err != nil {
+		return err
+	}
+
+	resp, err := c.DeleteIamRoleWithResponse(exoapi.WithEndpoint(ctx, c.reqEndpoint), id)
+	if err != nil {
+		return fmt.Errorf("failed to delete role %q: %w", id, classifyError(err))
+	}
+	if _, err := c.waitOperation(ctx, resp.JSON200); err != nil {
+		return fmt.Errorf("failed to delete role %q: %w", id, classifyError(err))
+	}
+
+	return nil
+}
+
+// V3GetAPIKey returns a IAMv3 API Key
+func (e *Exoscale) V3GetAPIKey(ctx context.Context, key string) (*oapi.IamApiKey, error) {
+	c, err := e.client("")
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := c.GetApiKeyWithResponse(exoapi.WithEndpoint(ctx, c.reqEndpoint), key)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch api key %q: %w", key, classifyAPIKeyError(err))
+	}
+
+	return resp.JSON200, nil
+}
+
+// V3ListAPIKeys returns the IAMv3 API Keys of the organization
+func (e *Exoscale) V3ListAPIKeys(ctx context.Context) ([]oapi.IamApiKey, error) {
+	c, err := e.client("")
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := c.ListApiKeysWithResponse(exoapi.WithEndpoint(ctx, c.reqEndpoint))
+	if err != nil {
+		return nil, fmt.Errorf("failed to list api keys: %w", classifyError(err))
+	}
+	if resp.JSON200 == nil || resp.JSON200.ApiKeys == nil {
+		return nil, nil
+	}
+
+	return *resp.JSON200.ApiKeys, nil
+}
+
+// V2ListAccessKeys returns the IAMv2 Access Keys of the organization
+func (e *Exoscale) V2ListAccessKeys(ctx context.Context) ([]oapi.AccessKey, error) {
+	c, err := e.client("")
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := c.ListAccessKeysWithResponse(exoapi.WithEndpoint(ctx, c.reqEndpoint))
+	if err != nil {
+		return nil, fmt.Errorf("failed to list access keys: %w", classifyError(err))
+	}
+	if resp.JSON200 == nil || resp.JSON200.AccessKeys == nil {
+		return nil, nil
+	}
+
+	return *resp.JSON200.AccessKeys, nil
+}
+
+// V3ListQuotas returns the quotas of the organization
+func (e *Exoscale) V3ListQuotas(ctx context.Context) ([]oapi.Quota, error) {
+	c, err := e.client("")
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := c.ListQuotasWithResponse(exoapi.WithEndpoint(ctx, c.reqEndpoint))
+	if err != nil {
+		return nil, fmt.Errorf("failed to list quotas: %w", classifyError(err))
+	}
+	if resp.JSON200 == nil || resp.JSON200.Quotas == nil {
+		return nil, nil
+	}
+
+	return *resp.JSON200.Quotas, nil
+}
+
+// V3GetQuota returns the quota of the organization for a resource
+func (e *Exoscale) V3GetQuota(ctx context.Context, resource string) (*oapi.Quota, error) {
+	c, err := e.client("")
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := c.GetQuotaWithResponse(exoapi.WithEndpoint(ctx, c.reqEndpoint), resource)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch quota %q: %w", resource, classifyError(err))
+	}
+
+	return resp.JSON200, nil
+}
+
+// apiClient is a snapshot of the client and settings of a configuration
+type apiClient struct {
+	egoscaleClient
+	reqEndpoint exoapi.ReqEndpoint
+
+	operationTimeout      time.Duration
+	operationPollInterval time.Duration
+}
+
+// client returns a snapshot of the client for the API endpoint of a zone, the zone of the
+// configuration if empty. API calls are performed with the snapshot so that the lock isn't
+// held while they complete, which would block the reload of the configuration and thus
+// every other call.
+func (e *Exoscale) client(zone string) (*apiClient, error) {
+	e.RLock()
+	defer e.RUnlock()
+
+	if !e.configured {
+		return nil, ErrorBackendNotConfigured
+	}
+
+	c := &apiClient{
+		egoscaleClient:        e.egoscaleClient,
+		reqEndpoint:           e.reqEndpoint,
+		operationTimeout:      e.operationTimeout,
+		operationPollInterval: e.operationPollInterval,
+	}
+	if zone != "" {
+		c.reqEndpoint = exoapi.NewReqEndpoint(e.reqEndpoint.Env(), zone)
+	}
+
+	return c, nil
+}
+
+// V3GetSOSPresignedURL returns a presigned URL to download an object of an SOS bucket,
+// the bucket is looked up in the zone of the configuration if zone is empty
+func (e *Exoscale) V3GetSOSPresignedURL(ctx context.Context, zone, bucket, key string) (string, error) {
+	c, err := e.client(zone)
+	if err != nil {
+		return "", err
+	}
+
+	resp, err := c.GetSosPresignedUrlWithResponse(
+		exoapi.WithEndpoint(ctx, c.reqEndpoint),
+		bucket,
+		&oapi.GetSosPresignedUrlParams{Key: &key},
+	)
+	if err != nil {
+		return "", fmt.Errorf("failed to presign object %q of bucket %q: %w", key, bucket, classifyError(err))
+	}
+	if resp.JSON200 == nil || resp.JSON200.Url == nil {
+		return "", errors.New("no presigned URL returned by the API")
+	}
+
+	return *resp.JSON200.Url, nil
+}
+
+// V3GenerateSKSKubeconfig returns a kubeconfig for a user of an SKS cluster, whose certificate
+// is valid for ttl, the cluster is looked up in the zone of the configuration if zone is empty
+func (e *Exoscale) V3GenerateSKSKubeconfig(
+	ctx context.Context,
+	zone, clusterID, user string,
+	groups []string,
+	ttl time.Duration,
+) (string, error) {
+	c, err := e.client(zone)
+	if err != nil {
+		return "", err
+	}
+
+	seconds := int64(ttl.Seconds())
+	resp, err := c.GenerateSksClusterKubeconfigWithResponse(
+		exoapi.WithEndpoint(ctx, c.reqEndpoint),
+		clusterID,
+		oapi.GenerateSksClusterKubeconfigJSONRequestBody{
+			User:   &user,
+			Groups: &groups,
+			Ttl:    &seconds,
+		},
+	)
+	if err != nil {
+		return "", fmt.Errorf("failed to generate kubeconfig of SKS cluster %q: %w", clusterID, classifyError(err))
+	}
+	if resp.JSON200 == nil || resp.JSON200.Kubeconfig == nil {
+		return "", errors.New("no kubeconfig returned by the API")
+	}
+
+	// the kubeconfig is returned base64-encoded
+	kubeconfig, err := base64.StdEncoding.DecodeString(*resp.JSON200.Kubeconfig)
+	if err != nil {
+		return *resp.JSON200.Kubeconfig, nil
+	}
+
+	return string(kubeconfig), nil
+}
+
+// waitOperation polls an asynchronous operation with an exponential backoff
+// until it succeeds, fails, or operationTimeout is reached.
+func (c *apiClient) waitOperation(ctx context.Context, op *oapi.Operation) (*oapi.Operation, error) {
+	ctx, cancel := context.WithTimeout(ctx, c.operationTimeout)
+	defer cancel()
+
+	interval := c.operationPollInterval
+	for {
+		if op == nil || op.State == nil {
+			return nil, errors.New("invalid operation returned by the API")
+		}
+
+		switch *op.State {
+		case oapi.OperationStateSuccess:
+			return op, nil
+		case oapi.OperationStatePending:
+		default:
+			return nil, operationError(op)
+		}
+
+		if op.Id == nil {
+			return nil, errors.New("pending operation without ID returned by the API")
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil, fmt.Errorf("operation %q still pending: %w", *op.Id, ctx.Err())
+		case <-time.After(interval):
+		}
+		interval = min(2*interval, maxOperationPollInterval)
+
+		resp, err := c.GetOperationWithResponse(exoapi.WithEndpoint(ctx, c.reqEndpoint), *op.Id)
+		if err != nil {
+			return nil, fmt.Errorf("failed to poll operation %q: %w", *op.Id, classifyError(err))
+		}
+		op = resp.JSON200
+	}
+}
+
+// operationError describes the failure of an asynchronous operation
+func operationError(op *oapi.Operation) error {
+	msg := fmt.Sprintf("operation %s", *op.State)
+	if op.Id != nil {
+		msg = fmt.Sprintf("operation %q %s", *op.Id, *op.State)
+	}
+	if op.Reason != nil {
+		msg += fmt.Sprintf(" (%s)", *op.Reason)
+	}
+	if op.Message != nil && *op.Message != "" {
+		msg += ": " + *op.Message
+	}
+
+	err := errors.New(msg)
+	if op.Reason == nil {
+		return err
+	}
+
+	var class error
+	switch *op.Reason {
+	case oapi.OperationReasonNotFound:
+		class = ErrNotFound
+	case oapi.OperationReasonForbidden:
+		class = ErrForbidden
+	case oapi.OperationReasonBusy, oapi.OperationReasonInterrupted, oapi.OperationReasonUnavailable:
+		class = ErrTransient
+	case oapi.OperationReasonIncorrect, oapi.OperationReasonConflict, oapi.OperationReasonUnsupported:
+		class = ErrInvalid
+	default:
+		return err
+	}
+
+	return &classifiedError{class: class, err: err}
 }
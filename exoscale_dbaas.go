@@ -0,0 +1,344 @@
+package exoscale
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"strings"
+	"time"
+
+	exoapi "github.com/exoscale/egoscale/v2/api"
+	"github.com/exoscale/egoscale/v2/oapi"
+)
+
+const (
+	dbaasTypePg         = "pg"
+	dbaasTypeMysql      = "mysql"
+	dbaasTypeKafka      = "kafka"
+	dbaasTypeOpensearch = "opensearch"
+)
+
+// dbaasTypes are the types of DBaaS services supporting users
+var dbaasTypes = []string{dbaasTypePg, dbaasTypeMysql, dbaasTypeKafka, dbaasTypeOpensearch}
+
+// dbaasUser is a user of a DBaaS service and its credentials
+type dbaasUser struct {
+	Username string
+	Password string
+
+	// Kafka client certificate and key
+	AccessCert       string
+	AccessKey        string
+	AccessCertExpiry time.Time
+
+	// URI is the connection URI of the service with the credentials of the user
+	URI string
+}
+
+// V3CreateDBaaSUser creates a user of a DBaaS service, the service is looked up in the
+// zone of the configuration if zone is empty
+func (e *Exoscale) V3CreateDBaaSUser(ctx context.Context, zone, serviceType, service, username string) error {
+	c, err := e.client(zone)
+	if err != nil {
+		return err
+	}
+
+	ctx = exoapi.WithEndpoint(ctx, c.reqEndpoint)
+	name := oapi.DbaasServiceName(service)
+	user := oapi.DbaasUserUsername(username)
+
+	var op *oapi.Operation
+	switch serviceType {
+	case dbaasTypePg:
+		var resp *oapi.CreateDbaasPostgresUserResponse
+		resp, err = c.CreateDbaasPostgresUserWithResponse(ctx, name, oapi.CreateDbaasPostgresUserJSONRequestBody{Username: user})
+		if err == nil {
+			op = resp.JSON200
+		}
+	case dbaasTypeMysql:
+		var resp *oapi.CreateDbaasMysqlUserResponse
+		resp, err = c.CreateDbaasMysqlUserWithResponse(ctx, name, oapi.CreateDbaasMysqlUserJSONRequestBody{Username: user})
+		if err == nil {
+			op = resp.JSON200
+		}
+	case dbaasTypeKafka:
+		var resp *oapi.CreateDbaasKafkaUserResponse
+		resp, err = c.CreateDbaasKafkaUserWithResponse(ctx, name, oapi.CreateDbaasKafkaUserJSONRequestBody{Username: user})
+		if err == nil {
+			op = resp.JSON200
+		}
+	case dbaasTypeOpensearch:
+		var resp *oapi.CreateDbaasOpensearchUserResponse
+		resp, err = c.CreateDbaasOpensearchUserWithResponse(ctx, name, oapi.CreateDbaasOpensearchUserJSONRequestBody{Username: user})
+		if err == nil {
+			op = resp.JSON200
+		}
+	default:
+		return fmt.Errorf("unsupported DBaaS service type %q", serviceType)
+	}
+	if err == nil {
+		_, err = c.waitOperation(ctx, op)
+	}
+	if err != nil {
+		return fmt.Errorf("failed to create user %q of %s service %q: %w", username, serviceType, service, classifyError(err))
+	}
+
+	return nil
+}
+
+// V3DeleteDBaaSUser deletes a user of a DBaaS service
+func (e *Exoscale) V3DeleteDBaaSUser(ctx context.Context, zone, serviceType, service, username string) error {
+	c, err := e.client(zone)
+	if err != nil {
+		return err
+	}
+
+	ctx = exoapi.WithEndpoint(ctx, c.reqEndpoint)
+	name := oapi.DbaasServiceName(service)
+	user := oapi.DbaasUserUsername(username)
+
+	var op *oapi.Operation
+	switch serviceType {
+	case dbaasTypePg:
+		var resp *oapi.DeleteDbaasPostgresUserResponse
+		resp, err = c.DeleteDbaasPostgresUserWithResponse(ctx, name, user)
+		if err == nil {
+			op = resp.JSON200
+		}
+	case dbaasTypeMysql:
+		var resp *oapi.DeleteDbaasMysqlUserResponse
+		resp, err = c.DeleteDbaasMysqlUserWithResponse(ctx, name, user)
+		if err == nil {
+			op = resp.JSON200
+		}
+	case dbaasTypeKafka:
+		var resp *oapi.DeleteDbaasKafkaUserResponse
+		resp, err = c.DeleteDbaasKafkaUserWithResponse(ctx, name, user)
+		if err == nil {
+			op = resp.JSON200
+		}
+	case dbaasTypeOpensearch:
+		var resp *oapi.DeleteDbaasOpensearchUserResponse
+		resp, err = c.DeleteDbaasOpensearchUserWithResponse(ctx, name, user)
+		if err == nil {
+			op = resp.JSON200
+		}
+	default:
+		return fmt.Errorf("unsupported DBaaS service type %q", serviceType)
+	}
+	if err == nil {
+		_, err = c.waitOperation(ctx, op)
+	}
+	if err != nil {
+		return fmt.Errorf("failed to delete user %q of %s service %q: %w", username, serviceType, service, classifyError(err))
+	}
+
+	return nil
+}
+
+// V3ResetDBaaSUserPassword replaces the password of a user of a DBaaS service
+// with one generated by the API
+func (e *Exoscale) V3ResetDBaaSUserPassword(ctx context.Context, zone, serviceType, service, username string) error {
+	c, err := e.client(zone)
+	if err != nil {
+		return err
+	}
+
+	ctx = exoapi.WithEndpoint(ctx, c.reqEndpoint)
+	name := oapi.DbaasServiceName(service)
+	user := oapi.DbaasUserUsername(username)
+
+	var op *oapi.Operation
+	switch serviceType {
+	case dbaasTypePg:
+		var resp *oapi.ResetDbaasPostgresUserPasswordResponse
+		resp, err = c.ResetDbaasPostgresUserPasswordWithResponse(ctx, name, user, oapi.ResetDbaasPostgresUserPasswordJSONRequestBody{})
+		if err == nil {
+			op = resp.JSON200
+		}
+	case dbaasTypeMysql:
+		var resp *oapi.ResetDbaasMysqlUserPasswordResponse
+		resp, err = c.ResetDbaasMysqlUserPasswordWithResponse(ctx, name, user, oapi.ResetDbaasMysqlUserPasswordJSONRequestBody{})
+		if err == nil {
+			op = resp.JSON200
+		}
+	case dbaasTypeKafka:
+		var resp *oapi.ResetDbaasKafkaUserPasswordResponse
+		resp, err = c.ResetDbaasKafkaUserPasswordWithResponse(ctx, name, user, oapi.ResetDbaasKafkaUserPasswordJSONRequestBody{})
+		if err == nil {
+			op = resp.JSON200
+		}
+	case dbaasTypeOpensearch:
+		var resp *oapi.ResetDbaasOpensearchUserPasswordResponse
+		resp, err = c.ResetDbaasOpensearchUserPasswordWithResponse(ctx, name, user, oapi.ResetDbaasOpensearchUserPasswordJSONRequestBody{})
+		if err == nil {
+			op = resp.JSON200
+		}
+	default:
+		return fmt.Errorf("unsupported DBaaS service type %q", serviceType)
+	}
+	if err == nil {
+		_, err = c.waitOperation(ctx, op)
+	}
+	if err != nil {
+		return fmt.Errorf("failed to reset the password of user %q of %s service %q: %w",
+			username, serviceType, service, classifyError(err))
+	}
+
+	return nil
+}
+
+// V3CreateKafkaTopicACL grants a permission on the topics matching a pattern to a user
+// of a Kafka service
+func (e *Exoscale) V3CreateKafkaTopicACL(ctx context.Context, zone, service, username string, acl kafkaACL) error {
+	c, err := e.client(zone)
+	if err != nil {
+		return err
+	}
+
+	resp, err := c.CreateDbaasKafkaTopicAclConfigWithResponse(
+		exoapi.WithEndpoint(ctx, c.reqEndpoint),
+		oapi.DbaasServiceName(service),
+		oapi.CreateDbaasKafkaTopicAclConfigJSONRequestBody{
+			Username:   username,
+			Topic:      acl.Topic,
+			Permission: oapi.DbaasKafkaTopicAclEntryPermission(acl.Permission),
+		},
+	)
+	if err == nil {
+		_, err = c.waitOperation(ctx, resp.JSON200)
+	}
+	if err != nil {
+		return fmt.Errorf("failed to grant %s on topics %q to user %q of kafka service %q: %w",
+			acl.Permission, acl.Topic, username, service, classifyError(err))
+	}
+
+	return nil
+}
+
+// V3DeleteKafkaTopicACLs deletes the topic ACLs of a user of a Kafka service
+func (e *Exoscale) V3DeleteKafkaTopicACLs(ctx context.Context, zone, service, username string) error {
+	c, err := e.client(zone)
+	if err != nil {
+		return err
+	}
+
+	ctx = exoapi.WithEndpoint(ctx, c.reqEndpoint)
+	name := oapi.DbaasServiceName(service)
+
+	resp, err := c.GetDbaasKafkaAclConfigWithResponse(ctx, name)
+	if err != nil {
+		return fmt.Errorf("failed to list the ACLs of kafka service %q: %w", service, classifyError(err))
+	}
+	if resp.JSON200 == nil {
+		return nil
+	}
+
+	for _, acl := range valueOrZero(resp.JSON200.TopicAcl) {
+		if acl.Username != username || acl.Id == nil {
+			continue
+		}
+
+		resp, err := c.DeleteDbaasKafkaTopicAclConfigWithResponse(ctx, name, *acl.Id)
+		if err == nil {
+			_, err = c.waitOperation(ctx, resp.JSON200)
+		}
+		if err != nil {
+			return fmt.Errorf("failed to delete ACL %q of kafka service %q: %w", *acl.Id, service, classifyError(err))
+		}
+	}
+
+	return nil
+}
+
+// V3GetDBaaSUser returns a user of a DBaaS service and its credentials
+func (e *Exoscale) V3GetDBaaSUser(ctx context.Context, zone, serviceType, service, username string) (*dbaasUser, error) {
+	c, err := e.client(zone)
+	if err != nil {
+		return nil, err
+	}
+
+	ctx = exoapi.WithEndpoint(ctx, c.reqEndpoint)
+	name := oapi.DbaasServiceName(service)
+
+	var user *dbaasUser
+	var serviceURI *string
+	switch serviceType {
+	case dbaasTypePg:
+		var resp *oapi.GetDbaasServicePgResponse
+		if resp, err = c.GetDbaasServicePgWithResponse(ctx, name); err == nil && resp.JSON200 != nil {
+			serviceURI = resp.JSON200.Uri
+			for _, u := range valueOrZero(resp.JSON200.Users) {
+				if u.Username == username {
+					user = &dbaasUser{Username: u.Username, Password: valueOrZero(u.Password)}
+				}
+			}
+		}
+	case dbaasTypeMysql:
+		var resp *oapi.GetDbaasServiceMysqlResponse
+		if resp, err = c.GetDbaasServiceMysqlWithResponse(ctx, name); err == nil && resp.JSON200 != nil {
+			serviceURI = resp.JSON200.Uri
+			for _, u := range valueOrZero(resp.JSON200.Users) {
+				if valueOrZero(u.Username) == username {
+					user = &dbaasUser{Username: username, Password: valueOrZero(u.Password)}
+				}
+			}
+		}
+	case dbaasTypeKafka:
+		var resp *oapi.GetDbaasServiceKafkaResponse
+		if resp, err = c.GetDbaasServiceKafkaWithResponse(ctx, name); err == nil && resp.JSON200 != nil {
+			serviceURI = resp.JSON200.Uri
+			for _, u := range valueOrZero(resp.JSON200.Users) {
+				if valueOrZero(u.Username) == username {
+					user = &dbaasUser{
+						Username:         username,
+						Password:         valueOrZero(u.Password),
+						AccessCert:       valueOrZero(u.AccessCert),
+						AccessKey:        valueOrZero(u.AccessKey),
+						AccessCertExpiry: valueOrZero(u.AccessCertExpiry),
+					}
+				}
+			}
+		}
+	case dbaasTypeOpensearch:
+		var resp *oapi.GetDbaasServiceOpensearchResponse
+		if resp, err = c.GetDbaasServiceOpensearchWithResponse(ctx, name); err == nil && resp.JSON200 != nil {
+			serviceURI = resp.JSON200.Uri
+			for _, u := range valueOrZero(resp.JSON200.Users) {
+				if valueOrZero(u.Username) == username {
+					user = &dbaasUser{Username: username, Password: valueOrZero(u.Password)}
+				}
+			}
+		}
+	default:
+		return nil, fmt.Errorf("unsupported DBaaS service type %q", serviceType)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch %s service %q: %w", serviceType, service, classifyError(err))
+	}
+	if user == nil {
+		return nil, fmt.Errorf("user %q of %s service %q: %w", username, serviceType, service, ErrNotFound)
+	}
+
+	user.URI = dbaasUserURI(valueOrZero(serviceURI), user.Username, user.Password)
+
+	return user, nil
+}
+
+// dbaasUserURI replaces the credentials of the admin user in the connection URI of
+// a service with the ones of a user, the URI is dropped if it can't be done safely
+func dbaasUserURI(serviceURI, username, password string) string {
+	u, err := url.Parse(serviceURI)
+	if err != nil {
+		return ""
+	}
+	if u.User == nil {
+		if strings.Contains(serviceURI, "@") {
+			return ""
+		}
+		return serviceURI
+	}
+
+	u.User = url.UserPassword(username, password)
+	return u.String()
+}
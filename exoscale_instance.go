@@ -0,0 +1,51 @@
+package exoscale
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	exoapi "github.com/exoscale/egoscale/v2/api"
+)
+
+// V3GetInstanceLabels returns the labels of a compute instance, the instance is looked up
+// in the zone of the configuration if zone is empty
+func (e *Exoscale) V3GetInstanceLabels(ctx context.Context, zone, id string) (map[string]string, error) {
+	c, err := e.client(zone)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := c.GetInstanceWithResponse(exoapi.WithEndpoint(ctx, c.reqEndpoint), id)
+	if err != nil {
+		return nil, fmt.Errorf("failed to retrieve instance %q: %w", id, classifyError(err))
+	}
+	if resp.JSON200 == nil {
+		return nil, errors.New("no instance returned by the API")
+	}
+
+	if resp.JSON200.Labels == nil {
+		return map[string]string{}, nil
+	}
+	return resp.JSON200.Labels.AdditionalProperties, nil
+}
+
+// V3RevealInstancePassword returns the password set on a compute instance at creation or by
+// the latest password reset, the instance is looked up in the zone of the configuration if
+// zone is empty
+func (e *Exoscale) V3RevealInstancePassword(ctx context.Context, zone, id string) (string, error) {
+	c, err := e.client(zone)
+	if err != nil {
+		return "", err
+	}
+
+	resp, err := c.RevealInstancePasswordWithResponse(exoapi.WithEndpoint(ctx, c.reqEndpoint), id)
+	if err != nil {
+		return "", fmt.Errorf("failed to reveal the password of instance %q: %w", id, classifyError(err))
+	}
+	if resp.JSON200 == nil || resp.JSON200.Password == nil {
+		return "", fmt.Errorf("no password available for instance %q: %w", id, ErrNotFound)
+	}
+
+	return *resp.JSON200.Password, nil
+}
@@ -0,0 +1,121 @@
+package exoscale
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	exoapi "github.com/exoscale/egoscale/v2/api"
+	"github.com/exoscale/egoscale/v2/oapi"
+)
+
+// securityGroupRule is an ingress rule of a security group
+type securityGroupRule struct {
+	Protocol    string
+	Network     string
+	StartPort   int64
+	EndPort     int64
+	Description string
+}
+
+// matches reports whether a rule of a security group is the ingress rule r
+func (r *securityGroupRule) matches(sgRule oapi.SecurityGroupRule) bool {
+	return sgRule.Id != nil &&
+		valueOrZero(sgRule.Description) == r.Description &&
+		valueOrZero(sgRule.Network) == r.Network &&
+		string(valueOrZero(sgRule.Protocol)) == r.Protocol &&
+		valueOrZero(sgRule.StartPort) == r.StartPort &&
+		valueOrZero(sgRule.EndPort) == r.EndPort
+}
+
+// V3AddSecurityGroupRule adds an ingress rule to a security group and returns its ID,
+// the description of the rule must be unique within the security group. If the rule
+// can't be found back once added, it is deleted so that it isn't left without a lease.
+func (e *Exoscale) V3AddSecurityGroupRule(ctx context.Context, securityGroupID string, rule securityGroupRule) (string, error) {
+	c, err := e.client("")
+	if err != nil {
+		return "", err
+	}
+
+	ctx = exoapi.WithEndpoint(ctx, c.reqEndpoint)
+
+	resp, err := c.AddRuleToSecurityGroupWithResponse(ctx, securityGroupID, oapi.AddRuleToSecurityGroupJSONRequestBody{
+		Description:   &rule.Description,
+		FlowDirection: "ingress",
+		Protocol:      oapi.AddRuleToSecurityGroupJSONBodyProtocol(rule.Protocol),
+		Network:       &rule.Network,
+		StartPort:     &rule.StartPort,
+		EndPort:       &rule.EndPort,
+	})
+	if err != nil {
+		return "", fmt.Errorf("failed to add rule to security group %q: %w", securityGroupID, classifyError(err))
+	}
+	if _, err := c.waitOperation(ctx, resp.JSON200); err != nil {
+		err = fmt.Errorf("failed to add rule to security group %q: %w", securityGroupID, classifyError(err))
+		return "", errors.Join(err, c.deleteSecurityGroupRules(ctx, securityGroupID, rule.Description))
+	}
+
+	// the operation references the security group, the rule is found back by its description
+	sg, err := c.GetSecurityGroupWithResponse(ctx, securityGroupID)
+	if err != nil {
+		err = fmt.Errorf("failed to retrieve security group %q: %w", securityGroupID, classifyError(err))
+		return "", errors.Join(err, c.deleteSecurityGroupRules(ctx, securityGroupID, rule.Description))
+	}
+	if sg.JSON200 != nil {
+		for _, r := range valueOrZero(sg.JSON200.Rules) {
+			if rule.matches(r) {
+				return *r.Id, nil
+			}
+		}
+	}
+
+	err = fmt.Errorf("rule %q not found in security group %q: %w", rule.Description, securityGroupID, ErrNotFound)
+	return "", errors.Join(err, c.deleteSecurityGroupRules(ctx, securityGroupID, rule.Description))
+}
+
+// deleteSecurityGroupRules deletes the rules of a security group with a description
+func (c *apiClient) deleteSecurityGroupRules(ctx context.Context, securityGroupID, description string) error {
+	sg, err := c.GetSecurityGroupWithResponse(ctx, securityGroupID)
+	if err != nil {
+		return fmt.Errorf("failed to delete rule %q of security group %q: %w", description, securityGroupID, classifyError(err))
+	}
+	if sg.JSON200 == nil {
+		return nil
+	}
+
+	var errs error
+	for _, r := range valueOrZero(sg.JSON200.Rules) {
+		if r.Id == nil || valueOrZero(r.Description) != description {
+			continue
+		}
+		resp, err := c.DeleteRuleFromSecurityGroupWithResponse(ctx, securityGroupID, *r.Id)
+		if err == nil {
+			_, err = c.waitOperation(ctx, resp.JSON200)
+		}
+		if err != nil {
+			errs = errors.Join(errs, fmt.Errorf("failed to delete rule %q of security group %q: %w", *r.Id, securityGroupID, classifyError(err)))
+		}
+	}
+
+	return errs
+}
+
+// V3DeleteSecurityGroupRule deletes a rule of a security group
+func (e *Exoscale) V3DeleteSecurityGroupRule(ctx context.Context, securityGroupID, ruleID string) error {
+	c, err := e.client("")
+	if err != nil {
+		return err
+	}
+
+	ctx = exoapi.WithEndpoint(ctx, c.reqEndpoint)
+
+	resp, err := c.DeleteRuleFromSecurityGroupWithResponse(ctx, securityGroupID, ruleID)
+	if err != nil {
+		return fmt.Errorf("failed to delete rule %q of security group %q: %w", ruleID, securityGroupID, classifyError(err))
+	}
+	if _, err := c.waitOperation(ctx, resp.JSON200); err != nil {
+		return fmt.Errorf("failed to delete rule %q of security group %q: %w", ruleID, securityGroupID, classifyError(err))
+	}
+
+	return nil
+}
@@ -0,0 +1,48 @@
+package exoscale
+
+import (
+	"context"
+	"fmt"
+
+	exoapi "github.com/exoscale/egoscale/v2/api"
+	"github.com/exoscale/egoscale/v2/oapi"
+)
+
+// V3RegisterSSHKey registers an SSH public key, formatted as in authorized_keys files
+func (e *Exoscale) V3RegisterSSHKey(ctx context.Context, name, publicKey string) error {
+	c, err := e.client("")
+	if err != nil {
+		return err
+	}
+
+	resp, err := c.RegisterSshKeyWithResponse(
+		exoapi.WithEndpoint(ctx, c.reqEndpoint),
+		oapi.RegisterSshKeyJSONRequestBody{Name: name, PublicKey: publicKey},
+	)
+	if err != nil {
+		return fmt.Errorf("failed to register SSH key %q: %w", name, classifyError(err))
+	}
+	if _, err := c.waitOperation(ctx, resp.JSON200); err != nil {
+		return fmt.Errorf("failed to register SSH key %q: %w", name, classifyError(err))
+	}
+
+	return nil
+}
+
+// V3DeleteSSHKey deletes a registered SSH key
+func (e *Exoscale) V3DeleteSSHKey(ctx context.Context, name string) error {
+	c, err := e.client("")
+	if err != nil {
+		return err
+	}
+
+	resp, err := c.DeleteSshKeyWithResponse(exoapi.WithEndpoint(ctx, c.reqEndpoint), name)
+	if err != nil {
+		return fmt.Errorf("failed to delete SSH key %q: %w", name, classifyError(err))
+	}
+	if _, err := c.waitOperation(ctx, resp.JSON200); err != nil {
+		return fmt.Errorf("failed to delete SSH key %q: %w", name, classifyError(err))
+	}
+
+	return nil
+}
@@ -0,0 +1,113 @@
+package exoscale
+
+import (
+	"context"
+	"errors"
+	"net"
+	"net/http"
+	"time"
+
+	"github.com/hashicorp/go-retryablehttp"
+	"golang.org/x/time/rate"
+)
+
+const (
+	defaultAPIMaxRetries   = 4
+	defaultAPIRetryWaitMin = time.Second
+	defaultAPIRetryWaitMax = 30 * time.Second
+)
+
+// newHTTPClient returns the HTTP client used by egoscale, a new client is
+// required for each egoscale client as egoscale wraps its transport.
+//
+// GET and DELETE requests failing with a connection error, a 429 or a 5xx are
+// retried with an exponential backoff honoring the Retry-After header, other
+// requests may have been processed and are only retried on a 429 or when the
+// connection couldn't be established. Once retries are
+// exhausted the last response is passed through so that its status code can
+// be used to classify the error. When a rate limit is configured, every
+// attempt waits for a token before being sent.
+func newHTTPClient(cfg ExoscaleConfig) *http.Client {
+	rc := retryablehttp.NewClient()
+	rc.Logger = nil
+	rc.ErrorHandler = retryablehttp.PassthroughErrorHandler
+	rc.Backoff = retryBackoff
+	rc.CheckRetry = retryPolicy
+
+	rc.RetryMax = defaultAPIMaxRetries
+	if cfg.APIMaxRetries != nil {
+		rc.RetryMax = *cfg.APIMaxRetries
+	}
+	rc.RetryWaitMin = defaultAPIRetryWaitMin
+	rc.RetryWaitMax = defaultAPIRetryWaitMax
+	if cfg.APIRetryWaitMax != 0 {
+		rc.RetryWaitMax = cfg.APIRetryWaitMax
+	}
+	rc.RetryWaitMin = min(rc.RetryWaitMin, rc.RetryWaitMax)
+
+	if cfg.APIRateLimit > 0 {
+		rc.HTTPClient.Transport = &rateLimitTransport{
+			limiter: rate.NewLimiter(rate.Limit(cfg.APIRateLimit), cfg.APIRateLimit),
+			next:    rc.HTTPClient.Transport,
+		}
+	}
+
+	return &http.Client{
+		Transport: &apiErrorTransport{next: &retryMethodTransport{next: &retryablehttp.RoundTripper{Client: rc}}},
+	}
+}
+
+type retryMethodContextKey struct{}
+
+// retryMethodTransport passes the request method to retryPolicy, retryablehttp doesn't
+// provide the request when it failed without a response
+type retryMethodTransport struct {
+	next http.RoundTripper
+}
+
+func (t *retryMethodTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	ctx := context.WithValue(req.Context(), retryMethodContextKey{}, req.Method)
+	return t.next.RoundTrip(req.WithContext(ctx))
+}
+
+// retryPolicy is retryablehttp.DefaultRetryPolicy for GET and DELETE requests, other
+// requests are only retried when they were rejected by the rate limiting or not sent
+func retryPolicy(ctx context.Context, resp *http.Response, err error) (bool, error) {
+	switch method, _ := ctx.Value(retryMethodContextKey{}).(string); {
+	case method == http.MethodGet || method == http.MethodDelete:
+	case err != nil && !isDialError(err):
+		return false, nil
+	case err == nil && resp.StatusCode != http.StatusTooManyRequests:
+		return false, nil
+	}
+
+	return retryablehttp.DefaultRetryPolicy(ctx, resp, err)
+}
+
+// isDialError reports whether err happened while establishing the connection, before
+// the request was sent
+func isDialError(err error) bool {
+	var opErr *net.OpError
+	var dnsErr *net.DNSError
+	return errors.As(err, &opErr) && opErr.Op == "dial" || errors.As(err, &dnsErr)
+}
+
+// retryBackoff is retryablehttp.DefaultBackoff, capping the delay requested by
+// the Retry-After header to the maximum wait so that a request never blocks for too long
+func retryBackoff(waitMin, waitMax time.Duration, attemptNum int, resp *http.Response) time.Duration {
+	return min(retryablehttp.DefaultBackoff(waitMin, waitMax, attemptNum, resp), waitMax)
+}
+
+// rateLimitTransport limits the rate of requests sent to the Exoscale API
+type rateLimitTransport struct {
+	limiter *rate.Limiter
+	next    http.RoundTripper
+}
+
+func (t *rateLimitTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if err := t.limiter.Wait(req.Context()); err != nil {
+		return nil, err
+	}
+
+	return t.next.RoundTrip(req)
+}
@@ -0,0 +1,119 @@
+package exoscale
+
+import (
+	"context"
+	"errors"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"syscall"
+	"time"
+)
+
+func (ts *testSuite) TestHTTPClientRetry() {
+	var attempts int
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		attempts++
+		switch r.URL.Path {
+		case "/flaky":
+			if attempts < 3 {
+				w.Header().Set("Retry-After", "0")
+				w.WriteHeader(http.StatusTooManyRequests)
+				return
+			}
+			_, _ = w.Write([]byte(`{}`))
+		default:
+			w.WriteHeader(http.StatusServiceUnavailable)
+			_, _ = w.Write([]byte(`{"message":"maintenance"}`))
+		}
+	}))
+	defer server.Close()
+
+	client := newHTTPClient(ExoscaleConfig{APIMaxRetries: ptr(2), APIRetryWaitMax: time.Millisecond})
+
+	resp, err := client.Get(server.URL + "/flaky")
+	ts.Require().NoError(err)
+	ts.Require().NoError(resp.Body.Close())
+	ts.Require().Equal(3, attempts)
+
+	attempts = 0
+	_, err = client.Get(server.URL + "/down")
+	ts.Require().ErrorIs(err, ErrTransient)
+	ts.Require().ErrorContains(err, "maintenance")
+	ts.Require().Equal(3, attempts)
+
+	// a POST request may have been processed by the failing server
+	attempts = 0
+	_, err = client.Post(server.URL+"/down", "application/json", strings.NewReader(`{}`))
+	ts.Require().ErrorIs(err, ErrTransient)
+	ts.Require().Equal(1, attempts)
+
+	attempts = 0
+	resp, err = client.Post(server.URL+"/flaky", "application/json", strings.NewReader(`{}`))
+	ts.Require().NoError(err)
+	ts.Require().NoError(resp.Body.Close())
+	ts.Require().Equal(3, attempts)
+}
+
+func (ts *testSuite) TestRetryPolicy() {
+	dialErr := &net.OpError{Op: "dial", Net: "tcp", Err: syscall.ECONNREFUSED}
+	resetErr := &net.OpError{Op: "read", Net: "tcp", Err: syscall.ECONNRESET}
+
+	tests := []struct {
+		name   string
+		method string
+		resp   *http.Response
+		err    error
+		want   bool
+	}{
+		{name: "get server error", method: http.MethodGet, resp: &http.Response{StatusCode: http.StatusBadGateway}, want: true},
+		{name: "delete connection reset", method: http.MethodDelete, err: resetErr, want: true},
+		{name: "post rate limited", method: http.MethodPost, resp: &http.Response{StatusCode: http.StatusTooManyRequests}, want: true},
+		{name: "post server error", method: http.MethodPost, resp: &http.Response{StatusCode: http.StatusBadGateway}},
+		{name: "post connection refused", method: http.MethodPost, err: dialErr, want: true},
+		{name: "post connection reset", method: http.MethodPost, err: resetErr},
+		{name: "put unknown host", method: http.MethodPut, err: &net.DNSError{Err: "no such host", IsNotFound: true}, want: true},
+		{name: "post wrapped error", method: http.MethodPost, err: errors.New("boom")},
+	}
+
+	for _, tt := range tests {
+		ts.Run(tt.name, func() {
+			ctx := context.WithValue(context.Background(), retryMethodContextKey{}, tt.method)
+			retry, err := retryPolicy(ctx, tt.resp, tt.err)
+			ts.Require().NoError(err)
+			ts.Require().Equal(tt.want, retry)
+		})
+	}
+}
+
+func (ts *testSuite) TestHTTPClientRateLimit() {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{}`))
+	}))
+	defer server.Close()
+
+	client := newHTTPClient(ExoscaleConfig{APIRateLimit: 2})
+
+	start := time.Now()
+	for i := 0; i < 3; i++ {
+		resp, err := client.Get(server.URL)
+		ts.Require().NoError(err)
+		ts.Require().NoError(resp.Body.Close())
+	}
+	// the first 2 requests consume the burst, the third one waits for a token
+	ts.Require().GreaterOrEqual(time.Since(start), 400*time.Millisecond)
+}
+
+func (ts *testSuite) TestRetryBackoff() {
+	resp := &http.Response{
+		StatusCode: http.StatusTooManyRequests,
+		Header:     http.Header{"Retry-After": []string{"3600"}},
+	}
+	ts.Require().Equal(30*time.Second, retryBackoff(time.Second, 30*time.Second, 1, resp))
+
+	resp.Header.Set("Retry-After", "5")
+	ts.Require().Equal(5*time.Second, retryBackoff(time.Second, 30*time.Second, 1, resp))
+
+	ts.Require().Equal(4*time.Second, retryBackoff(time.Second, 30*time.Second, 2, nil))
+}
@@ -0,0 +1,111 @@
+package exoscale
+
+import (
+	"regexp"
+	"strings"
+
+	"github.com/exoscale/egoscale/v2/oapi"
+)
+
+// requiredRootOperations lists the IAM operations the root API key must be allowed to perform
+var requiredRootOperations = []string{
+	"create-api-key",
+	"delete-api-key",
+	"get-api-key",
+	"list-api-keys",
+	"list-iam-roles",
+	"get-iam-role",
+}
+
+var (
+	iamExprOperationEqual = regexp.MustCompile(`^operation\s*==\s*['"]([a-z0-9-]+)['"]$`)
+	iamExprOperationIn    = regexp.MustCompile(`^operation\s+in\s+\[([^\]]*)\]$`)
+)
+
+// iamPolicyDecision is the outcome of the static evaluation of an IAM policy
+type iamPolicyDecision int
+
+const (
+	iamPolicyDenied iamPolicyDecision = iota
+	iamPolicyAllowed
+	// iamPolicyUndetermined is returned when the policy relies on rule
+	// expressions that can't be evaluated without the request context
+	iamPolicyUndetermined
+)
+
+// evaluateIAMPolicy statically evaluates whether a policy allows an operation on a service,
+// only rule expressions matching on the operation name alone are supported.
+func evaluateIAMPolicy(policy *oapi.IamPolicy, service, operation string) iamPolicyDecision {
+	fallback := iamPolicyDenied
+	if policy.DefaultServiceStrategy == oapi.IamPolicyDefaultServiceStrategyAllow {
+		fallback = iamPolicyAllowed
+	}
+
+	sp, ok := policy.Services.AdditionalProperties[service]
+	if !ok || sp.Type == nil {
+		return fallback
+	}
+
+	switch *sp.Type {
+	case oapi.IamServicePolicyTypeAllow:
+		return iamPolicyAllowed
+	case oapi.IamServicePolicyTypeDeny:
+		return iamPolicyDenied
+	case oapi.IamServicePolicyTypeRules:
+	default:
+		return iamPolicyUndetermined
+	}
+
+	if sp.Rules != nil {
+		for _, rule := range *sp.Rules {
+			if rule.Expression == nil || rule.Action == nil {
+				continue
+			}
+
+			matches, ok := iamExpressionMatchesOperation(*rule.Expression, operation)
+			if !ok {
+				return iamPolicyUndetermined
+			}
+			if !matches {
+				continue
+			}
+
+			if *rule.Action == oapi.IamServicePolicyRuleActionAllow {
+				return iamPolicyAllowed
+			}
+			return iamPolicyDenied
+		}
+	}
+
+	// when no rule matches, both a deny-by-default and a fallback
+	// on the default strategy lead to the same decision
+	if fallback == iamPolicyDenied {
+		return iamPolicyDenied
+	}
+	return iamPolicyUndetermined
+}
+
+// iamExpressionMatchesOperation evaluates simple CEL expressions such as
+// "operation == 'x'" or "operation in ['x', 'y']", ok is false for any other expression.
+func iamExpressionMatchesOperation(expr, operation string) (matches bool, ok bool) {
+	expr = strings.TrimSpace(expr)
+
+	if expr == "true" {
+		return true, true
+	}
+
+	if m := iamExprOperationEqual.FindStringSubmatch(expr); m != nil {
+		return m[1] == operation, true
+	}
+
+	if m := iamExprOperationIn.FindStringSubmatch(expr); m != nil {
+		for _, item := range strings.Split(m[1], ",") {
+			if strings.Trim(strings.TrimSpace(item), `'"`) == operation {
+				return true, true
+			}
+		}
+		return false, true
+	}
+
+	return false, false
+}
@@ -0,0 +1,82 @@
+package exoscale
+
+import (
+	"github.com/exoscale/egoscale/v2/oapi"
+)
+
+func (ts *testSuite) TestEvaluateIAMPolicy() {
+	allow := oapi.IamServicePolicyTypeAllow
+	deny := oapi.IamServicePolicyTypeDeny
+
+	tests := []struct {
+		name      string
+		policy    *oapi.IamPolicy
+		operation string
+		want      iamPolicyDecision
+	}{
+		{
+			name:      "default allow",
+			policy:    &oapi.IamPolicy{DefaultServiceStrategy: oapi.IamPolicyDefaultServiceStrategyAllow},
+			operation: "create-api-key",
+			want:      iamPolicyAllowed,
+		},
+		{
+			name:      "default deny",
+			policy:    &oapi.IamPolicy{DefaultServiceStrategy: oapi.IamPolicyDefaultServiceStrategyDeny},
+			operation: "create-api-key",
+			want:      iamPolicyDenied,
+		},
+		{
+			name: "service allow",
+			policy: &oapi.IamPolicy{
+				DefaultServiceStrategy: oapi.IamPolicyDefaultServiceStrategyDeny,
+				Services: oapi.IamPolicy_Services{
+					AdditionalProperties: map[string]oapi.IamServicePolicy{"iam": {Type: &allow}},
+				},
+			},
+			operation: "create-api-key",
+			want:      iamPolicyAllowed,
+		},
+		{
+			name: "service deny",
+			policy: &oapi.IamPolicy{
+				DefaultServiceStrategy: oapi.IamPolicyDefaultServiceStrategyAllow,
+				Services: oapi.IamPolicy_Services{
+					AdditionalProperties: map[string]oapi.IamServicePolicy{"iam": {Type: &deny}},
+				},
+			},
+			operation: "create-api-key",
+			want:      iamPolicyDenied,
+		},
+		{
+			name:      "rule equal",
+			policy:    testRootIAMPolicy(`operation == "create-api-key"`),
+			operation: "create-api-key",
+			want:      iamPolicyAllowed,
+		},
+		{
+			name:      "rule in",
+			policy:    testRootIAMPolicy("operation in ['get-iam-role', 'create-api-key']"),
+			operation: "create-api-key",
+			want:      iamPolicyAllowed,
+		},
+		{
+			name:      "no rule matching",
+			policy:    testRootIAMPolicy("operation in ['get-iam-role']"),
+			operation: "create-api-key",
+			want:      iamPolicyDenied,
+		},
+		{
+			name:      "unsupported expression",
+			policy:    testRootIAMPolicy("operation == 'create-api-key' && parameters.role_id == 'x'"),
+			operation: "create-api-key",
+			want:      iamPolicyUndetermined,
+		},
+	}
+
+	for _, tt := range tests {
+		ts.Run(tt.name, func() {
+			ts.Require().Equal(tt.want, evaluateIAMPolicy(tt.policy, "iam", tt.operation))
+		})
+	}
+}
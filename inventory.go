@@ -0,0 +1,243 @@
+package exoscale
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/hashicorp/vault/sdk/logical"
+)
+
+const (
+	issuedKeyStoragePathPrefix  = "issued/"
+	revokedKeyStoragePathPrefix = "revoked/"
+
+	// revokedKeyRetention is how long the records of revoked keys are kept for lookups
+	revokedKeyRetention = 30 * 24 * time.Hour
+	// revokedKeyPurgeInterval is the interval between two purges of expired records of revoked keys
+	revokedKeyPurgeInterval = time.Hour
+
+	// inventoryStoragePath records when the backend started recording issued keys,
+	// keys created before can't be told apart from keys issued by a previous version
+	inventoryStoragePath = "config/inventory"
+)
+
+// issuedKey is the record of an API key issued for a lease, kept until the lease is revoked,
+// then kept for revokedKeyRetention under revoked/ for lookups
+type issuedKey struct {
+	Key           string            `json:"key"`
+	Name          string            `json:"name"`
+	Role          string            `json:"role"`
+	DisplayName   string            `json:"display_name,omitempty"`
+	EntityID      string            `json:"entity_id,omitempty"`
+	EntityName    string            `json:"entity_name,omitempty"`
+	EntityAliases []*issuedKeyAlias `json:"entity_aliases,omitempty"`
+	MountPoint    string            `json:"mount_point,omitempty"`
+	Version       string            `json:"version"`
+	Account       string            `json:"account,omitempty"`
+	IssuedAt      time.Time         `json:"issued_at"`
+	ExpiresAt     time.Time         `json:"expires_at"`
+	// LeaseID is only known once the lease is renewed or revoked
+	LeaseID   string    `json:"lease_id,omitempty"`
+	RevokedAt time.Time `json:"revoked_at,omitempty"`
+	// Released is set once the key was released from the quotas of its role
+	Released bool `json:"released,omitempty"`
+}
+
+// issuedKeyAlias is an alias of the Vault entity which requested a key
+type issuedKeyAlias struct {
+	Name          string `json:"name"`
+	MountAccessor string `json:"mount_accessor"`
+	MountType     string `json:"mount_type"`
+}
+
+func (k *issuedKey) responseData() map[string]interface{} {
+	aliases := make([]map[string]interface{}, 0, len(k.EntityAliases))
+	for _, a := range k.EntityAliases {
+		aliases = append(aliases, map[string]interface{}{
+			"name":           a.Name,
+			"mount_accessor": a.MountAccessor,
+			"mount_type":     a.MountType,
+		})
+	}
+
+	data := map[string]interface{}{
+		"api_key":        k.Key,
+		"name":           k.Name,
+		"role":           k.Role,
+		"display_name":   k.DisplayName,
+		"entity_id":      k.EntityID,
+		"entity_name":    k.EntityName,
+		"entity_aliases": aliases,
+		"mount_point":    k.MountPoint,
+		"version":        k.Version,
+		"account":        k.Account,
+		"lease_id":       k.LeaseID,
+		"issued_at":      k.IssuedAt.Format(time.RFC3339),
+		"expires_at":     k.ExpiresAt.Format(time.RFC3339),
+	}
+	if !k.RevokedAt.IsZero() {
+		data["revoked_at"] = k.RevokedAt.Format(time.RFC3339)
+	}
+
+	return data
+}
+
+// setEntity records the identity of the Vault entity which requested a key
+func (k *issuedKey) setEntity(entity *logical.Entity) {
+	if entity == nil {
+		return
+	}
+
+	k.EntityName = entity.Name
+	for _, a := range entity.Aliases {
+		k.EntityAliases = append(k.EntityAliases, &issuedKeyAlias{
+			Name:          a.Name,
+			MountAccessor: a.MountAccessor,
+			MountType:     a.MountType,
+		})
+	}
+}
+
+type inventory struct {
+	Start time.Time `json:"start"`
+}
+
+func getIssuedKey(ctx context.Context, storage logical.Storage, key string) (*issuedKey, error) {
+	return getKeyRecord(ctx, storage, issuedKeyStoragePathPrefix+key)
+}
+
+func getRevokedKey(ctx context.Context, storage logical.Storage, key string) (*issuedKey, error) {
+	return getKeyRecord(ctx, storage, revokedKeyStoragePathPrefix+key)
+}
+
+func getKeyRecord(ctx context.Context, storage logical.Storage, path string) (*issuedKey, error) {
+	entry, err := storage.Get(ctx, path)
+	if err != nil {
+		return nil, fmt.Errorf("unable to retrieve record of key %q: %w", path, err)
+	}
+	if entry == nil {
+		return nil, nil
+	}
+
+	var issued issuedKey
+	if err := entry.DecodeJSON(&issued); err != nil {
+		return nil, err
+	}
+
+	return &issued, nil
+}
+
+func putIssuedKey(ctx context.Context, storage logical.Storage, key *issuedKey) error {
+	entry, err := logical.StorageEntryJSON(issuedKeyStoragePathPrefix+key.Key, key)
+	if err != nil {
+		return err
+	}
+
+	if err := storage.Put(ctx, entry); err != nil {
+		return fmt.Errorf("unable to record issued key %q: %w", key.Key, err)
+	}
+
+	return nil
+}
+
+// revokeIssuedKey moves the record of an issued key to revoked/ once its lease is revoked
+func revokeIssuedKey(ctx context.Context, storage logical.Storage, issued *issuedKey, leaseID string) error {
+	issued.RevokedAt = time.Now()
+	if leaseID != "" {
+		issued.LeaseID = leaseID
+	}
+
+	entry, err := logical.StorageEntryJSON(revokedKeyStoragePathPrefix+issued.Key, issued)
+	if err != nil {
+		return err
+	}
+	if err := storage.Put(ctx, entry); err != nil {
+		return fmt.Errorf("unable to record revoked key %q: %w", issued.Key, err)
+	}
+
+	if err := storage.Delete(ctx, issuedKeyStoragePathPrefix+issued.Key); err != nil {
+		return fmt.Errorf("unable to delete record of issued key %q: %w", issued.Key, err)
+	}
+
+	return nil
+}
+
+// purgeRevokedKeys deletes the records of the keys revoked for more than revokedKeyRetention
+func purgeRevokedKeys(ctx context.Context, storage logical.Storage) error {
+	keys, err := storage.List(ctx, revokedKeyStoragePathPrefix)
+	if err != nil {
+		return err
+	}
+
+	for _, key := range keys {
+		revoked, err := getRevokedKey(ctx, storage, key)
+		if err != nil {
+			return err
+		}
+		if revoked == nil || time.Since(revoked.RevokedAt) < revokedKeyRetention {
+			continue
+		}
+
+		if err := storage.Delete(ctx, revokedKeyStoragePathPrefix+key); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// getInventoryStart returns when the backend started recording issued keys
+func getInventoryStart(ctx context.Context, storage logical.Storage) (time.Time, error) {
+	entry, err := storage.Get(ctx, inventoryStoragePath)
+	if err != nil {
+		return time.Time{}, err
+	}
+	if entry == nil {
+		return time.Time{}, nil
+	}
+
+	var inv inventory
+	if err := entry.DecodeJSON(&inv); err != nil {
+		return time.Time{}, err
+	}
+
+	return inv.Start, nil
+}
+
+// initInventory records the start of the inventory of issued keys if needed
+func initInventory(ctx context.Context, storage logical.Storage) error {
+	start, err := getInventoryStart(ctx, storage)
+	if err != nil {
+		return err
+	}
+	if !start.IsZero() {
+		return nil
+	}
+
+	entry, err := logical.StorageEntryJSON(inventoryStoragePath, inventory{Start: time.Now()})
+	if err != nil {
+		return err
+	}
+
+	return storage.Put(ctx, entry)
+}
+
+// renewIssuedKey updates the expiry and the lease ID of the record of an issued key after its
+// lease was renewed, keys issued before the inventory existed have no record
+func renewIssuedKey(ctx context.Context, storage logical.Storage, key, leaseID string, ttl time.Duration) error {
+	issued, err := getIssuedKey(ctx, storage, key)
+	if err != nil {
+		return err
+	}
+	if issued == nil {
+		return nil
+	}
+
+	issued.ExpiresAt = time.Now().Add(ttl)
+	if leaseID != "" {
+		issued.LeaseID = leaseID
+	}
+
+	return putIssuedKey(ctx, storage, issued)
+}
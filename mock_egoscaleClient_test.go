@@ -24,6 +24,77 @@ func (_m *mockEgoscaleClient) EXPECT() *mockEgoscaleClient_Expecter {
 	return &mockEgoscaleClient_Expecter{mock: &_m.Mock}
 }
 
+// AddRuleToSecurityGroupWithResponse provides a mock function with given fields: ctx, id, body, reqEditors
+func (_m *mockEgoscaleClient) AddRuleToSecurityGroupWithResponse(ctx context.Context, id string, body oapi.AddRuleToSecurityGroupJSONRequestBody, reqEditors ...oapi.RequestEditorFn) (*oapi.AddRuleToSecurityGroupResponse, error) {
+	_va := make([]interface{}, len(reqEditors))
+	for _i := range reqEditors {
+		_va[_i] = reqEditors[_i]
+	}
+	var _ca []interface{}
+	_ca = append(_ca, ctx, id, body)
+	_ca = append(_ca, _va...)
+	ret := _m.Called(_ca...)
+
+	var r0 *oapi.AddRuleToSecurityGroupResponse
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, oapi.AddRuleToSecurityGroupJSONRequestBody, ...oapi.RequestEditorFn) (*oapi.AddRuleToSecurityGroupResponse, error)); ok {
+		return rf(ctx, id, body, reqEditors...)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, oapi.AddRuleToSecurityGroupJSONRequestBody, ...oapi.RequestEditorFn) *oapi.AddRuleToSecurityGroupResponse); ok {
+		r0 = rf(ctx, id, body, reqEditors...)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*oapi.AddRuleToSecurityGroupResponse)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, oapi.AddRuleToSecurityGroupJSONRequestBody, ...oapi.RequestEditorFn) error); ok {
+		r1 = rf(ctx, id, body, reqEditors...)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// mockEgoscaleClient_AddRuleToSecurityGroupWithResponse_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'AddRuleToSecurityGroupWithResponse'
+type mockEgoscaleClient_AddRuleToSecurityGroupWithResponse_Call struct {
+	*mock.Call
+}
+
+// AddRuleToSecurityGroupWithResponse is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id string
+//   - body oapi.AddRuleToSecurityGroupJSONRequestBody
+//   - reqEditors ...oapi.RequestEditorFn
+func (_e *mockEgoscaleClient_Expecter) AddRuleToSecurityGroupWithResponse(ctx interface{}, id interface{}, body interface{}, reqEditors ...interface{}) *mockEgoscaleClient_AddRuleToSecurityGroupWithResponse_Call {
+	return &mockEgoscaleClient_AddRuleToSecurityGroupWithResponse_Call{Call: _e.mock.On("AddRuleToSecurityGroupWithResponse",
+		append([]interface{}{ctx, id, body}, reqEditors...)...)}
+}
+
+func (_c *mockEgoscaleClient_AddRuleToSecurityGroupWithResponse_Call) Run(run func(ctx context.Context, id string, body oapi.AddRuleToSecurityGroupJSONRequestBody, reqEditors ...oapi.RequestEditorFn)) *mockEgoscaleClient_AddRuleToSecurityGroupWithResponse_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		variadicArgs := make([]oapi.RequestEditorFn, len(args)-3)
+		for i, a := range args[3:] {
+			if a != nil {
+				variadicArgs[i] = a.(oapi.RequestEditorFn)
+			}
+		}
+		run(args[0].(context.Context), args[1].(string), args[2].(oapi.AddRuleToSecurityGroupJSONRequestBody), variadicArgs...)
+	})
+	return _c
+}
+
+func (_c *mockEgoscaleClient_AddRuleToSecurityGroupWithResponse_Call) Return(_a0 *oapi.AddRuleToSecurityGroupResponse, _a1 error) *mockEgoscaleClient_AddRuleToSecurityGroupWithResponse_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *mockEgoscaleClient_AddRuleToSecurityGroupWithResponse_Call) RunAndReturn(run func(context.Context, string, oapi.AddRuleToSecurityGroupJSONRequestBody, ...oapi.RequestEditorFn) (*oapi.AddRuleToSecurityGroupResponse, error)) *mockEgoscaleClient_AddRuleToSecurityGroupWithResponse_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // CreateApiKeyWithResponse provides a mock function with given fields: ctx, body, reqEditors
 func (_m *mockEgoscaleClient) CreateApiKeyWithResponse(ctx context.Context, body oapi.CreateApiKeyJSONRequestBody, reqEditors ...oapi.RequestEditorFn) (*oapi.CreateApiKeyResponse, error) {
 	_va := make([]interface{}, len(reqEditors))
@@ -94,32 +165,32 @@ func (_c *mockEgoscaleClient_CreateApiKeyWithResponse_Call) RunAndReturn(run fun
 	return _c
 }
 
-// CreateIAMAccessKey provides a mock function with given fields: _a0, _a1, _a2, _a3
-func (_m *mockEgoscaleClient) CreateIAMAccessKey(_a0 context.Context, _a1 string, _a2 string, _a3 ...v2.CreateIAMAccessKeyOpt) (*v2.IAMAccessKey, error) {
-	_va := make([]interface{}, len(_a3))
-	for _i := range _a3 {
-		_va[_i] = _a3[_i]
+// CreateDbaasKafkaTopicAclConfigWithResponse provides a mock function with given fields: ctx, name, body, reqEditors
+func (_m *mockEgoscaleClient) CreateDbaasKafkaTopicAclConfigWithResponse(ctx context.Context, name oapi.DbaasServiceName, body oapi.CreateDbaasKafkaTopicAclConfigJSONRequestBody, reqEditors ...oapi.RequestEditorFn) (*oapi.CreateDbaasKafkaTopicAclConfigResponse, error) {
+	_va := make([]interface{}, len(reqEditors))
+	for _i := range reqEditors {
+		_va[_i] = reqEditors[_i]
 	}
 	var _ca []interface{}
-	_ca = append(_ca, _a0, _a1, _a2)
+	_ca = append(_ca, ctx, name, body)
 	_ca = append(_ca, _va...)
 	ret := _m.Called(_ca...)
 
-	var r0 *v2.IAMAccessKey
+	var r0 *oapi.CreateDbaasKafkaTopicAclConfigResponse
 	var r1 error
-	if rf, ok := ret.Get(0).(func(context.Context, string, string, ...v2.CreateIAMAccessKeyOpt) (*v2.IAMAccessKey, error)); ok {
-		return rf(_a0, _a1, _a2, _a3...)
+	if rf, ok := ret.Get(0).(func(context.Context, oapi.DbaasServiceName, oapi.CreateDbaasKafkaTopicAclConfigJSONRequestBody, ...oapi.RequestEditorFn) (*oapi.CreateDbaasKafkaTopicAclConfigResponse, error)); ok {
+		return rf(ctx, name, body, reqEditors...)
 	}
-	if rf, ok := ret.Get(0).(func(context.Context, string, string, ...v2.CreateIAMAccessKeyOpt) *v2.IAMAccessKey); ok {
-		r0 = rf(_a0, _a1, _a2, _a3...)
+	if rf, ok := ret.Get(0).(func(context.Context, oapi.DbaasServiceName, oapi.CreateDbaasKafkaTopicAclConfigJSONRequestBody, ...oapi.RequestEditorFn) *oapi.CreateDbaasKafkaTopicAclConfigResponse); ok {
+		r0 = rf(ctx, name, body, reqEditors...)
 	} else {
 		if ret.Get(0) != nil {
-			r0 = ret.Get(0).(*v2.IAMAccessKey)
+			r0 = ret.Get(0).(*oapi.CreateDbaasKafkaTopicAclConfigResponse)
 		}
 	}
 
-	if rf, ok := ret.Get(1).(func(context.Context, string, string, ...v2.CreateIAMAccessKeyOpt) error); ok {
-		r1 = rf(_a0, _a1, _a2, _a3...)
+	if rf, ok := ret.Get(1).(func(context.Context, oapi.DbaasServiceName, oapi.CreateDbaasKafkaTopicAclConfigJSONRequestBody, ...oapi.RequestEditorFn) error); ok {
+		r1 = rf(ctx, name, body, reqEditors...)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -127,70 +198,70 @@ func (_m *mockEgoscaleClient) CreateIAMAccessKey(_a0 context.Context, _a1 string
 	return r0, r1
 }
 
-// mockEgoscaleClient_CreateIAMAccessKey_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CreateIAMAccessKey'
-type mockEgoscaleClient_CreateIAMAccessKey_Call struct {
+// mockEgoscaleClient_CreateDbaasKafkaTopicAclConfigWithResponse_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CreateDbaasKafkaTopicAclConfigWithResponse'
+type mockEgoscaleClient_CreateDbaasKafkaTopicAclConfigWithResponse_Call struct {
 	*mock.Call
 }
 
-// CreateIAMAccessKey is a helper method to define mock.On call
-//   - _a0 context.Context
-//   - _a1 string
-//   - _a2 string
-//   - _a3 ...v2.CreateIAMAccessKeyOpt
-func (_e *mockEgoscaleClient_Expecter) CreateIAMAccessKey(_a0 interface{}, _a1 interface{}, _a2 interface{}, _a3 ...interface{}) *mockEgoscaleClient_CreateIAMAccessKey_Call {
-	return &mockEgoscaleClient_CreateIAMAccessKey_Call{Call: _e.mock.On("CreateIAMAccessKey",
-		append([]interface{}{_a0, _a1, _a2}, _a3...)...)}
+// CreateDbaasKafkaTopicAclConfigWithResponse is a helper method to define mock.On call
+//   - ctx context.Context
+//   - name oapi.DbaasServiceName
+//   - body oapi.CreateDbaasKafkaTopicAclConfigJSONRequestBody
+//   - reqEditors ...oapi.RequestEditorFn
+func (_e *mockEgoscaleClient_Expecter) CreateDbaasKafkaTopicAclConfigWithResponse(ctx interface{}, name interface{}, body interface{}, reqEditors ...interface{}) *mockEgoscaleClient_CreateDbaasKafkaTopicAclConfigWithResponse_Call {
+	return &mockEgoscaleClient_CreateDbaasKafkaTopicAclConfigWithResponse_Call{Call: _e.mock.On("CreateDbaasKafkaTopicAclConfigWithResponse",
+		append([]interface{}{ctx, name, body}, reqEditors...)...)}
 }
 
-func (_c *mockEgoscaleClient_CreateIAMAccessKey_Call) Run(run func(_a0 context.Context, _a1 string, _a2 string, _a3 ...v2.CreateIAMAccessKeyOpt)) *mockEgoscaleClient_CreateIAMAccessKey_Call {
+func (_c *mockEgoscaleClient_CreateDbaasKafkaTopicAclConfigWithResponse_Call) Run(run func(ctx context.Context, name oapi.DbaasServiceName, body oapi.CreateDbaasKafkaTopicAclConfigJSONRequestBody, reqEditors ...oapi.RequestEditorFn)) *mockEgoscaleClient_CreateDbaasKafkaTopicAclConfigWithResponse_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		variadicArgs := make([]v2.CreateIAMAccessKeyOpt, len(args)-3)
+		variadicArgs := make([]oapi.RequestEditorFn, len(args)-3)
 		for i, a := range args[3:] {
 			if a != nil {
-				variadicArgs[i] = a.(v2.CreateIAMAccessKeyOpt)
+				variadicArgs[i] = a.(oapi.RequestEditorFn)
 			}
 		}
-		run(args[0].(context.Context), args[1].(string), args[2].(string), variadicArgs...)
+		run(args[0].(context.Context), args[1].(oapi.DbaasServiceName), args[2].(oapi.CreateDbaasKafkaTopicAclConfigJSONRequestBody), variadicArgs...)
 	})
 	return _c
 }
 
-func (_c *mockEgoscaleClient_CreateIAMAccessKey_Call) Return(_a0 *v2.IAMAccessKey, _a1 error) *mockEgoscaleClient_CreateIAMAccessKey_Call {
+func (_c *mockEgoscaleClient_CreateDbaasKafkaTopicAclConfigWithResponse_Call) Return(_a0 *oapi.CreateDbaasKafkaTopicAclConfigResponse, _a1 error) *mockEgoscaleClient_CreateDbaasKafkaTopicAclConfigWithResponse_Call {
 	_c.Call.Return(_a0, _a1)
 	return _c
 }
 
-func (_c *mockEgoscaleClient_CreateIAMAccessKey_Call) RunAndReturn(run func(context.Context, string, string, ...v2.CreateIAMAccessKeyOpt) (*v2.IAMAccessKey, error)) *mockEgoscaleClient_CreateIAMAccessKey_Call {
+func (_c *mockEgoscaleClient_CreateDbaasKafkaTopicAclConfigWithResponse_Call) RunAndReturn(run func(context.Context, oapi.DbaasServiceName, oapi.CreateDbaasKafkaTopicAclConfigJSONRequestBody, ...oapi.RequestEditorFn) (*oapi.CreateDbaasKafkaTopicAclConfigResponse, error)) *mockEgoscaleClient_CreateDbaasKafkaTopicAclConfigWithResponse_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// DeleteApiKeyWithResponse provides a mock function with given fields: ctx, id, reqEditors
-func (_m *mockEgoscaleClient) DeleteApiKeyWithResponse(ctx context.Context, id string, reqEditors ...oapi.RequestEditorFn) (*oapi.DeleteApiKeyResponse, error) {
+// CreateDbaasKafkaUserWithResponse provides a mock function with given fields: ctx, serviceName, body, reqEditors
+func (_m *mockEgoscaleClient) CreateDbaasKafkaUserWithResponse(ctx context.Context, serviceName oapi.DbaasServiceName, body oapi.CreateDbaasKafkaUserJSONRequestBody, reqEditors ...oapi.RequestEditorFn) (*oapi.CreateDbaasKafkaUserResponse, error) {
 	_va := make([]interface{}, len(reqEditors))
 	for _i := range reqEditors {
 		_va[_i] = reqEditors[_i]
 	}
 	var _ca []interface{}
-	_ca = append(_ca, ctx, id)
+	_ca = append(_ca, ctx, serviceName, body)
 	_ca = append(_ca, _va...)
 	ret := _m.Called(_ca...)
 
-	var r0 *oapi.DeleteApiKeyResponse
+	var r0 *oapi.CreateDbaasKafkaUserResponse
 	var r1 error
-	if rf, ok := ret.Get(0).(func(context.Context, string, ...oapi.RequestEditorFn) (*oapi.DeleteApiKeyResponse, error)); ok {
-		return rf(ctx, id, reqEditors...)
+	if rf, ok := ret.Get(0).(func(context.Context, oapi.DbaasServiceName, oapi.CreateDbaasKafkaUserJSONRequestBody, ...oapi.RequestEditorFn) (*oapi.CreateDbaasKafkaUserResponse, error)); ok {
+		return rf(ctx, serviceName, body, reqEditors...)
 	}
-	if rf, ok := ret.Get(0).(func(context.Context, string, ...oapi.RequestEditorFn) *oapi.DeleteApiKeyResponse); ok {
-		r0 = rf(ctx, id, reqEditors...)
+	if rf, ok := ret.Get(0).(func(context.Context, oapi.DbaasServiceName, oapi.CreateDbaasKafkaUserJSONRequestBody, ...oapi.RequestEditorFn) *oapi.CreateDbaasKafkaUserResponse); ok {
+		r0 = rf(ctx, serviceName, body, reqEditors...)
 	} else {
 		if ret.Get(0) != nil {
-			r0 = ret.Get(0).(*oapi.DeleteApiKeyResponse)
+			r0 = ret.Get(0).(*oapi.CreateDbaasKafkaUserResponse)
 		}
 	}
 
-	if rf, ok := ret.Get(1).(func(context.Context, string, ...oapi.RequestEditorFn) error); ok {
-		r1 = rf(ctx, id, reqEditors...)
+	if rf, ok := ret.Get(1).(func(context.Context, oapi.DbaasServiceName, oapi.CreateDbaasKafkaUserJSONRequestBody, ...oapi.RequestEditorFn) error); ok {
+		r1 = rf(ctx, serviceName, body, reqEditors...)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -198,69 +269,70 @@ func (_m *mockEgoscaleClient) DeleteApiKeyWithResponse(ctx context.Context, id s
 	return r0, r1
 }
 
-// mockEgoscaleClient_DeleteApiKeyWithResponse_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeleteApiKeyWithResponse'
-type mockEgoscaleClient_DeleteApiKeyWithResponse_Call struct {
+// mockEgoscaleClient_CreateDbaasKafkaUserWithResponse_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CreateDbaasKafkaUserWithResponse'
+type mockEgoscaleClient_CreateDbaasKafkaUserWithResponse_Call struct {
 	*mock.Call
 }
 
-// DeleteApiKeyWithResponse is a helper method to define mock.On call
+// CreateDbaasKafkaUserWithResponse is a helper method to define mock.On call
 //   - ctx context.Context
-//   - id string
+//   - serviceName oapi.DbaasServiceName
+//   - body oapi.CreateDbaasKafkaUserJSONRequestBody
 //   - reqEditors ...oapi.RequestEditorFn
-func (_e *mockEgoscaleClient_Expecter) DeleteApiKeyWithResponse(ctx interface{}, id interface{}, reqEditors ...interface{}) *mockEgoscaleClient_DeleteApiKeyWithResponse_Call {
-	return &mockEgoscaleClient_DeleteApiKeyWithResponse_Call{Call: _e.mock.On("DeleteApiKeyWithResponse",
-		append([]interface{}{ctx, id}, reqEditors...)...)}
+func (_e *mockEgoscaleClient_Expecter) CreateDbaasKafkaUserWithResponse(ctx interface{}, serviceName interface{}, body interface{}, reqEditors ...interface{}) *mockEgoscaleClient_CreateDbaasKafkaUserWithResponse_Call {
+	return &mockEgoscaleClient_CreateDbaasKafkaUserWithResponse_Call{Call: _e.mock.On("CreateDbaasKafkaUserWithResponse",
+		append([]interface{}{ctx, serviceName, body}, reqEditors...)...)}
 }
 
-func (_c *mockEgoscaleClient_DeleteApiKeyWithResponse_Call) Run(run func(ctx context.Context, id string, reqEditors ...oapi.RequestEditorFn)) *mockEgoscaleClient_DeleteApiKeyWithResponse_Call {
+func (_c *mockEgoscaleClient_CreateDbaasKafkaUserWithResponse_Call) Run(run func(ctx context.Context, serviceName oapi.DbaasServiceName, body oapi.CreateDbaasKafkaUserJSONRequestBody, reqEditors ...oapi.RequestEditorFn)) *mockEgoscaleClient_CreateDbaasKafkaUserWithResponse_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		variadicArgs := make([]oapi.RequestEditorFn, len(args)-2)
-		for i, a := range args[2:] {
+		variadicArgs := make([]oapi.RequestEditorFn, len(args)-3)
+		for i, a := range args[3:] {
 			if a != nil {
 				variadicArgs[i] = a.(oapi.RequestEditorFn)
 			}
 		}
-		run(args[0].(context.Context), args[1].(string), variadicArgs...)
+		run(args[0].(context.Context), args[1].(oapi.DbaasServiceName), args[2].(oapi.CreateDbaasKafkaUserJSONRequestBody), variadicArgs...)
 	})
 	return _c
 }
 
-func (_c *mockEgoscaleClient_DeleteApiKeyWithResponse_Call) Return(_a0 *oapi.DeleteApiKeyResponse, _a1 error) *mockEgoscaleClient_DeleteApiKeyWithResponse_Call {
+func (_c *mockEgoscaleClient_CreateDbaasKafkaUserWithResponse_Call) Return(_a0 *oapi.CreateDbaasKafkaUserResponse, _a1 error) *mockEgoscaleClient_CreateDbaasKafkaUserWithResponse_Call {
 	_c.Call.Return(_a0, _a1)
 	return _c
 }
 
-func (_c *mockEgoscaleClient_DeleteApiKeyWithResponse_Call) RunAndReturn(run func(context.Context, string, ...oapi.RequestEditorFn) (*oapi.DeleteApiKeyResponse, error)) *mockEgoscaleClient_DeleteApiKeyWithResponse_Call {
+func (_c *mockEgoscaleClient_CreateDbaasKafkaUserWithResponse_Call) RunAndReturn(run func(context.Context, oapi.DbaasServiceName, oapi.CreateDbaasKafkaUserJSONRequestBody, ...oapi.RequestEditorFn) (*oapi.CreateDbaasKafkaUserResponse, error)) *mockEgoscaleClient_CreateDbaasKafkaUserWithResponse_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// GetIamRoleWithResponse provides a mock function with given fields: ctx, id, reqEditors
-func (_m *mockEgoscaleClient) GetIamRoleWithResponse(ctx context.Context, id string, reqEditors ...oapi.RequestEditorFn) (*oapi.GetIamRoleResponse, error) {
+// CreateDbaasMysqlUserWithResponse provides a mock function with given fields: ctx, serviceName, body, reqEditors
+func (_m *mockEgoscaleClient) CreateDbaasMysqlUserWithResponse(ctx context.Context, serviceName oapi.DbaasServiceName, body oapi.CreateDbaasMysqlUserJSONRequestBody, reqEditors ...oapi.RequestEditorFn) (*oapi.CreateDbaasMysqlUserResponse, error) {
 	_va := make([]interface{}, len(reqEditors))
 	for _i := range reqEditors {
 		_va[_i] = reqEditors[_i]
 	}
 	var _ca []interface{}
-	_ca = append(_ca, ctx, id)
+	_ca = append(_ca, ctx, serviceName, body)
 	_ca = append(_ca, _va...)
 	ret := _m.Called(_ca...)
 
-	var r0 *oapi.GetIamRoleResponse
+	var r0 *oapi.CreateDbaasMysqlUserResponse
 	var r1 error
-	if rf, ok := ret.Get(0).(func(context.Context, string, ...oapi.RequestEditorFn) (*oapi.GetIamRoleResponse, error)); ok {
-		return rf(ctx, id, reqEditors...)
+	if rf, ok := ret.Get(0).(func(context.Context, oapi.DbaasServiceName, oapi.CreateDbaasMysqlUserJSONRequestBody, ...oapi.RequestEditorFn) (*oapi.CreateDbaasMysqlUserResponse, error)); ok {
+		return rf(ctx, serviceName, body, reqEditors...)
 	}
-	if rf, ok := ret.Get(0).(func(context.Context, string, ...oapi.RequestEditorFn) *oapi.GetIamRoleResponse); ok {
-		r0 = rf(ctx, id, reqEditors...)
+	if rf, ok := ret.Get(0).(func(context.Context, oapi.DbaasServiceName, oapi.CreateDbaasMysqlUserJSONRequestBody, ...oapi.RequestEditorFn) *oapi.CreateDbaasMysqlUserResponse); ok {
+		r0 = rf(ctx, serviceName, body, reqEditors...)
 	} else {
 		if ret.Get(0) != nil {
-			r0 = ret.Get(0).(*oapi.GetIamRoleResponse)
+			r0 = ret.Get(0).(*oapi.CreateDbaasMysqlUserResponse)
 		}
 	}
 
-	if rf, ok := ret.Get(1).(func(context.Context, string, ...oapi.RequestEditorFn) error); ok {
-		r1 = rf(ctx, id, reqEditors...)
+	if rf, ok := ret.Get(1).(func(context.Context, oapi.DbaasServiceName, oapi.CreateDbaasMysqlUserJSONRequestBody, ...oapi.RequestEditorFn) error); ok {
+		r1 = rf(ctx, serviceName, body, reqEditors...)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -268,69 +340,70 @@ func (_m *mockEgoscaleClient) GetIamRoleWithResponse(ctx context.Context, id str
 	return r0, r1
 }
 
-// mockEgoscaleClient_GetIamRoleWithResponse_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetIamRoleWithResponse'
-type mockEgoscaleClient_GetIamRoleWithResponse_Call struct {
+// mockEgoscaleClient_CreateDbaasMysqlUserWithResponse_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CreateDbaasMysqlUserWithResponse'
+type mockEgoscaleClient_CreateDbaasMysqlUserWithResponse_Call struct {
 	*mock.Call
 }
 
-// GetIamRoleWithResponse is a helper method to define mock.On call
+// CreateDbaasMysqlUserWithResponse is a helper method to define mock.On call
 //   - ctx context.Context
-//   - id string
+//   - serviceName oapi.DbaasServiceName
+//   - body oapi.CreateDbaasMysqlUserJSONRequestBody
 //   - reqEditors ...oapi.RequestEditorFn
-func (_e *mockEgoscaleClient_Expecter) GetIamRoleWithResponse(ctx interface{}, id interface{}, reqEditors ...interface{}) *mockEgoscaleClient_GetIamRoleWithResponse_Call {
-	return &mockEgoscaleClient_GetIamRoleWithResponse_Call{Call: _e.mock.On("GetIamRoleWithResponse",
-		append([]interface{}{ctx, id}, reqEditors...)...)}
+func (_e *mockEgoscaleClient_Expecter) CreateDbaasMysqlUserWithResponse(ctx interface{}, serviceName interface{}, body interface{}, reqEditors ...interface{}) *mockEgoscaleClient_CreateDbaasMysqlUserWithResponse_Call {
+	return &mockEgoscaleClient_CreateDbaasMysqlUserWithResponse_Call{Call: _e.mock.On("CreateDbaasMysqlUserWithResponse",
+		append([]interface{}{ctx, serviceName, body}, reqEditors...)...)}
 }
 
-func (_c *mockEgoscaleClient_GetIamRoleWithResponse_Call) Run(run func(ctx context.Context, id string, reqEditors ...oapi.RequestEditorFn)) *mockEgoscaleClient_GetIamRoleWithResponse_Call {
+func (_c *mockEgoscaleClient_CreateDbaasMysqlUserWithResponse_Call) Run(run func(ctx context.Context, serviceName oapi.DbaasServiceName, body oapi.CreateDbaasMysqlUserJSONRequestBody, reqEditors ...oapi.RequestEditorFn)) *mockEgoscaleClient_CreateDbaasMysqlUserWithResponse_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		variadicArgs := make([]oapi.RequestEditorFn, len(args)-2)
-		for i, a := range args[2:] {
+		variadicArgs := make([]oapi.RequestEditorFn, len(args)-3)
+		for i, a := range args[3:] {
 			if a != nil {
 				variadicArgs[i] = a.(oapi.RequestEditorFn)
 			}
 		}
-		run(args[0].(context.Context), args[1].(string), variadicArgs...)
+		run(args[0].(context.Context), args[1].(oapi.DbaasServiceName), args[2].(oapi.CreateDbaasMysqlUserJSONRequestBody), variadicArgs...)
 	})
 	return _c
 }
 
-func (_c *mockEgoscaleClient_GetIamRoleWithResponse_Call) Return(_a0 *oapi.GetIamRoleResponse, _a1 error) *mockEgoscaleClient_GetIamRoleWithResponse_Call {
+func (_c *mockEgoscaleClient_CreateDbaasMysqlUserWithResponse_Call) Return(_a0 *oapi.CreateDbaasMysqlUserResponse, _a1 error) *mockEgoscaleClient_CreateDbaasMysqlUserWithResponse_Call {
 	_c.Call.Return(_a0, _a1)
 	return _c
 }
 
-func (_c *mockEgoscaleClient_GetIamRoleWithResponse_Call) RunAndReturn(run func(context.Context, string, ...oapi.RequestEditorFn) (*oapi.GetIamRoleResponse, error)) *mockEgoscaleClient_GetIamRoleWithResponse_Call {
+func (_c *mockEgoscaleClient_CreateDbaasMysqlUserWithResponse_Call) RunAndReturn(run func(context.Context, oapi.DbaasServiceName, oapi.CreateDbaasMysqlUserJSONRequestBody, ...oapi.RequestEditorFn) (*oapi.CreateDbaasMysqlUserResponse, error)) *mockEgoscaleClient_CreateDbaasMysqlUserWithResponse_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// ListIamRolesWithResponse provides a mock function with given fields: ctx, reqEditors
-func (_m *mockEgoscaleClient) ListIamRolesWithResponse(ctx context.Context, reqEditors ...oapi.RequestEditorFn) (*oapi.ListIamRolesResponse, error) {
+// CreateDbaasOpensearchUserWithResponse provides a mock function with given fields: ctx, serviceName, body, reqEditors
+func (_m *mockEgoscaleClient) CreateDbaasOpensearchUserWithResponse(ctx context.Context, serviceName oapi.DbaasServiceName, body oapi.CreateDbaasOpensearchUserJSONRequestBody, reqEditors ...oapi.RequestEditorFn) (*oapi.CreateDbaasOpensearchUserResponse, error) {
 	_va := make([]interface{}, len(reqEditors))
 	for _i := range reqEditors {
 		_va[_i] = reqEditors[_i]
 	}
 	var _ca []interface{}
-	_ca = append(_ca, ctx)
+	_ca = append(_ca, ctx, serviceName, body)
 	_ca = append(_ca, _va...)
 	ret := _m.Called(_ca...)
 
-	var r0 *oapi.ListIamRolesResponse
+	var r0 *oapi.CreateDbaasOpensearchUserResponse
 	var r1 error
-	if rf, ok := ret.Get(0).(func(context.Context, ...oapi.RequestEditorFn) (*oapi.ListIamRolesResponse, error)); ok {
-		return rf(ctx, reqEditors...)
+	if rf, ok := ret.Get(0).(func(context.Context, oapi.DbaasServiceName, oapi.CreateDbaasOpensearchUserJSONRequestBody, ...oapi.RequestEditorFn) (*oapi.CreateDbaasOpensearchUserResponse, error)); ok {
+		return rf(ctx, serviceName, body, reqEditors...)
 	}
-	if rf, ok := ret.Get(0).(func(context.Context, ...oapi.RequestEditorFn) *oapi.ListIamRolesResponse); ok {
-		r0 = rf(ctx, reqEditors...)
+	if rf, ok := ret.Get(0).(func(context.Context, oapi.DbaasServiceName, oapi.CreateDbaasOpensearchUserJSONRequestBody, ...oapi.RequestEditorFn) *oapi.CreateDbaasOpensearchUserResponse); ok {
+		r0 = rf(ctx, serviceName, body, reqEditors...)
 	} else {
 		if ret.Get(0) != nil {
-			r0 = ret.Get(0).(*oapi.ListIamRolesResponse)
+			r0 = ret.Get(0).(*oapi.CreateDbaasOpensearchUserResponse)
 		}
 	}
 
-	if rf, ok := ret.Get(1).(func(context.Context, ...oapi.RequestEditorFn) error); ok {
-		r1 = rf(ctx, reqEditors...)
+	if rf, ok := ret.Get(1).(func(context.Context, oapi.DbaasServiceName, oapi.CreateDbaasOpensearchUserJSONRequestBody, ...oapi.RequestEditorFn) error); ok {
+		r1 = rf(ctx, serviceName, body, reqEditors...)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -338,38 +411,2504 @@ func (_m *mockEgoscaleClient) ListIamRolesWithResponse(ctx context.Context, reqE
 	return r0, r1
 }
 
-// mockEgoscaleClient_ListIamRolesWithResponse_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListIamRolesWithResponse'
-type mockEgoscaleClient_ListIamRolesWithResponse_Call struct {
+// mockEgoscaleClient_CreateDbaasOpensearchUserWithResponse_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CreateDbaasOpensearchUserWithResponse'
+type mockEgoscaleClient_CreateDbaasOpensearchUserWithResponse_Call struct {
 	*mock.Call
 }
 
-// ListIamRolesWithResponse is a helper method to define mock.On call
+// CreateDbaasOpensearchUserWithResponse is a helper method to define mock.On call
 //   - ctx context.Context
+//   - serviceName oapi.DbaasServiceName
+//   - body oapi.CreateDbaasOpensearchUserJSONRequestBody
 //   - reqEditors ...oapi.RequestEditorFn
-func (_e *mockEgoscaleClient_Expecter) ListIamRolesWithResponse(ctx interface{}, reqEditors ...interface{}) *mockEgoscaleClient_ListIamRolesWithResponse_Call {
-	return &mockEgoscaleClient_ListIamRolesWithResponse_Call{Call: _e.mock.On("ListIamRolesWithResponse",
-		append([]interface{}{ctx}, reqEditors...)...)}
+func (_e *mockEgoscaleClient_Expecter) CreateDbaasOpensearchUserWithResponse(ctx interface{}, serviceName interface{}, body interface{}, reqEditors ...interface{}) *mockEgoscaleClient_CreateDbaasOpensearchUserWithResponse_Call {
+	return &mockEgoscaleClient_CreateDbaasOpensearchUserWithResponse_Call{Call: _e.mock.On("CreateDbaasOpensearchUserWithResponse",
+		append([]interface{}{ctx, serviceName, body}, reqEditors...)...)}
 }
 
-func (_c *mockEgoscaleClient_ListIamRolesWithResponse_Call) Run(run func(ctx context.Context, reqEditors ...oapi.RequestEditorFn)) *mockEgoscaleClient_ListIamRolesWithResponse_Call {
+func (_c *mockEgoscaleClient_CreateDbaasOpensearchUserWithResponse_Call) Run(run func(ctx context.Context, serviceName oapi.DbaasServiceName, body oapi.CreateDbaasOpensearchUserJSONRequestBody, reqEditors ...oapi.RequestEditorFn)) *mockEgoscaleClient_CreateDbaasOpensearchUserWithResponse_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		variadicArgs := make([]oapi.RequestEditorFn, len(args)-1)
-		for i, a := range args[1:] {
+		variadicArgs := make([]oapi.RequestEditorFn, len(args)-3)
+		for i, a := range args[3:] {
 			if a != nil {
 				variadicArgs[i] = a.(oapi.RequestEditorFn)
 			}
 		}
-		run(args[0].(context.Context), variadicArgs...)
+		run(args[0].(context.Context), args[1].(oapi.DbaasServiceName), args[2].(oapi.CreateDbaasOpensearchUserJSONRequestBody), variadicArgs...)
 	})
 	return _c
 }
 
-func (_c *mockEgoscaleClient_ListIamRolesWithResponse_Call) Return(_a0 *oapi.ListIamRolesResponse, _a1 error) *mockEgoscaleClient_ListIamRolesWithResponse_Call {
+func (_c *mockEgoscaleClient_CreateDbaasOpensearchUserWithResponse_Call) Return(_a0 *oapi.CreateDbaasOpensearchUserResponse, _a1 error) *mockEgoscaleClient_CreateDbaasOpensearchUserWithResponse_Call {
 	_c.Call.Return(_a0, _a1)
 	return _c
 }
 
-func (_c *mockEgoscaleClient_ListIamRolesWithResponse_Call) RunAndReturn(run func(context.Context, ...oapi.RequestEditorFn) (*oapi.ListIamRolesResponse, error)) *mockEgoscaleClient_ListIamRolesWithResponse_Call {
+func (_c *mockEgoscaleClient_CreateDbaasOpensearchUserWithResponse_Call) RunAndReturn(run func(context.Context, oapi.DbaasServiceName, oapi.CreateDbaasOpensearchUserJSONRequestBody, ...oapi.RequestEditorFn) (*oapi.CreateDbaasOpensearchUserResponse, error)) *mockEgoscaleClient_CreateDbaasOpensearchUserWithResponse_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// CreateDbaasPostgresUserWithResponse provides a mock function with given fields: ctx, serviceName, body, reqEditors
+func (_m *mockEgoscaleClient) CreateDbaasPostgresUserWithResponse(ctx context.Context, serviceName oapi.DbaasServiceName, body oapi.CreateDbaasPostgresUserJSONRequestBody, reqEditors ...oapi.RequestEditorFn) (*oapi.CreateDbaasPostgresUserResponse, error) {
+	_va := make([]interface{}, len(reqEditors))
+	for _i := range reqEditors {
+		_va[_i] = reqEditors[_i]
+	}
+	var _ca []interface{}
+	_ca = append(_ca, ctx, serviceName, body)
+	_ca = append(_ca, _va...)
+	ret := _m.Called(_ca...)
+
+	var r0 *oapi.CreateDbaasPostgresUserResponse
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, oapi.DbaasServiceName, oapi.CreateDbaasPostgresUserJSONRequestBody, ...oapi.RequestEditorFn) (*oapi.CreateDbaasPostgresUserResponse, error)); ok {
+		return rf(ctx, serviceName, body, reqEditors...)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, oapi.DbaasServiceName, oapi.CreateDbaasPostgresUserJSONRequestBody, ...oapi.RequestEditorFn) *oapi.CreateDbaasPostgresUserResponse); ok {
+		r0 = rf(ctx, serviceName, body, reqEditors...)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*oapi.CreateDbaasPostgresUserResponse)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, oapi.DbaasServiceName, oapi.CreateDbaasPostgresUserJSONRequestBody, ...oapi.RequestEditorFn) error); ok {
+		r1 = rf(ctx, serviceName, body, reqEditors...)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// mockEgoscaleClient_CreateDbaasPostgresUserWithResponse_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CreateDbaasPostgresUserWithResponse'
+type mockEgoscaleClient_CreateDbaasPostgresUserWithResponse_Call struct {
+	*mock.Call
+}
+
+// CreateDbaasPostgresUserWithResponse is a helper method to define mock.On call
+//   - ctx context.Context
+//   - serviceName oapi.DbaasServiceName
+//   - body oapi.CreateDbaasPostgresUserJSONRequestBody
+//   - reqEditors ...oapi.RequestEditorFn
+func (_e *mockEgoscaleClient_Expecter) CreateDbaasPostgresUserWithResponse(ctx interface{}, serviceName interface{}, body interface{}, reqEditors ...interface{}) *mockEgoscaleClient_CreateDbaasPostgresUserWithResponse_Call {
+	return &mockEgoscaleClient_CreateDbaasPostgresUserWithResponse_Call{Call: _e.mock.On("CreateDbaasPostgresUserWithResponse",
+		append([]interface{}{ctx, serviceName, body}, reqEditors...)...)}
+}
+
+func (_c *mockEgoscaleClient_CreateDbaasPostgresUserWithResponse_Call) Run(run func(ctx context.Context, serviceName oapi.DbaasServiceName, body oapi.CreateDbaasPostgresUserJSONRequestBody, reqEditors ...oapi.RequestEditorFn)) *mockEgoscaleClient_CreateDbaasPostgresUserWithResponse_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		variadicArgs := make([]oapi.RequestEditorFn, len(args)-3)
+		for i, a := range args[3:] {
+			if a != nil {
+				variadicArgs[i] = a.(oapi.RequestEditorFn)
+			}
+		}
+		run(args[0].(context.Context), args[1].(oapi.DbaasServiceName), args[2].(oapi.CreateDbaasPostgresUserJSONRequestBody), variadicArgs...)
+	})
+	return _c
+}
+
+func (_c *mockEgoscaleClient_CreateDbaasPostgresUserWithResponse_Call) Return(_a0 *oapi.CreateDbaasPostgresUserResponse, _a1 error) *mockEgoscaleClient_CreateDbaasPostgresUserWithResponse_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *mockEgoscaleClient_CreateDbaasPostgresUserWithResponse_Call) RunAndReturn(run func(context.Context, oapi.DbaasServiceName, oapi.CreateDbaasPostgresUserJSONRequestBody, ...oapi.RequestEditorFn) (*oapi.CreateDbaasPostgresUserResponse, error)) *mockEgoscaleClient_CreateDbaasPostgresUserWithResponse_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// CreateIAMAccessKey provides a mock function with given fields: _a0, _a1, _a2, _a3
+func (_m *mockEgoscaleClient) CreateIAMAccessKey(_a0 context.Context, _a1 string, _a2 string, _a3 ...v2.CreateIAMAccessKeyOpt) (*v2.IAMAccessKey, error) {
+	_va := make([]interface{}, len(_a3))
+	for _i := range _a3 {
+		_va[_i] = _a3[_i]
+	}
+	var _ca []interface{}
+	_ca = append(_ca, _a0, _a1, _a2)
+	_ca = append(_ca, _va...)
+	ret := _m.Called(_ca...)
+
+	var r0 *v2.IAMAccessKey
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, ...v2.CreateIAMAccessKeyOpt) (*v2.IAMAccessKey, error)); ok {
+		return rf(_a0, _a1, _a2, _a3...)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, ...v2.CreateIAMAccessKeyOpt) *v2.IAMAccessKey); ok {
+		r0 = rf(_a0, _a1, _a2, _a3...)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*v2.IAMAccessKey)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, string, ...v2.CreateIAMAccessKeyOpt) error); ok {
+		r1 = rf(_a0, _a1, _a2, _a3...)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// mockEgoscaleClient_CreateIAMAccessKey_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CreateIAMAccessKey'
+type mockEgoscaleClient_CreateIAMAccessKey_Call struct {
+	*mock.Call
+}
+
+// CreateIAMAccessKey is a helper method to define mock.On call
+//   - _a0 context.Context
+//   - _a1 string
+//   - _a2 string
+//   - _a3 ...v2.CreateIAMAccessKeyOpt
+func (_e *mockEgoscaleClient_Expecter) CreateIAMAccessKey(_a0 interface{}, _a1 interface{}, _a2 interface{}, _a3 ...interface{}) *mockEgoscaleClient_CreateIAMAccessKey_Call {
+	return &mockEgoscaleClient_CreateIAMAccessKey_Call{Call: _e.mock.On("CreateIAMAccessKey",
+		append([]interface{}{_a0, _a1, _a2}, _a3...)...)}
+}
+
+func (_c *mockEgoscaleClient_CreateIAMAccessKey_Call) Run(run func(_a0 context.Context, _a1 string, _a2 string, _a3 ...v2.CreateIAMAccessKeyOpt)) *mockEgoscaleClient_CreateIAMAccessKey_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		variadicArgs := make([]v2.CreateIAMAccessKeyOpt, len(args)-3)
+		for i, a := range args[3:] {
+			if a != nil {
+				variadicArgs[i] = a.(v2.CreateIAMAccessKeyOpt)
+			}
+		}
+		run(args[0].(context.Context), args[1].(string), args[2].(string), variadicArgs...)
+	})
+	return _c
+}
+
+func (_c *mockEgoscaleClient_CreateIAMAccessKey_Call) Return(_a0 *v2.IAMAccessKey, _a1 error) *mockEgoscaleClient_CreateIAMAccessKey_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *mockEgoscaleClient_CreateIAMAccessKey_Call) RunAndReturn(run func(context.Context, string, string, ...v2.CreateIAMAccessKeyOpt) (*v2.IAMAccessKey, error)) *mockEgoscaleClient_CreateIAMAccessKey_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// CreateIamRoleWithResponse provides a mock function with given fields: ctx, body, reqEditors
+func (_m *mockEgoscaleClient) CreateIamRoleWithResponse(ctx context.Context, body oapi.CreateIamRoleJSONRequestBody, reqEditors ...oapi.RequestEditorFn) (*oapi.CreateIamRoleResponse, error) {
+	_va := make([]interface{}, len(reqEditors))
+	for _i := range reqEditors {
+		_va[_i] = reqEditors[_i]
+	}
+	var _ca []interface{}
+	_ca = append(_ca, ctx, body)
+	_ca = append(_ca, _va...)
+	ret := _m.Called(_ca...)
+
+	var r0 *oapi.CreateIamRoleResponse
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, oapi.CreateIamRoleJSONRequestBody, ...oapi.RequestEditorFn) (*oapi.CreateIamRoleResponse, error)); ok {
+		return rf(ctx, body, reqEditors...)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, oapi.CreateIamRoleJSONRequestBody, ...oapi.RequestEditorFn) *oapi.CreateIamRoleResponse); ok {
+		r0 = rf(ctx, body, reqEditors...)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*oapi.CreateIamRoleResponse)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, oapi.CreateIamRoleJSONRequestBody, ...oapi.RequestEditorFn) error); ok {
+		r1 = rf(ctx, body, reqEditors...)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// mockEgoscaleClient_CreateIamRoleWithResponse_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CreateIamRoleWithResponse'
+type mockEgoscaleClient_CreateIamRoleWithResponse_Call struct {
+	*mock.Call
+}
+
+// CreateIamRoleWithResponse is a helper method to define mock.On call
+//   - ctx context.Context
+//   - body oapi.CreateIamRoleJSONRequestBody
+//   - reqEditors ...oapi.RequestEditorFn
+func (_e *mockEgoscaleClient_Expecter) CreateIamRoleWithResponse(ctx interface{}, body interface{}, reqEditors ...interface{}) *mockEgoscaleClient_CreateIamRoleWithResponse_Call {
+	return &mockEgoscaleClient_CreateIamRoleWithResponse_Call{Call: _e.mock.On("CreateIamRoleWithResponse",
+		append([]interface{}{ctx, body}, reqEditors...)...)}
+}
+
+func (_c *mockEgoscaleClient_CreateIamRoleWithResponse_Call) Run(run func(ctx context.Context, body oapi.CreateIamRoleJSONRequestBody, reqEditors ...oapi.RequestEditorFn)) *mockEgoscaleClient_CreateIamRoleWithResponse_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		variadicArgs := make([]oapi.RequestEditorFn, len(args)-2)
+		for i, a := range args[2:] {
+			if a != nil {
+				variadicArgs[i] = a.(oapi.RequestEditorFn)
+			}
+		}
+		run(args[0].(context.Context), args[1].(oapi.CreateIamRoleJSONRequestBody), variadicArgs...)
+	})
+	return _c
+}
+
+func (_c *mockEgoscaleClient_CreateIamRoleWithResponse_Call) Return(_a0 *oapi.CreateIamRoleResponse, _a1 error) *mockEgoscaleClient_CreateIamRoleWithResponse_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *mockEgoscaleClient_CreateIamRoleWithResponse_Call) RunAndReturn(run func(context.Context, oapi.CreateIamRoleJSONRequestBody, ...oapi.RequestEditorFn) (*oapi.CreateIamRoleResponse, error)) *mockEgoscaleClient_CreateIamRoleWithResponse_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// DeleteApiKeyWithResponse provides a mock function with given fields: ctx, id, reqEditors
+func (_m *mockEgoscaleClient) DeleteApiKeyWithResponse(ctx context.Context, id string, reqEditors ...oapi.RequestEditorFn) (*oapi.DeleteApiKeyResponse, error) {
+	_va := make([]interface{}, len(reqEditors))
+	for _i := range reqEditors {
+		_va[_i] = reqEditors[_i]
+	}
+	var _ca []interface{}
+	_ca = append(_ca, ctx, id)
+	_ca = append(_ca, _va...)
+	ret := _m.Called(_ca...)
+
+	var r0 *oapi.DeleteApiKeyResponse
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, ...oapi.RequestEditorFn) (*oapi.DeleteApiKeyResponse, error)); ok {
+		return rf(ctx, id, reqEditors...)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, ...oapi.RequestEditorFn) *oapi.DeleteApiKeyResponse); ok {
+		r0 = rf(ctx, id, reqEditors...)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*oapi.DeleteApiKeyResponse)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, ...oapi.RequestEditorFn) error); ok {
+		r1 = rf(ctx, id, reqEditors...)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// mockEgoscaleClient_DeleteApiKeyWithResponse_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeleteApiKeyWithResponse'
+type mockEgoscaleClient_DeleteApiKeyWithResponse_Call struct {
+	*mock.Call
+}
+
+// DeleteApiKeyWithResponse is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id string
+//   - reqEditors ...oapi.RequestEditorFn
+func (_e *mockEgoscaleClient_Expecter) DeleteApiKeyWithResponse(ctx interface{}, id interface{}, reqEditors ...interface{}) *mockEgoscaleClient_DeleteApiKeyWithResponse_Call {
+	return &mockEgoscaleClient_DeleteApiKeyWithResponse_Call{Call: _e.mock.On("DeleteApiKeyWithResponse",
+		append([]interface{}{ctx, id}, reqEditors...)...)}
+}
+
+func (_c *mockEgoscaleClient_DeleteApiKeyWithResponse_Call) Run(run func(ctx context.Context, id string, reqEditors ...oapi.RequestEditorFn)) *mockEgoscaleClient_DeleteApiKeyWithResponse_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		variadicArgs := make([]oapi.RequestEditorFn, len(args)-2)
+		for i, a := range args[2:] {
+			if a != nil {
+				variadicArgs[i] = a.(oapi.RequestEditorFn)
+			}
+		}
+		run(args[0].(context.Context), args[1].(string), variadicArgs...)
+	})
+	return _c
+}
+
+func (_c *mockEgoscaleClient_DeleteApiKeyWithResponse_Call) Return(_a0 *oapi.DeleteApiKeyResponse, _a1 error) *mockEgoscaleClient_DeleteApiKeyWithResponse_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *mockEgoscaleClient_DeleteApiKeyWithResponse_Call) RunAndReturn(run func(context.Context, string, ...oapi.RequestEditorFn) (*oapi.DeleteApiKeyResponse, error)) *mockEgoscaleClient_DeleteApiKeyWithResponse_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// DeleteDbaasKafkaTopicAclConfigWithResponse provides a mock function with given fields: ctx, name, aclId, reqEditors
+func (_m *mockEgoscaleClient) DeleteDbaasKafkaTopicAclConfigWithResponse(ctx context.Context, name oapi.DbaasServiceName, aclId oapi.DbaasKafkaAclId, reqEditors ...oapi.RequestEditorFn) (*oapi.DeleteDbaasKafkaTopicAclConfigResponse, error) {
+	_va := make([]interface{}, len(reqEditors))
+	for _i := range reqEditors {
+		_va[_i] = reqEditors[_i]
+	}
+	var _ca []interface{}
+	_ca = append(_ca, ctx, name, aclId)
+	_ca = append(_ca, _va...)
+	ret := _m.Called(_ca...)
+
+	var r0 *oapi.DeleteDbaasKafkaTopicAclConfigResponse
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, oapi.DbaasServiceName, oapi.DbaasKafkaAclId, ...oapi.RequestEditorFn) (*oapi.DeleteDbaasKafkaTopicAclConfigResponse, error)); ok {
+		return rf(ctx, name, aclId, reqEditors...)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, oapi.DbaasServiceName, oapi.DbaasKafkaAclId, ...oapi.RequestEditorFn) *oapi.DeleteDbaasKafkaTopicAclConfigResponse); ok {
+		r0 = rf(ctx, name, aclId, reqEditors...)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*oapi.DeleteDbaasKafkaTopicAclConfigResponse)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, oapi.DbaasServiceName, oapi.DbaasKafkaAclId, ...oapi.RequestEditorFn) error); ok {
+		r1 = rf(ctx, name, aclId, reqEditors...)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// mockEgoscaleClient_DeleteDbaasKafkaTopicAclConfigWithResponse_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeleteDbaasKafkaTopicAclConfigWithResponse'
+type mockEgoscaleClient_DeleteDbaasKafkaTopicAclConfigWithResponse_Call struct {
+	*mock.Call
+}
+
+// DeleteDbaasKafkaTopicAclConfigWithResponse is a helper method to define mock.On call
+//   - ctx context.Context
+//   - name oapi.DbaasServiceName
+//   - aclId oapi.DbaasKafkaAclId
+//   - reqEditors ...oapi.RequestEditorFn
+func (_e *mockEgoscaleClient_Expecter) DeleteDbaasKafkaTopicAclConfigWithResponse(ctx interface{}, name interface{}, aclId interface{}, reqEditors ...interface{}) *mockEgoscaleClient_DeleteDbaasKafkaTopicAclConfigWithResponse_Call {
+	return &mockEgoscaleClient_DeleteDbaasKafkaTopicAclConfigWithResponse_Call{Call: _e.mock.On("DeleteDbaasKafkaTopicAclConfigWithResponse",
+		append([]interface{}{ctx, name, aclId}, reqEditors...)...)}
+}
+
+func (_c *mockEgoscaleClient_DeleteDbaasKafkaTopicAclConfigWithResponse_Call) Run(run func(ctx context.Context, name oapi.DbaasServiceName, aclId oapi.DbaasKafkaAclId, reqEditors ...oapi.RequestEditorFn)) *mockEgoscaleClient_DeleteDbaasKafkaTopicAclConfigWithResponse_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		variadicArgs := make([]oapi.RequestEditorFn, len(args)-3)
+		for i, a := range args[3:] {
+			if a != nil {
+				variadicArgs[i] = a.(oapi.RequestEditorFn)
+			}
+		}
+		run(args[0].(context.Context), args[1].(oapi.DbaasServiceName), args[2].(oapi.DbaasKafkaAclId), variadicArgs...)
+	})
+	return _c
+}
+
+func (_c *mockEgoscaleClient_DeleteDbaasKafkaTopicAclConfigWithResponse_Call) Return(_a0 *oapi.DeleteDbaasKafkaTopicAclConfigResponse, _a1 error) *mockEgoscaleClient_DeleteDbaasKafkaTopicAclConfigWithResponse_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *mockEgoscaleClient_DeleteDbaasKafkaTopicAclConfigWithResponse_Call) RunAndReturn(run func(context.Context, oapi.DbaasServiceName, oapi.DbaasKafkaAclId, ...oapi.RequestEditorFn) (*oapi.DeleteDbaasKafkaTopicAclConfigResponse, error)) *mockEgoscaleClient_DeleteDbaasKafkaTopicAclConfigWithResponse_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// DeleteDbaasKafkaUserWithResponse provides a mock function with given fields: ctx, serviceName, username, reqEditors
+func (_m *mockEgoscaleClient) DeleteDbaasKafkaUserWithResponse(ctx context.Context, serviceName oapi.DbaasServiceName, username oapi.DbaasUserUsername, reqEditors ...oapi.RequestEditorFn) (*oapi.DeleteDbaasKafkaUserResponse, error) {
+	_va := make([]interface{}, len(reqEditors))
+	for _i := range reqEditors {
+		_va[_i] = reqEditors[_i]
+	}
+	var _ca []interface{}
+	_ca = append(_ca, ctx, serviceName, username)
+	_ca = append(_ca, _va...)
+	ret := _m.Called(_ca...)
+
+	var r0 *oapi.DeleteDbaasKafkaUserResponse
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, oapi.DbaasServiceName, oapi.DbaasUserUsername, ...oapi.RequestEditorFn) (*oapi.DeleteDbaasKafkaUserResponse, error)); ok {
+		return rf(ctx, serviceName, username, reqEditors...)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, oapi.DbaasServiceName, oapi.DbaasUserUsername, ...oapi.RequestEditorFn) *oapi.DeleteDbaasKafkaUserResponse); ok {
+		r0 = rf(ctx, serviceName, username, reqEditors...)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*oapi.DeleteDbaasKafkaUserResponse)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, oapi.DbaasServiceName, oapi.DbaasUserUsername, ...oapi.RequestEditorFn) error); ok {
+		r1 = rf(ctx, serviceName, username, reqEditors...)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// mockEgoscaleClient_DeleteDbaasKafkaUserWithResponse_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeleteDbaasKafkaUserWithResponse'
+type mockEgoscaleClient_DeleteDbaasKafkaUserWithResponse_Call struct {
+	*mock.Call
+}
+
+// DeleteDbaasKafkaUserWithResponse is a helper method to define mock.On call
+//   - ctx context.Context
+//   - serviceName oapi.DbaasServiceName
+//   - username oapi.DbaasUserUsername
+//   - reqEditors ...oapi.RequestEditorFn
+func (_e *mockEgoscaleClient_Expecter) DeleteDbaasKafkaUserWithResponse(ctx interface{}, serviceName interface{}, username interface{}, reqEditors ...interface{}) *mockEgoscaleClient_DeleteDbaasKafkaUserWithResponse_Call {
+	return &mockEgoscaleClient_DeleteDbaasKafkaUserWithResponse_Call{Call: _e.mock.On("DeleteDbaasKafkaUserWithResponse",
+		append([]interface{}{ctx, serviceName, username}, reqEditors...)...)}
+}
+
+func (_c *mockEgoscaleClient_DeleteDbaasKafkaUserWithResponse_Call) Run(run func(ctx context.Context, serviceName oapi.DbaasServiceName, username oapi.DbaasUserUsername, reqEditors ...oapi.RequestEditorFn)) *mockEgoscaleClient_DeleteDbaasKafkaUserWithResponse_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		variadicArgs := make([]oapi.RequestEditorFn, len(args)-3)
+		for i, a := range args[3:] {
+			if a != nil {
+				variadicArgs[i] = a.(oapi.RequestEditorFn)
+			}
+		}
+		run(args[0].(context.Context), args[1].(oapi.DbaasServiceName), args[2].(oapi.DbaasUserUsername), variadicArgs...)
+	})
+	return _c
+}
+
+func (_c *mockEgoscaleClient_DeleteDbaasKafkaUserWithResponse_Call) Return(_a0 *oapi.DeleteDbaasKafkaUserResponse, _a1 error) *mockEgoscaleClient_DeleteDbaasKafkaUserWithResponse_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *mockEgoscaleClient_DeleteDbaasKafkaUserWithResponse_Call) RunAndReturn(run func(context.Context, oapi.DbaasServiceName, oapi.DbaasUserUsername, ...oapi.RequestEditorFn) (*oapi.DeleteDbaasKafkaUserResponse, error)) *mockEgoscaleClient_DeleteDbaasKafkaUserWithResponse_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// DeleteDbaasMysqlUserWithResponse provides a mock function with given fields: ctx, serviceName, username, reqEditors
+func (_m *mockEgoscaleClient) DeleteDbaasMysqlUserWithResponse(ctx context.Context, serviceName oapi.DbaasServiceName, username oapi.DbaasUserUsername, reqEditors ...oapi.RequestEditorFn) (*oapi.DeleteDbaasMysqlUserResponse, error) {
+	_va := make([]interface{}, len(reqEditors))
+	for _i := range reqEditors {
+		_va[_i] = reqEditors[_i]
+	}
+	var _ca []interface{}
+	_ca = append(_ca, ctx, serviceName, username)
+	_ca = append(_ca, _va...)
+	ret := _m.Called(_ca...)
+
+	var r0 *oapi.DeleteDbaasMysqlUserResponse
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, oapi.DbaasServiceName, oapi.DbaasUserUsername, ...oapi.RequestEditorFn) (*oapi.DeleteDbaasMysqlUserResponse, error)); ok {
+		return rf(ctx, serviceName, username, reqEditors...)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, oapi.DbaasServiceName, oapi.DbaasUserUsername, ...oapi.RequestEditorFn) *oapi.DeleteDbaasMysqlUserResponse); ok {
+		r0 = rf(ctx, serviceName, username, reqEditors...)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*oapi.DeleteDbaasMysqlUserResponse)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, oapi.DbaasServiceName, oapi.DbaasUserUsername, ...oapi.RequestEditorFn) error); ok {
+		r1 = rf(ctx, serviceName, username, reqEditors...)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// mockEgoscaleClient_DeleteDbaasMysqlUserWithResponse_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeleteDbaasMysqlUserWithResponse'
+type mockEgoscaleClient_DeleteDbaasMysqlUserWithResponse_Call struct {
+	*mock.Call
+}
+
+// DeleteDbaasMysqlUserWithResponse is a helper method to define mock.On call
+//   - ctx context.Context
+//   - serviceName oapi.DbaasServiceName
+//   - username oapi.DbaasUserUsername
+//   - reqEditors ...oapi.RequestEditorFn
+func (_e *mockEgoscaleClient_Expecter) DeleteDbaasMysqlUserWithResponse(ctx interface{}, serviceName interface{}, username interface{}, reqEditors ...interface{}) *mockEgoscaleClient_DeleteDbaasMysqlUserWithResponse_Call {
+	return &mockEgoscaleClient_DeleteDbaasMysqlUserWithResponse_Call{Call: _e.mock.On("DeleteDbaasMysqlUserWithResponse",
+		append([]interface{}{ctx, serviceName, username}, reqEditors...)...)}
+}
+
+func (_c *mockEgoscaleClient_DeleteDbaasMysqlUserWithResponse_Call) Run(run func(ctx context.Context, serviceName oapi.DbaasServiceName, username oapi.DbaasUserUsername, reqEditors ...oapi.RequestEditorFn)) *mockEgoscaleClient_DeleteDbaasMysqlUserWithResponse_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		variadicArgs := make([]oapi.RequestEditorFn, len(args)-3)
+		for i, a := range args[3:] {
+			if a != nil {
+				variadicArgs[i] = a.(oapi.RequestEditorFn)
+			}
+		}
+		run(args[0].(context.Context), args[1].(oapi.DbaasServiceName), args[2].(oapi.DbaasUserUsername), variadicArgs...)
+	})
+	return _c
+}
+
+func (_c *mockEgoscaleClient_DeleteDbaasMysqlUserWithResponse_Call) Return(_a0 *oapi.DeleteDbaasMysqlUserResponse, _a1 error) *mockEgoscaleClient_DeleteDbaasMysqlUserWithResponse_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *mockEgoscaleClient_DeleteDbaasMysqlUserWithResponse_Call) RunAndReturn(run func(context.Context, oapi.DbaasServiceName, oapi.DbaasUserUsername, ...oapi.RequestEditorFn) (*oapi.DeleteDbaasMysqlUserResponse, error)) *mockEgoscaleClient_DeleteDbaasMysqlUserWithResponse_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// DeleteDbaasOpensearchUserWithResponse provides a mock function with given fields: ctx, serviceName, username, reqEditors
+func (_m *mockEgoscaleClient) DeleteDbaasOpensearchUserWithResponse(ctx context.Context, serviceName oapi.DbaasServiceName, username oapi.DbaasUserUsername, reqEditors ...oapi.RequestEditorFn) (*oapi.DeleteDbaasOpensearchUserResponse, error) {
+	_va := make([]interface{}, len(reqEditors))
+	for _i := range reqEditors {
+		_va[_i] = reqEditors[_i]
+	}
+	var _ca []interface{}
+	_ca = append(_ca, ctx, serviceName, username)
+	_ca = append(_ca, _va...)
+	ret := _m.Called(_ca...)
+
+	var r0 *oapi.DeleteDbaasOpensearchUserResponse
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, oapi.DbaasServiceName, oapi.DbaasUserUsername, ...oapi.RequestEditorFn) (*oapi.DeleteDbaasOpensearchUserResponse, error)); ok {
+		return rf(ctx, serviceName, username, reqEditors...)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, oapi.DbaasServiceName, oapi.DbaasUserUsername, ...oapi.RequestEditorFn) *oapi.DeleteDbaasOpensearchUserResponse); ok {
+		r0 = rf(ctx, serviceName, username, reqEditors...)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*oapi.DeleteDbaasOpensearchUserResponse)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, oapi.DbaasServiceName, oapi.DbaasUserUsername, ...oapi.RequestEditorFn) error); ok {
+		r1 = rf(ctx, serviceName, username, reqEditors...)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// mockEgoscaleClient_DeleteDbaasOpensearchUserWithResponse_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeleteDbaasOpensearchUserWithResponse'
+type mockEgoscaleClient_DeleteDbaasOpensearchUserWithResponse_Call struct {
+	*mock.Call
+}
+
+// DeleteDbaasOpensearchUserWithResponse is a helper method to define mock.On call
+//   - ctx context.Context
+//   - serviceName oapi.DbaasServiceName
+//   - username oapi.DbaasUserUsername
+//   - reqEditors ...oapi.RequestEditorFn
+func (_e *mockEgoscaleClient_Expecter) DeleteDbaasOpensearchUserWithResponse(ctx interface{}, serviceName interface{}, username interface{}, reqEditors ...interface{}) *mockEgoscaleClient_DeleteDbaasOpensearchUserWithResponse_Call {
+	return &mockEgoscaleClient_DeleteDbaasOpensearchUserWithResponse_Call{Call: _e.mock.On("DeleteDbaasOpensearchUserWithResponse",
+		append([]interface{}{ctx, serviceName, username}, reqEditors...)...)}
+}
+
+func (_c *mockEgoscaleClient_DeleteDbaasOpensearchUserWithResponse_Call) Run(run func(ctx context.Context, serviceName oapi.DbaasServiceName, username oapi.DbaasUserUsername, reqEditors ...oapi.RequestEditorFn)) *mockEgoscaleClient_DeleteDbaasOpensearchUserWithResponse_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		variadicArgs := make([]oapi.RequestEditorFn, len(args)-3)
+		for i, a := range args[3:] {
+			if a != nil {
+				variadicArgs[i] = a.(oapi.RequestEditorFn)
+			}
+		}
+		run(args[0].(context.Context), args[1].(oapi.DbaasServiceName), args[2].(oapi.DbaasUserUsername), variadicArgs...)
+	})
+	return _c
+}
+
+func (_c *mockEgoscaleClient_DeleteDbaasOpensearchUserWithResponse_Call) Return(_a0 *oapi.DeleteDbaasOpensearchUserResponse, _a1 error) *mockEgoscaleClient_DeleteDbaasOpensearchUserWithResponse_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *mockEgoscaleClient_DeleteDbaasOpensearchUserWithResponse_Call) RunAndReturn(run func(context.Context, oapi.DbaasServiceName, oapi.DbaasUserUsername, ...oapi.RequestEditorFn) (*oapi.DeleteDbaasOpensearchUserResponse, error)) *mockEgoscaleClient_DeleteDbaasOpensearchUserWithResponse_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// DeleteDbaasPostgresUserWithResponse provides a mock function with given fields: ctx, serviceName, username, reqEditors
+func (_m *mockEgoscaleClient) DeleteDbaasPostgresUserWithResponse(ctx context.Context, serviceName oapi.DbaasServiceName, username oapi.DbaasUserUsername, reqEditors ...oapi.RequestEditorFn) (*oapi.DeleteDbaasPostgresUserResponse, error) {
+	_va := make([]interface{}, len(reqEditors))
+	for _i := range reqEditors {
+		_va[_i] = reqEditors[_i]
+	}
+	var _ca []interface{}
+	_ca = append(_ca, ctx, serviceName, username)
+	_ca = append(_ca, _va...)
+	ret := _m.Called(_ca...)
+
+	var r0 *oapi.DeleteDbaasPostgresUserResponse
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, oapi.DbaasServiceName, oapi.DbaasUserUsername, ...oapi.RequestEditorFn) (*oapi.DeleteDbaasPostgresUserResponse, error)); ok {
+		return rf(ctx, serviceName, username, reqEditors...)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, oapi.DbaasServiceName, oapi.DbaasUserUsername, ...oapi.RequestEditorFn) *oapi.DeleteDbaasPostgresUserResponse); ok {
+		r0 = rf(ctx, serviceName, username, reqEditors...)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*oapi.DeleteDbaasPostgresUserResponse)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, oapi.DbaasServiceName, oapi.DbaasUserUsername, ...oapi.RequestEditorFn) error); ok {
+		r1 = rf(ctx, serviceName, username, reqEditors...)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// mockEgoscaleClient_DeleteDbaasPostgresUserWithResponse_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeleteDbaasPostgresUserWithResponse'
+type mockEgoscaleClient_DeleteDbaasPostgresUserWithResponse_Call struct {
+	*mock.Call
+}
+
+// DeleteDbaasPostgresUserWithResponse is a helper method to define mock.On call
+//   - ctx context.Context
+//   - serviceName oapi.DbaasServiceName
+//   - username oapi.DbaasUserUsername
+//   - reqEditors ...oapi.RequestEditorFn
+func (_e *mockEgoscaleClient_Expecter) DeleteDbaasPostgresUserWithResponse(ctx interface{}, serviceName interface{}, username interface{}, reqEditors ...interface{}) *mockEgoscaleClient_DeleteDbaasPostgresUserWithResponse_Call {
+	return &mockEgoscaleClient_DeleteDbaasPostgresUserWithResponse_Call{Call: _e.mock.On("DeleteDbaasPostgresUserWithResponse",
+		append([]interface{}{ctx, serviceName, username}, reqEditors...)...)}
+}
+
+func (_c *mockEgoscaleClient_DeleteDbaasPostgresUserWithResponse_Call) Run(run func(ctx context.Context, serviceName oapi.DbaasServiceName, username oapi.DbaasUserUsername, reqEditors ...oapi.RequestEditorFn)) *mockEgoscaleClient_DeleteDbaasPostgresUserWithResponse_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		variadicArgs := make([]oapi.RequestEditorFn, len(args)-3)
+		for i, a := range args[3:] {
+			if a != nil {
+				variadicArgs[i] = a.(oapi.RequestEditorFn)
+			}
+		}
+		run(args[0].(context.Context), args[1].(oapi.DbaasServiceName), args[2].(oapi.DbaasUserUsername), variadicArgs...)
+	})
+	return _c
+}
+
+func (_c *mockEgoscaleClient_DeleteDbaasPostgresUserWithResponse_Call) Return(_a0 *oapi.DeleteDbaasPostgresUserResponse, _a1 error) *mockEgoscaleClient_DeleteDbaasPostgresUserWithResponse_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *mockEgoscaleClient_DeleteDbaasPostgresUserWithResponse_Call) RunAndReturn(run func(context.Context, oapi.DbaasServiceName, oapi.DbaasUserUsername, ...oapi.RequestEditorFn) (*oapi.DeleteDbaasPostgresUserResponse, error)) *mockEgoscaleClient_DeleteDbaasPostgresUserWithResponse_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// DeleteIamRoleWithResponse provides a mock function with given fields: ctx, id, reqEditors
+func (_m *mockEgoscaleClient) DeleteIamRoleWithResponse(ctx context.Context, id string, reqEditors ...oapi.RequestEditorFn) (*oapi.DeleteIamRoleResponse, error) {
+	_va := make([]interface{}, len(reqEditors))
+	for _i := range reqEditors {
+		_va[_i] = reqEditors[_i]
+	}
+	var _ca []interface{}
+	_ca = append(_ca, ctx, id)
+	_ca = append(_ca, _va...)
+	ret := _m.Called(_ca...)
+
+	var r0 *oapi.DeleteIamRoleResponse
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, ...oapi.RequestEditorFn) (*oapi.DeleteIamRoleResponse, error)); ok {
+		return rf(ctx, id, reqEditors...)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, ...oapi.RequestEditorFn) *oapi.DeleteIamRoleResponse); ok {
+		r0 = rf(ctx, id, reqEditors...)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*oapi.DeleteIamRoleResponse)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, ...oapi.RequestEditorFn) error); ok {
+		r1 = rf(ctx, id, reqEditors...)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// mockEgoscaleClient_DeleteIamRoleWithResponse_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeleteIamRoleWithResponse'
+type mockEgoscaleClient_DeleteIamRoleWithResponse_Call struct {
+	*mock.Call
+}
+
+// DeleteIamRoleWithResponse is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id string
+//   - reqEditors ...oapi.RequestEditorFn
+func (_e *mockEgoscaleClient_Expecter) DeleteIamRoleWithResponse(ctx interface{}, id interface{}, reqEditors ...interface{}) *mockEgoscaleClient_DeleteIamRoleWithResponse_Call {
+	return &mockEgoscaleClient_DeleteIamRoleWithResponse_Call{Call: _e.mock.On("DeleteIamRoleWithResponse",
+		append([]interface{}{ctx, id}, reqEditors...)...)}
+}
+
+func (_c *mockEgoscaleClient_DeleteIamRoleWithResponse_Call) Run(run func(ctx context.Context, id string, reqEditors ...oapi.RequestEditorFn)) *mockEgoscaleClient_DeleteIamRoleWithResponse_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		variadicArgs := make([]oapi.RequestEditorFn, len(args)-2)
+		for i, a := range args[2:] {
+			if a != nil {
+				variadicArgs[i] = a.(oapi.RequestEditorFn)
+			}
+		}
+		run(args[0].(context.Context), args[1].(string), variadicArgs...)
+	})
+	return _c
+}
+
+func (_c *mockEgoscaleClient_DeleteIamRoleWithResponse_Call) Return(_a0 *oapi.DeleteIamRoleResponse, _a1 error) *mockEgoscaleClient_DeleteIamRoleWithResponse_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *mockEgoscaleClient_DeleteIamRoleWithResponse_Call) RunAndReturn(run func(context.Context, string, ...oapi.RequestEditorFn) (*oapi.DeleteIamRoleResponse, error)) *mockEgoscaleClient_DeleteIamRoleWithResponse_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// DeleteRuleFromSecurityGroupWithResponse provides a mock function with given fields: ctx, id, ruleId, reqEditors
+func (_m *mockEgoscaleClient) DeleteRuleFromSecurityGroupWithResponse(ctx context.Context, id string, ruleId string, reqEditors ...oapi.RequestEditorFn) (*oapi.DeleteRuleFromSecurityGroupResponse, error) {
+	_va := make([]interface{}, len(reqEditors))
+	for _i := range reqEditors {
+		_va[_i] = reqEditors[_i]
+	}
+	var _ca []interface{}
+	_ca = append(_ca, ctx, id, ruleId)
+	_ca = append(_ca, _va...)
+	ret := _m.Called(_ca...)
+
+	var r0 *oapi.DeleteRuleFromSecurityGroupResponse
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, ...oapi.RequestEditorFn) (*oapi.DeleteRuleFromSecurityGroupResponse, error)); ok {
+		return rf(ctx, id, ruleId, reqEditors...)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, ...oapi.RequestEditorFn) *oapi.DeleteRuleFromSecurityGroupResponse); ok {
+		r0 = rf(ctx, id, ruleId, reqEditors...)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*oapi.DeleteRuleFromSecurityGroupResponse)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, string, ...oapi.RequestEditorFn) error); ok {
+		r1 = rf(ctx, id, ruleId, reqEditors...)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// mockEgoscaleClient_DeleteRuleFromSecurityGroupWithResponse_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeleteRuleFromSecurityGroupWithResponse'
+type mockEgoscaleClient_DeleteRuleFromSecurityGroupWithResponse_Call struct {
+	*mock.Call
+}
+
+// DeleteRuleFromSecurityGroupWithResponse is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id string
+//   - ruleId string
+//   - reqEditors ...oapi.RequestEditorFn
+func (_e *mockEgoscaleClient_Expecter) DeleteRuleFromSecurityGroupWithResponse(ctx interface{}, id interface{}, ruleId interface{}, reqEditors ...interface{}) *mockEgoscaleClient_DeleteRuleFromSecurityGroupWithResponse_Call {
+	return &mockEgoscaleClient_DeleteRuleFromSecurityGroupWithResponse_Call{Call: _e.mock.On("DeleteRuleFromSecurityGroupWithResponse",
+		append([]interface{}{ctx, id, ruleId}, reqEditors...)...)}
+}
+
+func (_c *mockEgoscaleClient_DeleteRuleFromSecurityGroupWithResponse_Call) Run(run func(ctx context.Context, id string, ruleId string, reqEditors ...oapi.RequestEditorFn)) *mockEgoscaleClient_DeleteRuleFromSecurityGroupWithResponse_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		variadicArgs := make([]oapi.RequestEditorFn, len(args)-3)
+		for i, a := range args[3:] {
+			if a != nil {
+				variadicArgs[i] = a.(oapi.RequestEditorFn)
+			}
+		}
+		run(args[0].(context.Context), args[1].(string), args[2].(string), variadicArgs...)
+	})
+	return _c
+}
+
+func (_c *mockEgoscaleClient_DeleteRuleFromSecurityGroupWithResponse_Call) Return(_a0 *oapi.DeleteRuleFromSecurityGroupResponse, _a1 error) *mockEgoscaleClient_DeleteRuleFromSecurityGroupWithResponse_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *mockEgoscaleClient_DeleteRuleFromSecurityGroupWithResponse_Call) RunAndReturn(run func(context.Context, string, string, ...oapi.RequestEditorFn) (*oapi.DeleteRuleFromSecurityGroupResponse, error)) *mockEgoscaleClient_DeleteRuleFromSecurityGroupWithResponse_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// DeleteSshKeyWithResponse provides a mock function with given fields: ctx, name, reqEditors
+func (_m *mockEgoscaleClient) DeleteSshKeyWithResponse(ctx context.Context, name string, reqEditors ...oapi.RequestEditorFn) (*oapi.DeleteSshKeyResponse, error) {
+	_va := make([]interface{}, len(reqEditors))
+	for _i := range reqEditors {
+		_va[_i] = reqEditors[_i]
+	}
+	var _ca []interface{}
+	_ca = append(_ca, ctx, name)
+	_ca = append(_ca, _va...)
+	ret := _m.Called(_ca...)
+
+	var r0 *oapi.DeleteSshKeyResponse
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, ...oapi.RequestEditorFn) (*oapi.DeleteSshKeyResponse, error)); ok {
+		return rf(ctx, name, reqEditors...)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, ...oapi.RequestEditorFn) *oapi.DeleteSshKeyResponse); ok {
+		r0 = rf(ctx, name, reqEditors...)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*oapi.DeleteSshKeyResponse)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, ...oapi.RequestEditorFn) error); ok {
+		r1 = rf(ctx, name, reqEditors...)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// mockEgoscaleClient_DeleteSshKeyWithResponse_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeleteSshKeyWithResponse'
+type mockEgoscaleClient_DeleteSshKeyWithResponse_Call struct {
+	*mock.Call
+}
+
+// DeleteSshKeyWithResponse is a helper method to define mock.On call
+//   - ctx context.Context
+//   - name string
+//   - reqEditors ...oapi.RequestEditorFn
+func (_e *mockEgoscaleClient_Expecter) DeleteSshKeyWithResponse(ctx interface{}, name interface{}, reqEditors ...interface{}) *mockEgoscaleClient_DeleteSshKeyWithResponse_Call {
+	return &mockEgoscaleClient_DeleteSshKeyWithResponse_Call{Call: _e.mock.On("DeleteSshKeyWithResponse",
+		append([]interface{}{ctx, name}, reqEditors...)...)}
+}
+
+func (_c *mockEgoscaleClient_DeleteSshKeyWithResponse_Call) Run(run func(ctx context.Context, name string, reqEditors ...oapi.RequestEditorFn)) *mockEgoscaleClient_DeleteSshKeyWithResponse_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		variadicArgs := make([]oapi.RequestEditorFn, len(args)-2)
+		for i, a := range args[2:] {
+			if a != nil {
+				variadicArgs[i] = a.(oapi.RequestEditorFn)
+			}
+		}
+		run(args[0].(context.Context), args[1].(string), variadicArgs...)
+	})
+	return _c
+}
+
+func (_c *mockEgoscaleClient_DeleteSshKeyWithResponse_Call) Return(_a0 *oapi.DeleteSshKeyResponse, _a1 error) *mockEgoscaleClient_DeleteSshKeyWithResponse_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *mockEgoscaleClient_DeleteSshKeyWithResponse_Call) RunAndReturn(run func(context.Context, string, ...oapi.RequestEditorFn) (*oapi.DeleteSshKeyResponse, error)) *mockEgoscaleClient_DeleteSshKeyWithResponse_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GenerateSksClusterKubeconfigWithResponse provides a mock function with given fields: ctx, id, body, reqEditors
+func (_m *mockEgoscaleClient) GenerateSksClusterKubeconfigWithResponse(ctx context.Context, id string, body oapi.GenerateSksClusterKubeconfigJSONRequestBody, reqEditors ...oapi.RequestEditorFn) (*oapi.GenerateSksClusterKubeconfigResponse, error) {
+	_va := make([]interface{}, len(reqEditors))
+	for _i := range reqEditors {
+		_va[_i] = reqEditors[_i]
+	}
+	var _ca []interface{}
+	_ca = append(_ca, ctx, id, body)
+	_ca = append(_ca, _va...)
+	ret := _m.Called(_ca...)
+
+	var r0 *oapi.GenerateSksClusterKubeconfigResponse
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, oapi.GenerateSksClusterKubeconfigJSONRequestBody, ...oapi.RequestEditorFn) (*oapi.GenerateSksClusterKubeconfigResponse, error)); ok {
+		return rf(ctx, id, body, reqEditors...)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, oapi.GenerateSksClusterKubeconfigJSONRequestBody, ...oapi.RequestEditorFn) *oapi.GenerateSksClusterKubeconfigResponse); ok {
+		r0 = rf(ctx, id, body, reqEditors...)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*oapi.GenerateSksClusterKubeconfigResponse)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, oapi.GenerateSksClusterKubeconfigJSONRequestBody, ...oapi.RequestEditorFn) error); ok {
+		r1 = rf(ctx, id, body, reqEditors...)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// mockEgoscaleClient_GenerateSksClusterKubeconfigWithResponse_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GenerateSksClusterKubeconfigWithResponse'
+type mockEgoscaleClient_GenerateSksClusterKubeconfigWithResponse_Call struct {
+	*mock.Call
+}
+
+// GenerateSksClusterKubeconfigWithResponse is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id string
+//   - body oapi.GenerateSksClusterKubeconfigJSONRequestBody
+//   - reqEditors ...oapi.RequestEditorFn
+func (_e *mockEgoscaleClient_Expecter) GenerateSksClusterKubeconfigWithResponse(ctx interface{}, id interface{}, body interface{}, reqEditors ...interface{}) *mockEgoscaleClient_GenerateSksClusterKubeconfigWithResponse_Call {
+	return &mockEgoscaleClient_GenerateSksClusterKubeconfigWithResponse_Call{Call: _e.mock.On("GenerateSksClusterKubeconfigWithResponse",
+		append([]interface{}{ctx, id, body}, reqEditors...)...)}
+}
+
+func (_c *mockEgoscaleClient_GenerateSksClusterKubeconfigWithResponse_Call) Run(run func(ctx context.Context, id string, body oapi.GenerateSksClusterKubeconfigJSONRequestBody, reqEditors ...oapi.RequestEditorFn)) *mockEgoscaleClient_GenerateSksClusterKubeconfigWithResponse_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		variadicArgs := make([]oapi.RequestEditorFn, len(args)-3)
+		for i, a := range args[3:] {
+			if a != nil {
+				variadicArgs[i] = a.(oapi.RequestEditorFn)
+			}
+		}
+		run(args[0].(context.Context), args[1].(string), args[2].(oapi.GenerateSksClusterKubeconfigJSONRequestBody), variadicArgs...)
+	})
+	return _c
+}
+
+func (_c *mockEgoscaleClient_GenerateSksClusterKubeconfigWithResponse_Call) Return(_a0 *oapi.GenerateSksClusterKubeconfigResponse, _a1 error) *mockEgoscaleClient_GenerateSksClusterKubeconfigWithResponse_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *mockEgoscaleClient_GenerateSksClusterKubeconfigWithResponse_Call) RunAndReturn(run func(context.Context, string, oapi.GenerateSksClusterKubeconfigJSONRequestBody, ...oapi.RequestEditorFn) (*oapi.GenerateSksClusterKubeconfigResponse, error)) *mockEgoscaleClient_GenerateSksClusterKubeconfigWithResponse_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetApiKeyWithResponse provides a mock function with given fields: ctx, id, reqEditors
+func (_m *mockEgoscaleClient) GetApiKeyWithResponse(ctx context.Context, id string, reqEditors ...oapi.RequestEditorFn) (*oapi.GetApiKeyResponse, error) {
+	_va := make([]interface{}, len(reqEditors))
+	for _i := range reqEditors {
+		_va[_i] = reqEditors[_i]
+	}
+	var _ca []interface{}
+	_ca = append(_ca, ctx, id)
+	_ca = append(_ca, _va...)
+	ret := _m.Called(_ca...)
+
+	var r0 *oapi.GetApiKeyResponse
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, ...oapi.RequestEditorFn) (*oapi.GetApiKeyResponse, error)); ok {
+		return rf(ctx, id, reqEditors...)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, ...oapi.RequestEditorFn) *oapi.GetApiKeyResponse); ok {
+		r0 = rf(ctx, id, reqEditors...)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*oapi.GetApiKeyResponse)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, ...oapi.RequestEditorFn) error); ok {
+		r1 = rf(ctx, id, reqEditors...)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// mockEgoscaleClient_GetApiKeyWithResponse_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetApiKeyWithResponse'
+type mockEgoscaleClient_GetApiKeyWithResponse_Call struct {
+	*mock.Call
+}
+
+// GetApiKeyWithResponse is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id string
+//   - reqEditors ...oapi.RequestEditorFn
+func (_e *mockEgoscaleClient_Expecter) GetApiKeyWithResponse(ctx interface{}, id interface{}, reqEditors ...interface{}) *mockEgoscaleClient_GetApiKeyWithResponse_Call {
+	return &mockEgoscaleClient_GetApiKeyWithResponse_Call{Call: _e.mock.On("GetApiKeyWithResponse",
+		append([]interface{}{ctx, id}, reqEditors...)...)}
+}
+
+func (_c *mockEgoscaleClient_GetApiKeyWithResponse_Call) Run(run func(ctx context.Context, id string, reqEditors ...oapi.RequestEditorFn)) *mockEgoscaleClient_GetApiKeyWithResponse_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		variadicArgs := make([]oapi.RequestEditorFn, len(args)-2)
+		for i, a := range args[2:] {
+			if a != nil {
+				variadicArgs[i] = a.(oapi.RequestEditorFn)
+			}
+		}
+		run(args[0].(context.Context), args[1].(string), variadicArgs...)
+	})
+	return _c
+}
+
+func (_c *mockEgoscaleClient_GetApiKeyWithResponse_Call) Return(_a0 *oapi.GetApiKeyResponse, _a1 error) *mockEgoscaleClient_GetApiKeyWithResponse_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *mockEgoscaleClient_GetApiKeyWithResponse_Call) RunAndReturn(run func(context.Context, string, ...oapi.RequestEditorFn) (*oapi.GetApiKeyResponse, error)) *mockEgoscaleClient_GetApiKeyWithResponse_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetDbaasKafkaAclConfigWithResponse provides a mock function with given fields: ctx, name, reqEditors
+func (_m *mockEgoscaleClient) GetDbaasKafkaAclConfigWithResponse(ctx context.Context, name oapi.DbaasServiceName, reqEditors ...oapi.RequestEditorFn) (*oapi.GetDbaasKafkaAclConfigResponse, error) {
+	_va := make([]interface{}, len(reqEditors))
+	for _i := range reqEditors {
+		_va[_i] = reqEditors[_i]
+	}
+	var _ca []interface{}
+	_ca = append(_ca, ctx, name)
+	_ca = append(_ca, _va...)
+	ret := _m.Called(_ca...)
+
+	var r0 *oapi.GetDbaasKafkaAclConfigResponse
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, oapi.DbaasServiceName, ...oapi.RequestEditorFn) (*oapi.GetDbaasKafkaAclConfigResponse, error)); ok {
+		return rf(ctx, name, reqEditors...)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, oapi.DbaasServiceName, ...oapi.RequestEditorFn) *oapi.GetDbaasKafkaAclConfigResponse); ok {
+		r0 = rf(ctx, name, reqEditors...)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*oapi.GetDbaasKafkaAclConfigResponse)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, oapi.DbaasServiceName, ...oapi.RequestEditorFn) error); ok {
+		r1 = rf(ctx, name, reqEditors...)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// mockEgoscaleClient_GetDbaasKafkaAclConfigWithResponse_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetDbaasKafkaAclConfigWithResponse'
+type mockEgoscaleClient_GetDbaasKafkaAclConfigWithResponse_Call struct {
+	*mock.Call
+}
+
+// GetDbaasKafkaAclConfigWithResponse is a helper method to define mock.On call
+//   - ctx context.Context
+//   - name oapi.DbaasServiceName
+//   - reqEditors ...oapi.RequestEditorFn
+func (_e *mockEgoscaleClient_Expecter) GetDbaasKafkaAclConfigWithResponse(ctx interface{}, name interface{}, reqEditors ...interface{}) *mockEgoscaleClient_GetDbaasKafkaAclConfigWithResponse_Call {
+	return &mockEgoscaleClient_GetDbaasKafkaAclConfigWithResponse_Call{Call: _e.mock.On("GetDbaasKafkaAclConfigWithResponse",
+		append([]interface{}{ctx, name}, reqEditors...)...)}
+}
+
+func (_c *mockEgoscaleClient_GetDbaasKafkaAclConfigWithResponse_Call) Run(run func(ctx context.Context, name oapi.DbaasServiceName, reqEditors ...oapi.RequestEditorFn)) *mockEgoscaleClient_GetDbaasKafkaAclConfigWithResponse_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		variadicArgs := make([]oapi.RequestEditorFn, len(args)-2)
+		for i, a := range args[2:] {
+			if a != nil {
+				variadicArgs[i] = a.(oapi.RequestEditorFn)
+			}
+		}
+		run(args[0].(context.Context), args[1].(oapi.DbaasServiceName), variadicArgs...)
+	})
+	return _c
+}
+
+func (_c *mockEgoscaleClient_GetDbaasKafkaAclConfigWithResponse_Call) Return(_a0 *oapi.GetDbaasKafkaAclConfigResponse, _a1 error) *mockEgoscaleClient_GetDbaasKafkaAclConfigWithResponse_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *mockEgoscaleClient_GetDbaasKafkaAclConfigWithResponse_Call) RunAndReturn(run func(context.Context, oapi.DbaasServiceName, ...oapi.RequestEditorFn) (*oapi.GetDbaasKafkaAclConfigResponse, error)) *mockEgoscaleClient_GetDbaasKafkaAclConfigWithResponse_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetDbaasServiceKafkaWithResponse provides a mock function with given fields: ctx, name, reqEditors
+func (_m *mockEgoscaleClient) GetDbaasServiceKafkaWithResponse(ctx context.Context, name oapi.DbaasServiceName, reqEditors ...oapi.RequestEditorFn) (*oapi.GetDbaasServiceKafkaResponse, error) {
+	_va := make([]interface{}, len(reqEditors))
+	for _i := range reqEditors {
+		_va[_i] = reqEditors[_i]
+	}
+	var _ca []interface{}
+	_ca = append(_ca, ctx, name)
+	_ca = append(_ca, _va...)
+	ret := _m.Called(_ca...)
+
+	var r0 *oapi.GetDbaasServiceKafkaResponse
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, oapi.DbaasServiceName, ...oapi.RequestEditorFn) (*oapi.GetDbaasServiceKafkaResponse, error)); ok {
+		return rf(ctx, name, reqEditors...)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, oapi.DbaasServiceName, ...oapi.RequestEditorFn) *oapi.GetDbaasServiceKafkaResponse); ok {
+		r0 = rf(ctx, name, reqEditors...)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*oapi.GetDbaasServiceKafkaResponse)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, oapi.DbaasServiceName, ...oapi.RequestEditorFn) error); ok {
+		r1 = rf(ctx, name, reqEditors...)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// mockEgoscaleClient_GetDbaasServiceKafkaWithResponse_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetDbaasServiceKafkaWithResponse'
+type mockEgoscaleClient_GetDbaasServiceKafkaWithResponse_Call struct {
+	*mock.Call
+}
+
+// GetDbaasServiceKafkaWithResponse is a helper method to define mock.On call
+//   - ctx context.Context
+//   - name oapi.DbaasServiceName
+//   - reqEditors ...oapi.RequestEditorFn
+func (_e *mockEgoscaleClient_Expecter) GetDbaasServiceKafkaWithResponse(ctx interface{}, name interface{}, reqEditors ...interface{}) *mockEgoscaleClient_GetDbaasServiceKafkaWithResponse_Call {
+	return &mockEgoscaleClient_GetDbaasServiceKafkaWithResponse_Call{Call: _e.mock.On("GetDbaasServiceKafkaWithResponse",
+		append([]interface{}{ctx, name}, reqEditors...)...)}
+}
+
+func (_c *mockEgoscaleClient_GetDbaasServiceKafkaWithResponse_Call) Run(run func(ctx context.Context, name oapi.DbaasServiceName, reqEditors ...oapi.RequestEditorFn)) *mockEgoscaleClient_GetDbaasServiceKafkaWithResponse_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		variadicArgs := make([]oapi.RequestEditorFn, len(args)-2)
+		for i, a := range args[2:] {
+			if a != nil {
+				variadicArgs[i] = a.(oapi.RequestEditorFn)
+			}
+		}
+		run(args[0].(context.Context), args[1].(oapi.DbaasServiceName), variadicArgs...)
+	})
+	return _c
+}
+
+func (_c *mockEgoscaleClient_GetDbaasServiceKafkaWithResponse_Call) Return(_a0 *oapi.GetDbaasServiceKafkaResponse, _a1 error) *mockEgoscaleClient_GetDbaasServiceKafkaWithResponse_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *mockEgoscaleClient_GetDbaasServiceKafkaWithResponse_Call) RunAndReturn(run func(context.Context, oapi.DbaasServiceName, ...oapi.RequestEditorFn) (*oapi.GetDbaasServiceKafkaResponse, error)) *mockEgoscaleClient_GetDbaasServiceKafkaWithResponse_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetDbaasServiceMysqlWithResponse provides a mock function with given fields: ctx, name, reqEditors
+func (_m *mockEgoscaleClient) GetDbaasServiceMysqlWithResponse(ctx context.Context, name oapi.DbaasServiceName, reqEditors ...oapi.RequestEditorFn) (*oapi.GetDbaasServiceMysqlResponse, error) {
+	_va := make([]interface{}, len(reqEditors))
+	for _i := range reqEditors {
+		_va[_i] = reqEditors[_i]
+	}
+	var _ca []interface{}
+	_ca = append(_ca, ctx, name)
+	_ca = append(_ca, _va...)
+	ret := _m.Called(_ca...)
+
+	var r0 *oapi.GetDbaasServiceMysqlResponse
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, oapi.DbaasServiceName, ...oapi.RequestEditorFn) (*oapi.GetDbaasServiceMysqlResponse, error)); ok {
+		return rf(ctx, name, reqEditors...)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, oapi.DbaasServiceName, ...oapi.RequestEditorFn) *oapi.GetDbaasServiceMysqlResponse); ok {
+		r0 = rf(ctx, name, reqEditors...)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*oapi.GetDbaasServiceMysqlResponse)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, oapi.DbaasServiceName, ...oapi.RequestEditorFn) error); ok {
+		r1 = rf(ctx, name, reqEditors...)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// mockEgoscaleClient_GetDbaasServiceMysqlWithResponse_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetDbaasServiceMysqlWithResponse'
+type mockEgoscaleClient_GetDbaasServiceMysqlWithResponse_Call struct {
+	*mock.Call
+}
+
+// GetDbaasServiceMysqlWithResponse is a helper method to define mock.On call
+//   - ctx context.Context
+//   - name oapi.DbaasServiceName
+//   - reqEditors ...oapi.RequestEditorFn
+func (_e *mockEgoscaleClient_Expecter) GetDbaasServiceMysqlWithResponse(ctx interface{}, name interface{}, reqEditors ...interface{}) *mockEgoscaleClient_GetDbaasServiceMysqlWithResponse_Call {
+	return &mockEgoscaleClient_GetDbaasServiceMysqlWithResponse_Call{Call: _e.mock.On("GetDbaasServiceMysqlWithResponse",
+		append([]interface{}{ctx, name}, reqEditors...)...)}
+}
+
+func (_c *mockEgoscaleClient_GetDbaasServiceMysqlWithResponse_Call) Run(run func(ctx context.Context, name oapi.DbaasServiceName, reqEditors ...oapi.RequestEditorFn)) *mockEgoscaleClient_GetDbaasServiceMysqlWithResponse_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		variadicArgs := make([]oapi.RequestEditorFn, len(args)-2)
+		for i, a := range args[2:] {
+			if a != nil {
+				variadicArgs[i] = a.(oapi.RequestEditorFn)
+			}
+		}
+		run(args[0].(context.Context), args[1].(oapi.DbaasServiceName), variadicArgs...)
+	})
+	return _c
+}
+
+func (_c *mockEgoscaleClient_GetDbaasServiceMysqlWithResponse_Call) Return(_a0 *oapi.GetDbaasServiceMysqlResponse, _a1 error) *mockEgoscaleClient_GetDbaasServiceMysqlWithResponse_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *mockEgoscaleClient_GetDbaasServiceMysqlWithResponse_Call) RunAndReturn(run func(context.Context, oapi.DbaasServiceName, ...oapi.RequestEditorFn) (*oapi.GetDbaasServiceMysqlResponse, error)) *mockEgoscaleClient_GetDbaasServiceMysqlWithResponse_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetDbaasServiceOpensearchWithResponse provides a mock function with given fields: ctx, name, reqEditors
+func (_m *mockEgoscaleClient) GetDbaasServiceOpensearchWithResponse(ctx context.Context, name oapi.DbaasServiceName, reqEditors ...oapi.RequestEditorFn) (*oapi.GetDbaasServiceOpensearchResponse, error) {
+	_va := make([]interface{}, len(reqEditors))
+	for _i := range reqEditors {
+		_va[_i] = reqEditors[_i]
+	}
+	var _ca []interface{}
+	_ca = append(_ca, ctx, name)
+	_ca = append(_ca, _va...)
+	ret := _m.Called(_ca...)
+
+	var r0 *oapi.GetDbaasServiceOpensearchResponse
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, oapi.DbaasServiceName, ...oapi.RequestEditorFn) (*oapi.GetDbaasServiceOpensearchResponse, error)); ok {
+		return rf(ctx, name, reqEditors...)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, oapi.DbaasServiceName, ...oapi.RequestEditorFn) *oapi.GetDbaasServiceOpensearchResponse); ok {
+		r0 = rf(ctx, name, reqEditors...)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*oapi.GetDbaasServiceOpensearchResponse)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, oapi.DbaasServiceName, ...oapi.RequestEditorFn) error); ok {
+		r1 = rf(ctx, name, reqEditors...)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// mockEgoscaleClient_GetDbaasServiceOpensearchWithResponse_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetDbaasServiceOpensearchWithResponse'
+type mockEgoscaleClient_GetDbaasServiceOpensearchWithResponse_Call struct {
+	*mock.Call
+}
+
+// GetDbaasServiceOpensearchWithResponse is a helper method to define mock.On call
+//   - ctx context.Context
+//   - name oapi.DbaasServiceName
+//   - reqEditors ...oapi.RequestEditorFn
+func (_e *mockEgoscaleClient_Expecter) GetDbaasServiceOpensearchWithResponse(ctx interface{}, name interface{}, reqEditors ...interface{}) *mockEgoscaleClient_GetDbaasServiceOpensearchWithResponse_Call {
+	return &mockEgoscaleClient_GetDbaasServiceOpensearchWithResponse_Call{Call: _e.mock.On("GetDbaasServiceOpensearchWithResponse",
+		append([]interface{}{ctx, name}, reqEditors...)...)}
+}
+
+func (_c *mockEgoscaleClient_GetDbaasServiceOpensearchWithResponse_Call) Run(run func(ctx context.Context, name oapi.DbaasServiceName, reqEditors ...oapi.RequestEditorFn)) *mockEgoscaleClient_GetDbaasServiceOpensearchWithResponse_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		variadicArgs := make([]oapi.RequestEditorFn, len(args)-2)
+		for i, a := range args[2:] {
+			if a != nil {
+				variadicArgs[i] = a.(oapi.RequestEditorFn)
+			}
+		}
+		run(args[0].(context.Context), args[1].(oapi.DbaasServiceName), variadicArgs...)
+	})
+	return _c
+}
+
+func (_c *mockEgoscaleClient_GetDbaasServiceOpensearchWithResponse_Call) Return(_a0 *oapi.GetDbaasServiceOpensearchResponse, _a1 error) *mockEgoscaleClient_GetDbaasServiceOpensearchWithResponse_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *mockEgoscaleClient_GetDbaasServiceOpensearchWithResponse_Call) RunAndReturn(run func(context.Context, oapi.DbaasServiceName, ...oapi.RequestEditorFn) (*oapi.GetDbaasServiceOpensearchResponse, error)) *mockEgoscaleClient_GetDbaasServiceOpensearchWithResponse_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetDbaasServicePgWithResponse provides a mock function with given fields: ctx, name, reqEditors
+func (_m *mockEgoscaleClient) GetDbaasServicePgWithResponse(ctx context.Context, name oapi.DbaasServiceName, reqEditors ...oapi.RequestEditorFn) (*oapi.GetDbaasServicePgResponse, error) {
+	_va := make([]interface{}, len(reqEditors))
+	for _i := range reqEditors {
+		_va[_i] = reqEditors[_i]
+	}
+	var _ca []interface{}
+	_ca = append(_ca, ctx, name)
+	_ca = append(_ca, _va...)
+	ret := _m.Called(_ca...)
+
+	var r0 *oapi.GetDbaasServicePgResponse
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, oapi.DbaasServiceName, ...oapi.RequestEditorFn) (*oapi.GetDbaasServicePgResponse, error)); ok {
+		return rf(ctx, name, reqEditors...)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, oapi.DbaasServiceName, ...oapi.RequestEditorFn) *oapi.GetDbaasServicePgResponse); ok {
+		r0 = rf(ctx, name, reqEditors...)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*oapi.GetDbaasServicePgResponse)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, oapi.DbaasServiceName, ...oapi.RequestEditorFn) error); ok {
+		r1 = rf(ctx, name, reqEditors...)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// mockEgoscaleClient_GetDbaasServicePgWithResponse_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetDbaasServicePgWithResponse'
+type mockEgoscaleClient_GetDbaasServicePgWithResponse_Call struct {
+	*mock.Call
+}
+
+// GetDbaasServicePgWithResponse is a helper method to define mock.On call
+//   - ctx context.Context
+//   - name oapi.DbaasServiceName
+//   - reqEditors ...oapi.RequestEditorFn
+func (_e *mockEgoscaleClient_Expecter) GetDbaasServicePgWithResponse(ctx interface{}, name interface{}, reqEditors ...interface{}) *mockEgoscaleClient_GetDbaasServicePgWithResponse_Call {
+	return &mockEgoscaleClient_GetDbaasServicePgWithResponse_Call{Call: _e.mock.On("GetDbaasServicePgWithResponse",
+		append([]interface{}{ctx, name}, reqEditors...)...)}
+}
+
+func (_c *mockEgoscaleClient_GetDbaasServicePgWithResponse_Call) Run(run func(ctx context.Context, name oapi.DbaasServiceName, reqEditors ...oapi.RequestEditorFn)) *mockEgoscaleClient_GetDbaasServicePgWithResponse_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		variadicArgs := make([]oapi.RequestEditorFn, len(args)-2)
+		for i, a := range args[2:] {
+			if a != nil {
+				variadicArgs[i] = a.(oapi.RequestEditorFn)
+			}
+		}
+		run(args[0].(context.Context), args[1].(oapi.DbaasServiceName), variadicArgs...)
+	})
+	return _c
+}
+
+func (_c *mockEgoscaleClient_GetDbaasServicePgWithResponse_Call) Return(_a0 *oapi.GetDbaasServicePgResponse, _a1 error) *mockEgoscaleClient_GetDbaasServicePgWithResponse_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *mockEgoscaleClient_GetDbaasServicePgWithResponse_Call) RunAndReturn(run func(context.Context, oapi.DbaasServiceName, ...oapi.RequestEditorFn) (*oapi.GetDbaasServicePgResponse, error)) *mockEgoscaleClient_GetDbaasServicePgWithResponse_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetIamRoleWithResponse provides a mock function with given fields: ctx, id, reqEditors
+func (_m *mockEgoscaleClient) GetIamRoleWithResponse(ctx context.Context, id string, reqEditors ...oapi.RequestEditorFn) (*oapi.GetIamRoleResponse, error) {
+	_va := make([]interface{}, len(reqEditors))
+	for _i := range reqEditors {
+		_va[_i] = reqEditors[_i]
+	}
+	var _ca []interface{}
+	_ca = append(_ca, ctx, id)
+	_ca = append(_ca, _va...)
+	ret := _m.Called(_ca...)
+
+	var r0 *oapi.GetIamRoleResponse
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, ...oapi.RequestEditorFn) (*oapi.GetIamRoleResponse, error)); ok {
+		return rf(ctx, id, reqEditors...)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, ...oapi.RequestEditorFn) *oapi.GetIamRoleResponse); ok {
+		r0 = rf(ctx, id, reqEditors...)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*oapi.GetIamRoleResponse)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, ...oapi.RequestEditorFn) error); ok {
+		r1 = rf(ctx, id, reqEditors...)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// mockEgoscaleClient_GetIamRoleWithResponse_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetIamRoleWithResponse'
+type mockEgoscaleClient_GetIamRoleWithResponse_Call struct {
+	*mock.Call
+}
+
+// GetIamRoleWithResponse is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id string
+//   - reqEditors ...oapi.RequestEditorFn
+func (_e *mockEgoscaleClient_Expecter) GetIamRoleWithResponse(ctx interface{}, id interface{}, reqEditors ...interface{}) *mockEgoscaleClient_GetIamRoleWithResponse_Call {
+	return &mockEgoscaleClient_GetIamRoleWithResponse_Call{Call: _e.mock.On("GetIamRoleWithResponse",
+		append([]interface{}{ctx, id}, reqEditors...)...)}
+}
+
+func (_c *mockEgoscaleClient_GetIamRoleWithResponse_Call) Run(run func(ctx context.Context, id string, reqEditors ...oapi.RequestEditorFn)) *mockEgoscaleClient_GetIamRoleWithResponse_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		variadicArgs := make([]oapi.RequestEditorFn, len(args)-2)
+		for i, a := range args[2:] {
+			if a != nil {
+				variadicArgs[i] = a.(oapi.RequestEditorFn)
+			}
+		}
+		run(args[0].(context.Context), args[1].(string), variadicArgs...)
+	})
+	return _c
+}
+
+func (_c *mockEgoscaleClient_GetIamRoleWithResponse_Call) Return(_a0 *oapi.GetIamRoleResponse, _a1 error) *mockEgoscaleClient_GetIamRoleWithResponse_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *mockEgoscaleClient_GetIamRoleWithResponse_Call) RunAndReturn(run func(context.Context, string, ...oapi.RequestEditorFn) (*oapi.GetIamRoleResponse, error)) *mockEgoscaleClient_GetIamRoleWithResponse_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetInstanceWithResponse provides a mock function with given fields: ctx, id, reqEditors
+func (_m *mockEgoscaleClient) GetInstanceWithResponse(ctx context.Context, id string, reqEditors ...oapi.RequestEditorFn) (*oapi.GetInstanceResponse, error) {
+	_va := make([]interface{}, len(reqEditors))
+	for _i := range reqEditors {
+		_va[_i] = reqEditors[_i]
+	}
+	var _ca []interface{}
+	_ca = append(_ca, ctx, id)
+	_ca = append(_ca, _va...)
+	ret := _m.Called(_ca...)
+
+	var r0 *oapi.GetInstanceResponse
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, ...oapi.RequestEditorFn) (*oapi.GetInstanceResponse, error)); ok {
+		return rf(ctx, id, reqEditors...)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, ...oapi.RequestEditorFn) *oapi.GetInstanceResponse); ok {
+		r0 = rf(ctx, id, reqEditors...)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*oapi.GetInstanceResponse)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, ...oapi.RequestEditorFn) error); ok {
+		r1 = rf(ctx, id, reqEditors...)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// mockEgoscaleClient_GetInstanceWithResponse_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetInstanceWithResponse'
+type mockEgoscaleClient_GetInstanceWithResponse_Call struct {
+	*mock.Call
+}
+
+// GetInstanceWithResponse is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id string
+//   - reqEditors ...oapi.RequestEditorFn
+func (_e *mockEgoscaleClient_Expecter) GetInstanceWithResponse(ctx interface{}, id interface{}, reqEditors ...interface{}) *mockEgoscaleClient_GetInstanceWithResponse_Call {
+	return &mockEgoscaleClient_GetInstanceWithResponse_Call{Call: _e.mock.On("GetInstanceWithResponse",
+		append([]interface{}{ctx, id}, reqEditors...)...)}
+}
+
+func (_c *mockEgoscaleClient_GetInstanceWithResponse_Call) Run(run func(ctx context.Context, id string, reqEditors ...oapi.RequestEditorFn)) *mockEgoscaleClient_GetInstanceWithResponse_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		variadicArgs := make([]oapi.RequestEditorFn, len(args)-2)
+		for i, a := range args[2:] {
+			if a != nil {
+				variadicArgs[i] = a.(oapi.RequestEditorFn)
+			}
+		}
+		run(args[0].(context.Context), args[1].(string), variadicArgs...)
+	})
+	return _c
+}
+
+func (_c *mockEgoscaleClient_GetInstanceWithResponse_Call) Return(_a0 *oapi.GetInstanceResponse, _a1 error) *mockEgoscaleClient_GetInstanceWithResponse_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *mockEgoscaleClient_GetInstanceWithResponse_Call) RunAndReturn(run func(context.Context, string, ...oapi.RequestEditorFn) (*oapi.GetInstanceResponse, error)) *mockEgoscaleClient_GetInstanceWithResponse_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetOperationWithResponse provides a mock function with given fields: ctx, id, reqEditors
+func (_m *mockEgoscaleClient) GetOperationWithResponse(ctx context.Context, id string, reqEditors ...oapi.RequestEditorFn) (*oapi.GetOperationResponse, error) {
+	_va := make([]interface{}, len(reqEditors))
+	for _i := range reqEditors {
+		_va[_i] = reqEditors[_i]
+	}
+	var _ca []interface{}
+	_ca = append(_ca, ctx, id)
+	_ca = append(_ca, _va...)
+	ret := _m.Called(_ca...)
+
+	var r0 *oapi.GetOperationResponse
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, ...oapi.RequestEditorFn) (*oapi.GetOperationResponse, error)); ok {
+		return rf(ctx, id, reqEditors...)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, ...oapi.RequestEditorFn) *oapi.GetOperationResponse); ok {
+		r0 = rf(ctx, id, reqEditors...)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*oapi.GetOperationResponse)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, ...oapi.RequestEditorFn) error); ok {
+		r1 = rf(ctx, id, reqEditors...)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// mockEgoscaleClient_GetOperationWithResponse_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetOperationWithResponse'
+type mockEgoscaleClient_GetOperationWithResponse_Call struct {
+	*mock.Call
+}
+
+// GetOperationWithResponse is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id string
+//   - reqEditors ...oapi.RequestEditorFn
+func (_e *mockEgoscaleClient_Expecter) GetOperationWithResponse(ctx interface{}, id interface{}, reqEditors ...interface{}) *mockEgoscaleClient_GetOperationWithResponse_Call {
+	return &mockEgoscaleClient_GetOperationWithResponse_Call{Call: _e.mock.On("GetOperationWithResponse",
+		append([]interface{}{ctx, id}, reqEditors...)...)}
+}
+
+func (_c *mockEgoscaleClient_GetOperationWithResponse_Call) Run(run func(ctx context.Context, id string, reqEditors ...oapi.RequestEditorFn)) *mockEgoscaleClient_GetOperationWithResponse_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		variadicArgs := make([]oapi.RequestEditorFn, len(args)-2)
+		for i, a := range args[2:] {
+			if a != nil {
+				variadicArgs[i] = a.(oapi.RequestEditorFn)
+			}
+		}
+		run(args[0].(context.Context), args[1].(string), variadicArgs...)
+	})
+	return _c
+}
+
+func (_c *mockEgoscaleClient_GetOperationWithResponse_Call) Return(_a0 *oapi.GetOperationResponse, _a1 error) *mockEgoscaleClient_GetOperationWithResponse_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *mockEgoscaleClient_GetOperationWithResponse_Call) RunAndReturn(run func(context.Context, string, ...oapi.RequestEditorFn) (*oapi.GetOperationResponse, error)) *mockEgoscaleClient_GetOperationWithResponse_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetQuotaWithResponse provides a mock function with given fields: ctx, entity, reqEditors
+func (_m *mockEgoscaleClient) GetQuotaWithResponse(ctx context.Context, entity string, reqEditors ...oapi.RequestEditorFn) (*oapi.GetQuotaResponse, error) {
+	_va := make([]interface{}, len(reqEditors))
+	for _i := range reqEditors {
+		_va[_i] = reqEditors[_i]
+	}
+	var _ca []interface{}
+	_ca = append(_ca, ctx, entity)
+	_ca = append(_ca, _va...)
+	ret := _m.Called(_ca...)
+
+	var r0 *oapi.GetQuotaResponse
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, ...oapi.RequestEditorFn) (*oapi.GetQuotaResponse, error)); ok {
+		return rf(ctx, entity, reqEditors...)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, ...oapi.RequestEditorFn) *oapi.GetQuotaResponse); ok {
+		r0 = rf(ctx, entity, reqEditors...)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*oapi.GetQuotaResponse)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, ...oapi.RequestEditorFn) error); ok {
+		r1 = rf(ctx, entity, reqEditors...)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// mockEgoscaleClient_GetQuotaWithResponse_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetQuotaWithResponse'
+type mockEgoscaleClient_GetQuotaWithResponse_Call struct {
+	*mock.Call
+}
+
+// GetQuotaWithResponse is a helper method to define mock.On call
+//   - ctx context.Context
+//   - entity string
+//   - reqEditors ...oapi.RequestEditorFn
+func (_e *mockEgoscaleClient_Expecter) GetQuotaWithResponse(ctx interface{}, entity interface{}, reqEditors ...interface{}) *mockEgoscaleClient_GetQuotaWithResponse_Call {
+	return &mockEgoscaleClient_GetQuotaWithResponse_Call{Call: _e.mock.On("GetQuotaWithResponse",
+		append([]interface{}{ctx, entity}, reqEditors...)...)}
+}
+
+func (_c *mockEgoscaleClient_GetQuotaWithResponse_Call) Run(run func(ctx context.Context, entity string, reqEditors ...oapi.RequestEditorFn)) *mockEgoscaleClient_GetQuotaWithResponse_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		variadicArgs := make([]oapi.RequestEditorFn, len(args)-2)
+		for i, a := range args[2:] {
+			if a != nil {
+				variadicArgs[i] = a.(oapi.RequestEditorFn)
+			}
+		}
+		run(args[0].(context.Context), args[1].(string), variadicArgs...)
+	})
+	return _c
+}
+
+func (_c *mockEgoscaleClient_GetQuotaWithResponse_Call) Return(_a0 *oapi.GetQuotaResponse, _a1 error) *mockEgoscaleClient_GetQuotaWithResponse_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *mockEgoscaleClient_GetQuotaWithResponse_Call) RunAndReturn(run func(context.Context, string, ...oapi.RequestEditorFn) (*oapi.GetQuotaResponse, error)) *mockEgoscaleClient_GetQuotaWithResponse_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetSecurityGroupWithResponse provides a mock function with given fields: ctx, id, reqEditors
+func (_m *mockEgoscaleClient) GetSecurityGroupWithResponse(ctx context.Context, id string, reqEditors ...oapi.RequestEditorFn) (*oapi.GetSecurityGroupResponse, error) {
+	_va := make([]interface{}, len(reqEditors))
+	for _i := range reqEditors {
+		_va[_i] = reqEditors[_i]
+	}
+	var _ca []interface{}
+	_ca = append(_ca, ctx, id)
+	_ca = append(_ca, _va...)
+	ret := _m.Called(_ca...)
+
+	var r0 *oapi.GetSecurityGroupResponse
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, ...oapi.RequestEditorFn) (*oapi.GetSecurityGroupResponse, error)); ok {
+		return rf(ctx, id, reqEditors...)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, ...oapi.RequestEditorFn) *oapi.GetSecurityGroupResponse); ok {
+		r0 = rf(ctx, id, reqEditors...)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*oapi.GetSecurityGroupResponse)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, ...oapi.RequestEditorFn) error); ok {
+		r1 = rf(ctx, id, reqEditors...)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// mockEgoscaleClient_GetSecurityGroupWithResponse_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetSecurityGroupWithResponse'
+type mockEgoscaleClient_GetSecurityGroupWithResponse_Call struct {
+	*mock.Call
+}
+
+// GetSecurityGroupWithResponse is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id string
+//   - reqEditors ...oapi.RequestEditorFn
+func (_e *mockEgoscaleClient_Expecter) GetSecurityGroupWithResponse(ctx interface{}, id interface{}, reqEditors ...interface{}) *mockEgoscaleClient_GetSecurityGroupWithResponse_Call {
+	return &mockEgoscaleClient_GetSecurityGroupWithResponse_Call{Call: _e.mock.On("GetSecurityGroupWithResponse",
+		append([]interface{}{ctx, id}, reqEditors...)...)}
+}
+
+func (_c *mockEgoscaleClient_GetSecurityGroupWithResponse_Call) Run(run func(ctx context.Context, id string, reqEditors ...oapi.RequestEditorFn)) *mockEgoscaleClient_GetSecurityGroupWithResponse_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		variadicArgs := make([]oapi.RequestEditorFn, len(args)-2)
+		for i, a := range args[2:] {
+			if a != nil {
+				variadicArgs[i] = a.(oapi.RequestEditorFn)
+			}
+		}
+		run(args[0].(context.Context), args[1].(string), variadicArgs...)
+	})
+	return _c
+}
+
+func (_c *mockEgoscaleClient_GetSecurityGroupWithResponse_Call) Return(_a0 *oapi.GetSecurityGroupResponse, _a1 error) *mockEgoscaleClient_GetSecurityGroupWithResponse_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *mockEgoscaleClient_GetSecurityGroupWithResponse_Call) RunAndReturn(run func(context.Context, string, ...oapi.RequestEditorFn) (*oapi.GetSecurityGroupResponse, error)) *mockEgoscaleClient_GetSecurityGroupWithResponse_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetSosPresignedUrlWithResponse provides a mock function with given fields: ctx, bucket, params, reqEditors
+func (_m *mockEgoscaleClient) GetSosPresignedUrlWithResponse(ctx context.Context, bucket string, params *oapi.GetSosPresignedUrlParams, reqEditors ...oapi.RequestEditorFn) (*oapi.GetSosPresignedUrlResponse, error) {
+	_va := make([]interface{}, len(reqEditors))
+	for _i := range reqEditors {
+		_va[_i] = reqEditors[_i]
+	}
+	var _ca []interface{}
+	_ca = append(_ca, ctx, bucket, params)
+	_ca = append(_ca, _va...)
+	ret := _m.Called(_ca...)
+
+	var r0 *oapi.GetSosPresignedUrlResponse
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, *oapi.GetSosPresignedUrlParams, ...oapi.RequestEditorFn) (*oapi.GetSosPresignedUrlResponse, error)); ok {
+		return rf(ctx, bucket, params, reqEditors...)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, *oapi.GetSosPresignedUrlParams, ...oapi.RequestEditorFn) *oapi.GetSosPresignedUrlResponse); ok {
+		r0 = rf(ctx, bucket, params, reqEditors...)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*oapi.GetSosPresignedUrlResponse)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, *oapi.GetSosPresignedUrlParams, ...oapi.RequestEditorFn) error); ok {
+		r1 = rf(ctx, bucket, params, reqEditors...)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// mockEgoscaleClient_GetSosPresignedUrlWithResponse_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetSosPresignedUrlWithResponse'
+type mockEgoscaleClient_GetSosPresignedUrlWithResponse_Call struct {
+	*mock.Call
+}
+
+// GetSosPresignedUrlWithResponse is a helper method to define mock.On call
+//   - ctx context.Context
+//   - bucket string
+//   - params *oapi.GetSosPresignedUrlParams
+//   - reqEditors ...oapi.RequestEditorFn
+func (_e *mockEgoscaleClient_Expecter) GetSosPresignedUrlWithResponse(ctx interface{}, bucket interface{}, params interface{}, reqEditors ...interface{}) *mockEgoscaleClient_GetSosPresignedUrlWithResponse_Call {
+	return &mockEgoscaleClient_GetSosPresignedUrlWithResponse_Call{Call: _e.mock.On("GetSosPresignedUrlWithResponse",
+		append([]interface{}{ctx, bucket, params}, reqEditors...)...)}
+}
+
+func (_c *mockEgoscaleClient_GetSosPresignedUrlWithResponse_Call) Run(run func(ctx context.Context, bucket string, params *oapi.GetSosPresignedUrlParams, reqEditors ...oapi.RequestEditorFn)) *mockEgoscaleClient_GetSosPresignedUrlWithResponse_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		variadicArgs := make([]oapi.RequestEditorFn, len(args)-3)
+		for i, a := range args[3:] {
+			if a != nil {
+				variadicArgs[i] = a.(oapi.RequestEditorFn)
+			}
+		}
+		run(args[0].(context.Context), args[1].(string), args[2].(*oapi.GetSosPresignedUrlParams), variadicArgs...)
+	})
+	return _c
+}
+
+func (_c *mockEgoscaleClient_GetSosPresignedUrlWithResponse_Call) Return(_a0 *oapi.GetSosPresignedUrlResponse, _a1 error) *mockEgoscaleClient_GetSosPresignedUrlWithResponse_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *mockEgoscaleClient_GetSosPresignedUrlWithResponse_Call) RunAndReturn(run func(context.Context, string, *oapi.GetSosPresignedUrlParams, ...oapi.RequestEditorFn) (*oapi.GetSosPresignedUrlResponse, error)) *mockEgoscaleClient_GetSosPresignedUrlWithResponse_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ListAccessKeysWithResponse provides a mock function with given fields: ctx, reqEditors
+func (_m *mockEgoscaleClient) ListAccessKeysWithResponse(ctx context.Context, reqEditors ...oapi.RequestEditorFn) (*oapi.ListAccessKeysResponse, error) {
+	_va := make([]interface{}, len(reqEditors))
+	for _i := range reqEditors {
+		_va[_i] = reqEditors[_i]
+	}
+	var _ca []interface{}
+	_ca = append(_ca, ctx)
+	_ca = append(_ca, _va...)
+	ret := _m.Called(_ca...)
+
+	var r0 *oapi.ListAccessKeysResponse
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, ...oapi.RequestEditorFn) (*oapi.ListAccessKeysResponse, error)); ok {
+		return rf(ctx, reqEditors...)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, ...oapi.RequestEditorFn) *oapi.ListAccessKeysResponse); ok {
+		r0 = rf(ctx, reqEditors...)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*oapi.ListAccessKeysResponse)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, ...oapi.RequestEditorFn) error); ok {
+		r1 = rf(ctx, reqEditors...)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// mockEgoscaleClient_ListAccessKeysWithResponse_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListAccessKeysWithResponse'
+type mockEgoscaleClient_ListAccessKeysWithResponse_Call struct {
+	*mock.Call
+}
+
+// ListAccessKeysWithResponse is a helper method to define mock.On call
+//   - ctx context.Context
+//   - reqEditors ...oapi.RequestEditorFn
+func (_e *mockEgoscaleClient_Expecter) ListAccessKeysWithResponse(ctx interface{}, reqEditors ...interface{}) *mockEgoscaleClient_ListAccessKeysWithResponse_Call {
+	return &mockEgoscaleClient_ListAccessKeysWithResponse_Call{Call: _e.mock.On("ListAccessKeysWithResponse",
+		append([]interface{}{ctx}, reqEditors...)...)}
+}
+
+func (_c *mockEgoscaleClient_ListAccessKeysWithResponse_Call) Run(run func(ctx context.Context, reqEditors ...oapi.RequestEditorFn)) *mockEgoscaleClient_ListAccessKeysWithResponse_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		variadicArgs := make([]oapi.RequestEditorFn, len(args)-1)
+		for i, a := range args[1:] {
+			if a != nil {
+				variadicArgs[i] = a.(oapi.RequestEditorFn)
+			}
+		}
+		run(args[0].(context.Context), variadicArgs...)
+	})
+	return _c
+}
+
+func (_c *mockEgoscaleClient_ListAccessKeysWithResponse_Call) Return(_a0 *oapi.ListAccessKeysResponse, _a1 error) *mockEgoscaleClient_ListAccessKeysWithResponse_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *mockEgoscaleClient_ListAccessKeysWithResponse_Call) RunAndReturn(run func(context.Context, ...oapi.RequestEditorFn) (*oapi.ListAccessKeysResponse, error)) *mockEgoscaleClient_ListAccessKeysWithResponse_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ListApiKeysWithResponse provides a mock function with given fields: ctx, reqEditors
+func (_m *mockEgoscaleClient) ListApiKeysWithResponse(ctx context.Context, reqEditors ...oapi.RequestEditorFn) (*oapi.ListApiKeysResponse, error) {
+	_va := make([]interface{}, len(reqEditors))
+	for _i := range reqEditors {
+		_va[_i] = reqEditors[_i]
+	}
+	var _ca []interface{}
+	_ca = append(_ca, ctx)
+	_ca = append(_ca, _va...)
+	ret := _m.Called(_ca...)
+
+	var r0 *oapi.ListApiKeysResponse
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, ...oapi.RequestEditorFn) (*oapi.ListApiKeysResponse, error)); ok {
+		return rf(ctx, reqEditors...)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, ...oapi.RequestEditorFn) *oapi.ListApiKeysResponse); ok {
+		r0 = rf(ctx, reqEditors...)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*oapi.ListApiKeysResponse)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, ...oapi.RequestEditorFn) error); ok {
+		r1 = rf(ctx, reqEditors...)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// mockEgoscaleClient_ListApiKeysWithResponse_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListApiKeysWithResponse'
+type mockEgoscaleClient_ListApiKeysWithResponse_Call struct {
+	*mock.Call
+}
+
+// ListApiKeysWithResponse is a helper method to define mock.On call
+//   - ctx context.Context
+//   - reqEditors ...oapi.RequestEditorFn
+func (_e *mockEgoscaleClient_Expecter) ListApiKeysWithResponse(ctx interface{}, reqEditors ...interface{}) *mockEgoscaleClient_ListApiKeysWithResponse_Call {
+	return &mockEgoscaleClient_ListApiKeysWithResponse_Call{Call: _e.mock.On("ListApiKeysWithResponse",
+		append([]interface{}{ctx}, reqEditors...)...)}
+}
+
+func (_c *mockEgoscaleClient_ListApiKeysWithResponse_Call) Run(run func(ctx context.Context, reqEditors ...oapi.RequestEditorFn)) *mockEgoscaleClient_ListApiKeysWithResponse_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		variadicArgs := make([]oapi.RequestEditorFn, len(args)-1)
+		for i, a := range args[1:] {
+			if a != nil {
+				variadicArgs[i] = a.(oapi.RequestEditorFn)
+			}
+		}
+		run(args[0].(context.Context), variadicArgs...)
+	})
+	return _c
+}
+
+func (_c *mockEgoscaleClient_ListApiKeysWithResponse_Call) Return(_a0 *oapi.ListApiKeysResponse, _a1 error) *mockEgoscaleClient_ListApiKeysWithResponse_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *mockEgoscaleClient_ListApiKeysWithResponse_Call) RunAndReturn(run func(context.Context, ...oapi.RequestEditorFn) (*oapi.ListApiKeysResponse, error)) *mockEgoscaleClient_ListApiKeysWithResponse_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ListIamRolesWithResponse provides a mock function with given fields: ctx, reqEditors
+func (_m *mockEgoscaleClient) ListIamRolesWithResponse(ctx context.Context, reqEditors ...oapi.RequestEditorFn) (*oapi.ListIamRolesResponse, error) {
+	_va := make([]interface{}, len(reqEditors))
+	for _i := range reqEditors {
+		_va[_i] = reqEditors[_i]
+	}
+	var _ca []interface{}
+	_ca = append(_ca, ctx)
+	_ca = append(_ca, _va...)
+	ret := _m.Called(_ca...)
+
+	var r0 *oapi.ListIamRolesResponse
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, ...oapi.RequestEditorFn) (*oapi.ListIamRolesResponse, error)); ok {
+		return rf(ctx, reqEditors...)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, ...oapi.RequestEditorFn) *oapi.ListIamRolesResponse); ok {
+		r0 = rf(ctx, reqEditors...)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*oapi.ListIamRolesResponse)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, ...oapi.RequestEditorFn) error); ok {
+		r1 = rf(ctx, reqEditors...)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// mockEgoscaleClient_ListIamRolesWithResponse_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListIamRolesWithResponse'
+type mockEgoscaleClient_ListIamRolesWithResponse_Call struct {
+	*mock.Call
+}
+
+// ListIamRolesWithResponse is a helper method to define mock.On call
+//   - ctx context.Context
+//   - reqEditors ...oapi.RequestEditorFn
+func (_e *mockEgoscaleClient_Expecter) ListIamRolesWithResponse(ctx interface{}, reqEditors ...interface{}) *mockEgoscaleClient_ListIamRolesWithResponse_Call {
+	return &mockEgoscaleClient_ListIamRolesWithResponse_Call{Call: _e.mock.On("ListIamRolesWithResponse",
+		append([]interface{}{ctx}, reqEditors...)...)}
+}
+
+func (_c *mockEgoscaleClient_ListIamRolesWithResponse_Call) Run(run func(ctx context.Context, reqEditors ...oapi.RequestEditorFn)) *mockEgoscaleClient_ListIamRolesWithResponse_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		variadicArgs := make([]oapi.RequestEditorFn, len(args)-1)
+		for i, a := range args[1:] {
+			if a != nil {
+				variadicArgs[i] = a.(oapi.RequestEditorFn)
+			}
+		}
+		run(args[0].(context.Context), variadicArgs...)
+	})
+	return _c
+}
+
+func (_c *mockEgoscaleClient_ListIamRolesWithResponse_Call) Return(_a0 *oapi.ListIamRolesResponse, _a1 error) *mockEgoscaleClient_ListIamRolesWithResponse_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *mockEgoscaleClient_ListIamRolesWithResponse_Call) RunAndReturn(run func(context.Context, ...oapi.RequestEditorFn) (*oapi.ListIamRolesResponse, error)) *mockEgoscaleClient_ListIamRolesWithResponse_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ListQuotasWithResponse provides a mock function with given fields: ctx, reqEditors
+func (_m *mockEgoscaleClient) ListQuotasWithResponse(ctx context.Context, reqEditors ...oapi.RequestEditorFn) (*oapi.ListQuotasResponse, error) {
+	_va := make([]interface{}, len(reqEditors))
+	for _i := range reqEditors {
+		_va[_i] = reqEditors[_i]
+	}
+	var _ca []interface{}
+	_ca = append(_ca, ctx)
+	_ca = append(_ca, _va...)
+	ret := _m.Called(_ca...)
+
+	var r0 *oapi.ListQuotasResponse
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, ...oapi.RequestEditorFn) (*oapi.ListQuotasResponse, error)); ok {
+		return rf(ctx, reqEditors...)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, ...oapi.RequestEditorFn) *oapi.ListQuotasResponse); ok {
+		r0 = rf(ctx, reqEditors...)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*oapi.ListQuotasResponse)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, ...oapi.RequestEditorFn) error); ok {
+		r1 = rf(ctx, reqEditors...)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// mockEgoscaleClient_ListQuotasWithResponse_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListQuotasWithResponse'
+type mockEgoscaleClient_ListQuotasWithResponse_Call struct {
+	*mock.Call
+}
+
+// ListQuotasWithResponse is a helper method to define mock.On call
+//   - ctx context.Context
+//   - reqEditors ...oapi.RequestEditorFn
+func (_e *mockEgoscaleClient_Expecter) ListQuotasWithResponse(ctx interface{}, reqEditors ...interface{}) *mockEgoscaleClient_ListQuotasWithResponse_Call {
+	return &mockEgoscaleClient_ListQuotasWithResponse_Call{Call: _e.mock.On("ListQuotasWithResponse",
+		append([]interface{}{ctx}, reqEditors...)...)}
+}
+
+func (_c *mockEgoscaleClient_ListQuotasWithResponse_Call) Run(run func(ctx context.Context, reqEditors ...oapi.RequestEditorFn)) *mockEgoscaleClient_ListQuotasWithResponse_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		variadicArgs := make([]oapi.RequestEditorFn, len(args)-1)
+		for i, a := range args[1:] {
+			if a != nil {
+				variadicArgs[i] = a.(oapi.RequestEditorFn)
+			}
+		}
+		run(args[0].(context.Context), variadicArgs...)
+	})
+	return _c
+}
+
+func (_c *mockEgoscaleClient_ListQuotasWithResponse_Call) Return(_a0 *oapi.ListQuotasResponse, _a1 error) *mockEgoscaleClient_ListQuotasWithResponse_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *mockEgoscaleClient_ListQuotasWithResponse_Call) RunAndReturn(run func(context.Context, ...oapi.RequestEditorFn) (*oapi.ListQuotasResponse, error)) *mockEgoscaleClient_ListQuotasWithResponse_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// RegisterSshKeyWithResponse provides a mock function with given fields: ctx, body, reqEditors
+func (_m *mockEgoscaleClient) RegisterSshKeyWithResponse(ctx context.Context, body oapi.RegisterSshKeyJSONRequestBody, reqEditors ...oapi.RequestEditorFn) (*oapi.RegisterSshKeyResponse, error) {
+	_va := make([]interface{}, len(reqEditors))
+	for _i := range reqEditors {
+		_va[_i] = reqEditors[_i]
+	}
+	var _ca []interface{}
+	_ca = append(_ca, ctx, body)
+	_ca = append(_ca, _va...)
+	ret := _m.Called(_ca...)
+
+	var r0 *oapi.RegisterSshKeyResponse
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, oapi.RegisterSshKeyJSONRequestBody, ...oapi.RequestEditorFn) (*oapi.RegisterSshKeyResponse, error)); ok {
+		return rf(ctx, body, reqEditors...)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, oapi.RegisterSshKeyJSONRequestBody, ...oapi.RequestEditorFn) *oapi.RegisterSshKeyResponse); ok {
+		r0 = rf(ctx, body, reqEditors...)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*oapi.RegisterSshKeyResponse)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, oapi.RegisterSshKeyJSONRequestBody, ...oapi.RequestEditorFn) error); ok {
+		r1 = rf(ctx, body, reqEditors...)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// mockEgoscaleClient_RegisterSshKeyWithResponse_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'RegisterSshKeyWithResponse'
+type mockEgoscaleClient_RegisterSshKeyWithResponse_Call struct {
+	*mock.Call
+}
+
+// RegisterSshKeyWithResponse is a helper method to define mock.On call
+//   - ctx context.Context
+//   - body oapi.RegisterSshKeyJSONRequestBody
+//   - reqEditors ...oapi.RequestEditorFn
+func (_e *mockEgoscaleClient_Expecter) RegisterSshKeyWithResponse(ctx interface{}, body interface{}, reqEditors ...interface{}) *mockEgoscaleClient_RegisterSshKeyWithResponse_Call {
+	return &mockEgoscaleClient_RegisterSshKeyWithResponse_Call{Call: _e.mock.On("RegisterSshKeyWithResponse",
+		append([]interface{}{ctx, body}, reqEditors...)...)}
+}
+
+func (_c *mockEgoscaleClient_RegisterSshKeyWithResponse_Call) Run(run func(ctx context.Context, body oapi.RegisterSshKeyJSONRequestBody, reqEditors ...oapi.RequestEditorFn)) *mockEgoscaleClient_RegisterSshKeyWithResponse_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		variadicArgs := make([]oapi.RequestEditorFn, len(args)-2)
+		for i, a := range args[2:] {
+			if a != nil {
+				variadicArgs[i] = a.(oapi.RequestEditorFn)
+			}
+		}
+		run(args[0].(context.Context), args[1].(oapi.RegisterSshKeyJSONRequestBody), variadicArgs...)
+	})
+	return _c
+}
+
+func (_c *mockEgoscaleClient_RegisterSshKeyWithResponse_Call) Return(_a0 *oapi.RegisterSshKeyResponse, _a1 error) *mockEgoscaleClient_RegisterSshKeyWithResponse_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *mockEgoscaleClient_RegisterSshKeyWithResponse_Call) RunAndReturn(run func(context.Context, oapi.RegisterSshKeyJSONRequestBody, ...oapi.RequestEditorFn) (*oapi.RegisterSshKeyResponse, error)) *mockEgoscaleClient_RegisterSshKeyWithResponse_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ResetDbaasKafkaUserPasswordWithResponse provides a mock function with given fields: ctx, serviceName, username, body, reqEditors
+func (_m *mockEgoscaleClient) ResetDbaasKafkaUserPasswordWithResponse(ctx context.Context, serviceName oapi.DbaasServiceName, username oapi.DbaasUserUsername, body oapi.ResetDbaasKafkaUserPasswordJSONRequestBody, reqEditors ...oapi.RequestEditorFn) (*oapi.ResetDbaasKafkaUserPasswordResponse, error) {
+	_va := make([]interface{}, len(reqEditors))
+	for _i := range reqEditors {
+		_va[_i] = reqEditors[_i]
+	}
+	var _ca []interface{}
+	_ca = append(_ca, ctx, serviceName, username, body)
+	_ca = append(_ca, _va...)
+	ret := _m.Called(_ca...)
+
+	var r0 *oapi.ResetDbaasKafkaUserPasswordResponse
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, oapi.DbaasServiceName, oapi.DbaasUserUsername, oapi.ResetDbaasKafkaUserPasswordJSONRequestBody, ...oapi.RequestEditorFn) (*oapi.ResetDbaasKafkaUserPasswordResponse, error)); ok {
+		return rf(ctx, serviceName, username, body, reqEditors...)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, oapi.DbaasServiceName, oapi.DbaasUserUsername, oapi.ResetDbaasKafkaUserPasswordJSONRequestBody, ...oapi.RequestEditorFn) *oapi.ResetDbaasKafkaUserPasswordResponse); ok {
+		r0 = rf(ctx, serviceName, username, body, reqEditors...)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*oapi.ResetDbaasKafkaUserPasswordResponse)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, oapi.DbaasServiceName, oapi.DbaasUserUsername, oapi.ResetDbaasKafkaUserPasswordJSONRequestBody, ...oapi.RequestEditorFn) error); ok {
+		r1 = rf(ctx, serviceName, username, body, reqEditors...)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// mockEgoscaleClient_ResetDbaasKafkaUserPasswordWithResponse_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ResetDbaasKafkaUserPasswordWithResponse'
+type mockEgoscaleClient_ResetDbaasKafkaUserPasswordWithResponse_Call struct {
+	*mock.Call
+}
+
+// ResetDbaasKafkaUserPasswordWithResponse is a helper method to define mock.On call
+//   - ctx context.Context
+//   - serviceName oapi.DbaasServiceName
+//   - username oapi.DbaasUserUsername
+//   - body oapi.ResetDbaasKafkaUserPasswordJSONRequestBody
+//   - reqEditors ...oapi.RequestEditorFn
+func (_e *mockEgoscaleClient_Expecter) ResetDbaasKafkaUserPasswordWithResponse(ctx interface{}, serviceName interface{}, username interface{}, body interface{}, reqEditors ...interface{}) *mockEgoscaleClient_ResetDbaasKafkaUserPasswordWithResponse_Call {
+	return &mockEgoscaleClient_ResetDbaasKafkaUserPasswordWithResponse_Call{Call: _e.mock.On("ResetDbaasKafkaUserPasswordWithResponse",
+		append([]interface{}{ctx, serviceName, username, body}, reqEditors...)...)}
+}
+
+func (_c *mockEgoscaleClient_ResetDbaasKafkaUserPasswordWithResponse_Call) Run(run func(ctx context.Context, serviceName oapi.DbaasServiceName, username oapi.DbaasUserUsername, body oapi.ResetDbaasKafkaUserPasswordJSONRequestBody, reqEditors ...oapi.RequestEditorFn)) *mockEgoscaleClient_ResetDbaasKafkaUserPasswordWithResponse_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		variadicArgs := make([]oapi.RequestEditorFn, len(args)-4)
+		for i, a := range args[4:] {
+			if a != nil {
+				variadicArgs[i] = a.(oapi.RequestEditorFn)
+			}
+		}
+		run(args[0].(context.Context), args[1].(oapi.DbaasServiceName), args[2].(oapi.DbaasUserUsername), args[3].(oapi.ResetDbaasKafkaUserPasswordJSONRequestBody), variadicArgs...)
+	})
+	return _c
+}
+
+func (_c *mockEgoscaleClient_ResetDbaasKafkaUserPasswordWithResponse_Call) Return(_a0 *oapi.ResetDbaasKafkaUserPasswordResponse, _a1 error) *mockEgoscaleClient_ResetDbaasKafkaUserPasswordWithResponse_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *mockEgoscaleClient_ResetDbaasKafkaUserPasswordWithResponse_Call) RunAndReturn(run func(context.Context, oapi.DbaasServiceName, oapi.DbaasUserUsername, oapi.ResetDbaasKafkaUserPasswordJSONRequestBody, ...oapi.RequestEditorFn) (*oapi.ResetDbaasKafkaUserPasswordResponse, error)) *mockEgoscaleClient_ResetDbaasKafkaUserPasswordWithResponse_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ResetDbaasMysqlUserPasswordWithResponse provides a mock function with given fields: ctx, serviceName, username, body, reqEditors
+func (_m *mockEgoscaleClient) ResetDbaasMysqlUserPasswordWithResponse(ctx context.Context, serviceName oapi.DbaasServiceName, username oapi.DbaasUserUsername, body oapi.ResetDbaasMysqlUserPasswordJSONRequestBody, reqEditors ...oapi.RequestEditorFn) (*oapi.ResetDbaasMysqlUserPasswordResponse, error) {
+	_va := make([]interface{}, len(reqEditors))
+	for _i := range reqEditors {
+		_va[_i] = reqEditors[_i]
+	}
+	var _ca []interface{}
+	_ca = append(_ca, ctx, serviceName, username, body)
+	_ca = append(_ca, _va...)
+	ret := _m.Called(_ca...)
+
+	var r0 *oapi.ResetDbaasMysqlUserPasswordResponse
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, oapi.DbaasServiceName, oapi.DbaasUserUsername, oapi.ResetDbaasMysqlUserPasswordJSONRequestBody, ...oapi.RequestEditorFn) (*oapi.ResetDbaasMysqlUserPasswordResponse, error)); ok {
+		return rf(ctx, serviceName, username, body, reqEditors...)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, oapi.DbaasServiceName, oapi.DbaasUserUsername, oapi.ResetDbaasMysqlUserPasswordJSONRequestBody, ...oapi.RequestEditorFn) *oapi.ResetDbaasMysqlUserPasswordResponse); ok {
+		r0 = rf(ctx, serviceName, username, body, reqEditors...)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*oapi.ResetDbaasMysqlUserPasswordResponse)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, oapi.DbaasServiceName, oapi.DbaasUserUsername, oapi.ResetDbaasMysqlUserPasswordJSONRequestBody, ...oapi.RequestEditorFn) error); ok {
+		r1 = rf(ctx, serviceName, username, body, reqEditors...)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// mockEgoscaleClient_ResetDbaasMysqlUserPasswordWithResponse_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ResetDbaasMysqlUserPasswordWithResponse'
+type mockEgoscaleClient_ResetDbaasMysqlUserPasswordWithResponse_Call struct {
+	*mock.Call
+}
+
+// ResetDbaasMysqlUserPasswordWithResponse is a helper method to define mock.On call
+//   - ctx context.Context
+//   - serviceName oapi.DbaasServiceName
+//   - username oapi.DbaasUserUsername
+//   - body oapi.ResetDbaasMysqlUserPasswordJSONRequestBody
+//   - reqEditors ...oapi.RequestEditorFn
+func (_e *mockEgoscaleClient_Expecter) ResetDbaasMysqlUserPasswordWithResponse(ctx interface{}, serviceName interface{}, username interface{}, body interface{}, reqEditors ...interface{}) *mockEgoscaleClient_ResetDbaasMysqlUserPasswordWithResponse_Call {
+	return &mockEgoscaleClient_ResetDbaasMysqlUserPasswordWithResponse_Call{Call: _e.mock.On("ResetDbaasMysqlUserPasswordWithResponse",
+		append([]interface{}{ctx, serviceName, username, body}, reqEditors...)...)}
+}
+
+func (_c *mockEgoscaleClient_ResetDbaasMysqlUserPasswordWithResponse_Call) Run(run func(ctx context.Context, serviceName oapi.DbaasServiceName, username oapi.DbaasUserUsername, body oapi.ResetDbaasMysqlUserPasswordJSONRequestBody, reqEditors ...oapi.RequestEditorFn)) *mockEgoscaleClient_ResetDbaasMysqlUserPasswordWithResponse_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		variadicArgs := make([]oapi.RequestEditorFn, len(args)-4)
+		for i, a := range args[4:] {
+			if a != nil {
+				variadicArgs[i] = a.(oapi.RequestEditorFn)
+			}
+		}
+		run(args[0].(context.Context), args[1].(oapi.DbaasServiceName), args[2].(oapi.DbaasUserUsername), args[3].(oapi.ResetDbaasMysqlUserPasswordJSONRequestBody), variadicArgs...)
+	})
+	return _c
+}
+
+func (_c *mockEgoscaleClient_ResetDbaasMysqlUserPasswordWithResponse_Call) Return(_a0 *oapi.ResetDbaasMysqlUserPasswordResponse, _a1 error) *mockEgoscaleClient_ResetDbaasMysqlUserPasswordWithResponse_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *mockEgoscaleClient_ResetDbaasMysqlUserPasswordWithResponse_Call) RunAndReturn(run func(context.Context, oapi.DbaasServiceName, oapi.DbaasUserUsername, oapi.ResetDbaasMysqlUserPasswordJSONRequestBody, ...oapi.RequestEditorFn) (*oapi.ResetDbaasMysqlUserPasswordResponse, error)) *mockEgoscaleClient_ResetDbaasMysqlUserPasswordWithResponse_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ResetDbaasOpensearchUserPasswordWithResponse provides a mock function with given fields: ctx, serviceName, username, body, reqEditors
+func (_m *mockEgoscaleClient) ResetDbaasOpensearchUserPasswordWithResponse(ctx context.Context, serviceName oapi.DbaasServiceName, username oapi.DbaasUserUsername, body oapi.ResetDbaasOpensearchUserPasswordJSONRequestBody, reqEditors ...oapi.RequestEditorFn) (*oapi.ResetDbaasOpensearchUserPasswordResponse, error) {
+	_va := make([]interface{}, len(reqEditors))
+	for _i := range reqEditors {
+		_va[_i] = reqEditors[_i]
+	}
+	var _ca []interface{}
+	_ca = append(_ca, ctx, serviceName, username, body)
+	_ca = append(_ca, _va...)
+	ret := _m.Called(_ca...)
+
+	var r0 *oapi.ResetDbaasOpensearchUserPasswordResponse
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, oapi.DbaasServiceName, oapi.DbaasUserUsername, oapi.ResetDbaasOpensearchUserPasswordJSONRequestBody, ...oapi.RequestEditorFn) (*oapi.ResetDbaasOpensearchUserPasswordResponse, error)); ok {
+		return rf(ctx, serviceName, username, body, reqEditors...)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, oapi.DbaasServiceName, oapi.DbaasUserUsername, oapi.ResetDbaasOpensearchUserPasswordJSONRequestBody, ...oapi.RequestEditorFn) *oapi.ResetDbaasOpensearchUserPasswordResponse); ok {
+		r0 = rf(ctx, serviceName, username, body, reqEditors...)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*oapi.ResetDbaasOpensearchUserPasswordResponse)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, oapi.DbaasServiceName, oapi.DbaasUserUsername, oapi.ResetDbaasOpensearchUserPasswordJSONRequestBody, ...oapi.RequestEditorFn) error); ok {
+		r1 = rf(ctx, serviceName, username, body, reqEditors...)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// mockEgoscaleClient_ResetDbaasOpensearchUserPasswordWithResponse_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ResetDbaasOpensearchUserPasswordWithResponse'
+type mockEgoscaleClient_ResetDbaasOpensearchUserPasswordWithResponse_Call struct {
+	*mock.Call
+}
+
+// ResetDbaasOpensearchUserPasswordWithResponse is a helper method to define mock.On call
+//   - ctx context.Context
+//   - serviceName oapi.DbaasServiceName
+//   - username oapi.DbaasUserUsername
+//   - body oapi.ResetDbaasOpensearchUserPasswordJSONRequestBody
+//   - reqEditors ...oapi.RequestEditorFn
+func (_e *mockEgoscaleClient_Expecter) ResetDbaasOpensearchUserPasswordWithResponse(ctx interface{}, serviceName interface{}, username interface{}, body interface{}, reqEditors ...interface{}) *mockEgoscaleClient_ResetDbaasOpensearchUserPasswordWithResponse_Call {
+	return &mockEgoscaleClient_ResetDbaasOpensearchUserPasswordWithResponse_Call{Call: _e.mock.On("ResetDbaasOpensearchUserPasswordWithResponse",
+		append([]interface{}{ctx, serviceName, username, body}, reqEditors...)...)}
+}
+
+func (_c *mockEgoscaleClient_ResetDbaasOpensearchUserPasswordWithResponse_Call) Run(run func(ctx context.Context, serviceName oapi.DbaasServiceName, username oapi.DbaasUserUsername, body oapi.ResetDbaasOpensearchUserPasswordJSONRequestBody, reqEditors ...oapi.RequestEditorFn)) *mockEgoscaleClient_ResetDbaasOpensearchUserPasswordWithResponse_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		variadicArgs := make([]oapi.RequestEditorFn, len(args)-4)
+		for i, a := range args[4:] {
+			if a != nil {
+				variadicArgs[i] = a.(oapi.RequestEditorFn)
+			}
+		}
+		run(args[0].(context.Context), args[1].(oapi.DbaasServiceName), args[2].(oapi.DbaasUserUsername), args[3].(oapi.ResetDbaasOpensearchUserPasswordJSONRequestBody), variadicArgs...)
+	})
+	return _c
+}
+
+func (_c *mockEgoscaleClient_ResetDbaasOpensearchUserPasswordWithResponse_Call) Return(_a0 *oapi.ResetDbaasOpensearchUserPasswordResponse, _a1 error) *mockEgoscaleClient_ResetDbaasOpensearchUserPasswordWithResponse_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *mockEgoscaleClient_ResetDbaasOpensearchUserPasswordWithResponse_Call) RunAndReturn(run func(context.Context, oapi.DbaasServiceName, oapi.DbaasUserUsername, oapi.ResetDbaasOpensearchUserPasswordJSONRequestBody, ...oapi.RequestEditorFn) (*oapi.ResetDbaasOpensearchUserPasswordResponse, error)) *mockEgoscaleClient_ResetDbaasOpensearchUserPasswordWithResponse_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ResetDbaasPostgresUserPasswordWithResponse provides a mock function with given fields: ctx, serviceName, username, body, reqEditors
+func (_m *mockEgoscaleClient) ResetDbaasPostgresUserPasswordWithResponse(ctx context.Context, serviceName oapi.DbaasServiceName, username oapi.DbaasUserUsername, body oapi.ResetDbaasPostgresUserPasswordJSONRequestBody, reqEditors ...oapi.RequestEditorFn) (*oapi.ResetDbaasPostgresUserPasswordResponse, error) {
+	_va := make([]interface{}, len(reqEditors))
+	for _i := range reqEditors {
+		_va[_i] = reqEditors[_i]
+	}
+	var _ca []interface{}
+	_ca = append(_ca, ctx, serviceName, username, body)
+	_ca = append(_ca, _va...)
+	ret := _m.Called(_ca...)
+
+	var r0 *oapi.ResetDbaasPostgresUserPasswordResponse
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, oapi.DbaasServiceName, oapi.DbaasUserUsername, oapi.ResetDbaasPostgresUserPasswordJSONRequestBody, ...oapi.RequestEditorFn) (*oapi.ResetDbaasPostgresUserPasswordResponse, error)); ok {
+		return rf(ctx, serviceName, username, body, reqEditors...)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, oapi.DbaasServiceName, oapi.DbaasUserUsername, oapi.ResetDbaasPostgresUserPasswordJSONRequestBody, ...oapi.RequestEditorFn) *oapi.ResetDbaasPostgresUserPasswordResponse); ok {
+		r0 = rf(ctx, serviceName, username, body, reqEditors...)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*oapi.ResetDbaasPostgresUserPasswordResponse)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, oapi.DbaasServiceName, oapi.DbaasUserUsername, oapi.ResetDbaasPostgresUserPasswordJSONRequestBody, ...oapi.RequestEditorFn) error); ok {
+		r1 = rf(ctx, serviceName, username, body, reqEditors...)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// mockEgoscaleClient_ResetDbaasPostgresUserPasswordWithResponse_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ResetDbaasPostgresUserPasswordWithResponse'
+type mockEgoscaleClient_ResetDbaasPostgresUserPasswordWithResponse_Call struct {
+	*mock.Call
+}
+
+// ResetDbaasPostgresUserPasswordWithResponse is a helper method to define mock.On call
+//   - ctx context.Context
+//   - serviceName oapi.DbaasServiceName
+//   - username oapi.DbaasUserUsername
+//   - body oapi.ResetDbaasPostgresUserPasswordJSONRequestBody
+//   - reqEditors ...oapi.RequestEditorFn
+func (_e *mockEgoscaleClient_Expecter) ResetDbaasPostgresUserPasswordWithResponse(ctx interface{}, serviceName interface{}, username interface{}, body interface{}, reqEditors ...interface{}) *mockEgoscaleClient_ResetDbaasPostgresUserPasswordWithResponse_Call {
+	return &mockEgoscaleClient_ResetDbaasPostgresUserPasswordWithResponse_Call{Call: _e.mock.On("ResetDbaasPostgresUserPasswordWithResponse",
+		append([]interface{}{ctx, serviceName, username, body}, reqEditors...)...)}
+}
+
+func (_c *mockEgoscaleClient_ResetDbaasPostgresUserPasswordWithResponse_Call) Run(run func(ctx context.Context, serviceName oapi.DbaasServiceName, username oapi.DbaasUserUsername, body oapi.ResetDbaasPostgresUserPasswordJSONRequestBody, reqEditors ...oapi.RequestEditorFn)) *mockEgoscaleClient_ResetDbaasPostgresUserPasswordWithResponse_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		variadicArgs := make([]oapi.RequestEditorFn, len(args)-4)
+		for i, a := range args[4:] {
+			if a != nil {
+				variadicArgs[i] = a.(oapi.RequestEditorFn)
+			}
+		}
+		run(args[0].(context.Context), args[1].(oapi.DbaasServiceName), args[2].(oapi.DbaasUserUsername), args[3].(oapi.ResetDbaasPostgresUserPasswordJSONRequestBody), variadicArgs...)
+	})
+	return _c
+}
+
+func (_c *mockEgoscaleClient_ResetDbaasPostgresUserPasswordWithResponse_Call) Return(_a0 *oapi.ResetDbaasPostgresUserPasswordResponse, _a1 error) *mockEgoscaleClient_ResetDbaasPostgresUserPasswordWithResponse_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *mockEgoscaleClient_ResetDbaasPostgresUserPasswordWithResponse_Call) RunAndReturn(run func(context.Context, oapi.DbaasServiceName, oapi.DbaasUserUsername, oapi.ResetDbaasPostgresUserPasswordJSONRequestBody, ...oapi.RequestEditorFn) (*oapi.ResetDbaasPostgresUserPasswordResponse, error)) *mockEgoscaleClient_ResetDbaasPostgresUserPasswordWithResponse_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// RevealInstancePasswordWithResponse provides a mock function with given fields: ctx, id, reqEditors
+func (_m *mockEgoscaleClient) RevealInstancePasswordWithResponse(ctx context.Context, id string, reqEditors ...oapi.RequestEditorFn) (*oapi.RevealInstancePasswordResponse, error) {
+	_va := make([]interface{}, len(reqEditors))
+	for _i := range reqEditors {
+		_va[_i] = reqEditors[_i]
+	}
+	var _ca []interface{}
+	_ca = append(_ca, ctx, id)
+	_ca = append(_ca, _va...)
+	ret := _m.Called(_ca...)
+
+	var r0 *oapi.RevealInstancePasswordResponse
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, ...oapi.RequestEditorFn) (*oapi.RevealInstancePasswordResponse, error)); ok {
+		return rf(ctx, id, reqEditors...)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, ...oapi.RequestEditorFn) *oapi.RevealInstancePasswordResponse); ok {
+		r0 = rf(ctx, id, reqEditors...)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*oapi.RevealInstancePasswordResponse)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, ...oapi.RequestEditorFn) error); ok {
+		r1 = rf(ctx, id, reqEditors...)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// mockEgoscaleClient_RevealInstancePasswordWithResponse_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'RevealInstancePasswordWithResponse'
+type mockEgoscaleClient_RevealInstancePasswordWithResponse_Call struct {
+	*mock.Call
+}
+
+// RevealInstancePasswordWithResponse is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id string
+//   - reqEditors ...oapi.RequestEditorFn
+func (_e *mockEgoscaleClient_Expecter) RevealInstancePasswordWithResponse(ctx interface{}, id interface{}, reqEditors ...interface{}) *mockEgoscaleClient_RevealInstancePasswordWithResponse_Call {
+	return &mockEgoscaleClient_RevealInstancePasswordWithResponse_Call{Call: _e.mock.On("RevealInstancePasswordWithResponse",
+		append([]interface{}{ctx, id}, reqEditors...)...)}
+}
+
+func (_c *mockEgoscaleClient_RevealInstancePasswordWithResponse_Call) Run(run func(ctx context.Context, id string, reqEditors ...oapi.RequestEditorFn)) *mockEgoscaleClient_RevealInstancePasswordWithResponse_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		variadicArgs := make([]oapi.RequestEditorFn, len(args)-2)
+		for i, a := range args[2:] {
+			if a != nil {
+				variadicArgs[i] = a.(oapi.RequestEditorFn)
+			}
+		}
+		run(args[0].(context.Context), args[1].(string), variadicArgs...)
+	})
+	return _c
+}
+
+func (_c *mockEgoscaleClient_RevealInstancePasswordWithResponse_Call) Return(_a0 *oapi.RevealInstancePasswordResponse, _a1 error) *mockEgoscaleClient_RevealInstancePasswordWithResponse_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *mockEgoscaleClient_RevealInstancePasswordWithResponse_Call) RunAndReturn(run func(context.Context, string, ...oapi.RequestEditorFn) (*oapi.RevealInstancePasswordResponse, error)) *mockEgoscaleClient_RevealInstancePasswordWithResponse_Call {
 	_c.Call.Return(run)
 	return _c
 }
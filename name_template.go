@@ -0,0 +1,145 @@
+package exoscale
+
+import (
+	"crypto/rand"
+	"fmt"
+	"math/big"
+	"strings"
+	"text/template"
+	"time"
+)
+
+const (
+	configNameTemplate = "name_template"
+
+	// defaultNameTemplate names resources vault-<api_key_name_prefix>-<role>-<display name>-<timestamp>
+	defaultNameTemplate = `vault-{{ with .Prefix }}{{ . }}-{{ end }}{{ .RoleName }}-{{ .DisplayName }}-{{ unix_time_nano }}`
+
+	// maxResourceNameLength is the maximum length of the name of an Exoscale IAM resource
+	maxResourceNameLength = 255
+
+	randomCharset = "abcdefghijklmnopqrstuvwxyzABCDEFGHIJKLMNOPQRSTUVWXYZ0123456789"
+)
+
+const nameTemplateHelp = `
+The names of the API keys are generated with a Go template (name_template), either
+set on config/root or on a role. The template has access to:
+	.Prefix: api_key_name_prefix of config/root or of the account
+	.RoleName: name of the role
+	.DisplayName: display name of the requester
+	.EntityID: ID of the Vault entity of the requester
+	.Metadata: metadata of the Vault entity of the requester
+	.MountAccessor: accessor of the mount
+
+and to the functions random <length>, truncate <length>, lowercase, uppercase,
+replace <old> <new>, unix_time, unix_time_nano and timestamp <Go layout>.
+Characters other than letters, digits, ".", "_", "@" and "-" are replaced with "-",
+names are limited to 255 characters. The default template is:
+
+    ` + defaultNameTemplate + `
+
+The tidy endpoint only considers keys whose names start with vault-<api_key_name_prefix>-
+and end with -{{ unix_time_nano }}.
+`
+
+// nameData is the data available to name templates
+type nameData struct {
+	Prefix        string
+	RoleName      string
+	DisplayName   string
+	EntityID      string
+	Metadata      map[string]string
+	MountAccessor string
+}
+
+var nameTemplateFuncs = template.FuncMap{
+	"random": func(length int) (string, error) {
+		return randomString(randomCharset, length)
+	},
+	"truncate": func(length int, s string) string {
+		if len(s) > length {
+			return s[:length]
+		}
+		return s
+	},
+	"lowercase": strings.ToLower,
+	"uppercase": strings.ToUpper,
+	"replace": func(old, new, s string) string {
+		return strings.ReplaceAll(s, old, new)
+	},
+	"unix_time":      func() int64 { return time.Now().Unix() },
+	"unix_time_nano": func() int64 { return time.Now().UnixNano() },
+	"timestamp":      func(layout string) string { return time.Now().UTC().Format(layout) },
+}
+
+// randomString returns a random string of characters of charset
+func randomString(charset string, length int) (string, error) {
+	var sb strings.Builder
+	for i := 0; i < length; i++ {
+		n, err := rand.Int(rand.Reader, big.NewInt(int64(len(charset))))
+		if err != nil {
+			return "", err
+		}
+		sb.WriteByte(charset[n.Int64()])
+	}
+	return sb.String(), nil
+}
+
+// sanitizeName replaces the characters not allowed in Exoscale resource names
+func sanitizeName(name string) string {
+	return strings.Map(func(r rune) rune {
+		switch {
+		case r >= 'a' && r <= 'z', r >= 'A' && r <= 'Z', r >= '0' && r <= '9',
+			r == '.', r == '_', r == '@', r == '-':
+			return r
+		default:
+			return '-'
+		}
+	}, name)
+}
+
+// renderName generates a resource name from a name template, the default template if empty
+func renderName(text string, data nameData) (string, error) {
+	if text == "" {
+		text = defaultNameTemplate
+	}
+
+	tmpl, err := template.New(configNameTemplate).Funcs(nameTemplateFuncs).Option("missingkey=zero").Parse(text)
+	if err != nil {
+		return "", fmt.Errorf("invalid %s: %w", configNameTemplate, err)
+	}
+
+	var sb strings.Builder
+	if err := tmpl.Execute(&sb, data); err != nil {
+		return "", fmt.Errorf("unable to apply %s: %w", configNameTemplate, err)
+	}
+
+	name := sanitizeName(strings.TrimSpace(sb.String()))
+	if name == "" {
+		return "", fmt.Errorf("%s generated an empty name", configNameTemplate)
+	}
+	if len(name) > maxResourceNameLength {
+		return "", fmt.Errorf("name generated by %s exceeds %d characters, use truncate: %q",
+			configNameTemplate, maxResourceNameLength, name)
+	}
+
+	return name, nil
+}
+
+// validateNameTemplate checks that a name template generates a valid name for sample data
+func validateNameTemplate(text string) error {
+	if text == "" {
+		return nil
+	}
+
+	_, err := renderName(text, nameData{
+		Prefix:        "prefix",
+		RoleName:      "role",
+		DisplayName:   "token",
+		EntityID:      "00000000-0000-0000-0000-000000000000",
+		Metadata:      map[string]string{},
+		MountAccessor: "exoscale_00000000",
+	})
+
+	return err
+}
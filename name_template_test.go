@@ -0,0 +1,107 @@
+package exoscale
+
+import (
+	"context"
+	"strings"
+
+	"github.com/hashicorp/vault/sdk/logical"
+	"github.com/stretchr/testify/mock"
+
+	"github.com/exoscale/egoscale/v2/oapi"
+)
+
+func (ts *testSuite) TestRenderName() {
+	data := nameData{
+		Prefix:        "ci",
+		RoleName:      "deploy",
+		DisplayName:   "oidc-alice@example.com",
+		EntityID:      "3d1c6a4e-9d0b-4a4b-8f3e-1b7c0f5b2a1d",
+		Metadata:      map[string]string{"team": "Platform"},
+		MountAccessor: "exoscale_1234",
+	}
+
+	tests := []struct {
+		name     string
+		template string
+		expected string
+		err      string
+	}{
+		{
+			name:     "default",
+			template: "",
+			expected: `^vault-ci-deploy-oidc-alice@example.com-[0-9]{19}$`,
+		},
+		{
+			name:     "custom",
+			template: `{{ .RoleName }}-{{ .Metadata.team | lowercase }}-{{ .EntityID | truncate 8 }}-{{ random 6 }}`,
+			expected: `^deploy-platform-3d1c6a4e-[a-zA-Z0-9]{6}$`,
+		},
+		{
+			name:     "sanitized",
+			template: `{{ .RoleName }} {{ .MountAccessor }}/{{ .Metadata.missing }}`,
+			expected: `^deploy-exoscale_1234-$`,
+		},
+		{
+			name:     "too long",
+			template: `{{ .RoleName }}-{{ random 300 }}`,
+			err:      "exceeds 255 characters",
+		},
+		{
+			name:     "invalid",
+			template: `{{ .RoleName `,
+			err:      "invalid name_template",
+		},
+	}
+
+	for _, tt := range tests {
+		ts.Run(tt.name, func() {
+			name, err := renderName(tt.template, data)
+			if tt.err != "" {
+				ts.Require().ErrorContains(err, tt.err)
+				return
+			}
+			ts.Require().NoError(err)
+			ts.Require().Regexp(tt.expected, name)
+		})
+	}
+}
+
+func (ts *testSuite) TestPathAPIKeyRoleNameTemplate() {
+	res, err := ts.backend.HandleRequest(context.Background(), &logical.Request{
+		Storage:   ts.storage,
+		Operation: logical.CreateOperation,
+		Path:      roleStoragePathPrefix + testRoleName,
+		Data: map[string]interface{}{
+			configIAMPolicy:    `{"default-service-strategy":"deny"}`,
+			configNameTemplate: `{{ .RoleName`,
+		},
+	})
+	ts.Require().ErrorContains(err, "invalid name_template")
+	ts.Require().Nil(res)
+
+	ts.storeEntry(roleStoragePathPrefix+testRoleName, Role{
+		IAMRoleID:    ts.randomID(),
+		Version:      "v3",
+		Renewable:    true,
+		NameTemplate: `{{ .RoleName | uppercase }}-{{ .DisplayName }}`,
+	})
+
+	var name string
+	mockClient := ts.backend.(*exoscaleBackend).exo.egoscaleClient.(*mockEgoscaleClient)
+	mockClient.
+		On("CreateApiKeyWithResponse", mock.Anything, mock.Anything).
+		Run(func(args mock.Arguments) {
+			name = args.Get(1).(oapi.CreateApiKeyJSONRequestBody).Name
+		}).
+		Return(&oapi.CreateApiKeyResponse{
+			JSON200: &oapi.IamApiKeyCreated{
+				Key:    &testIAMAccessKeyKey,
+				Name:   &name,
+				RoleId: ptr(ts.randomID()),
+				Secret: &testIAMAccessKeySecret,
+			},
+		}, nil)
+
+	ts.issueAPIKey()
+	ts.Require().Equal(strings.ToUpper(testRoleName)+"-test", name)
+}
@@ -0,0 +1,229 @@
+package exoscale
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"strings"
+
+	"github.com/hashicorp/vault/sdk/framework"
+	"github.com/hashicorp/vault/sdk/logical"
+)
+
+const (
+	configAccessCIDR     = "cidr"
+	configAccessPort     = "port"
+	configAccessProtocol = "protocol"
+
+	accessRuleDescriptionRandomLength = 16
+)
+
+const (
+	pathAccessHelpSyn  = "Temporarily allow ingress traffic to a security group"
+	pathAccessHelpDesc = `
+This endpoint adds a rule to the security group of an access role, allowing ingress
+traffic from a source network to a port, for the duration of the lease. The rule is
+deleted when the lease expires or is revoked. Rules are described as
+vault-<role>-<random>, regardless of name_template, to identify the rule of each lease.
+
+Fields:
+	cidr: source address or network, at most max_cidr_size host bits large (optional,
+		default: the address of the client connection, if known by Vault)
+	port: port or range of ports (<start>-<end>) to open, within the ports of the role
+		(optional if the role has a single range of ports)
+	protocol: protocol to allow, one of the protocols of the role (optional if the role
+		has a single protocol)
+
+Vault only exposes the address of the client connection to some plugins, and it may
+be the address of a proxy: set cidr explicitly when in doubt.
+
+Example:
+    vault write exoscale/access/bastion-ssh cidr=203.0.113.7
+`
+)
+
+func (b *exoscaleBackend) pathAccess() *framework.Path {
+	return &framework.Path{
+		Pattern: "access/" + framework.GenericNameRegex(configVaultRoleName),
+		Fields: map[string]*framework.FieldSchema{
+			configVaultRoleName: {
+				Type:        framework.TypeString,
+				Description: "Name of the access role",
+				Required:    true,
+			},
+			configAccessCIDR: {
+				Type:        framework.TypeString,
+				Description: "Source address or network (optional, default: the address of the client connection)",
+			},
+			configAccessPort: {
+				Type:        framework.TypeString,
+				Description: "Port or range of ports to open (optional if the role has a single range of ports)",
+			},
+			configAccessProtocol: {
+				Type:        framework.TypeString,
+				Description: "Protocol to allow (optional if the role has a single protocol)",
+			},
+		},
+
+		Operations: map[logical.Operation]framework.OperationHandler{
+			logical.ReadOperation:   &framework.PathOperation{Callback: b.writeAccess},
+			logical.UpdateOperation: &framework.PathOperation{Callback: b.writeAccess},
+		},
+
+		HelpSynopsis:    pathAccessHelpSyn,
+		HelpDescription: pathAccessHelpDesc,
+	}
+}
+
+// parseAccessCIDR parses a source address or network, returning the network in the
+// canonical CIDR notation used by the Exoscale API: host bits are cleared, and a bare
+// address is a network of a single address
+func parseAccessCIDR(s string, maxSize int) (*net.IPNet, error) {
+	cidr := s
+	if !strings.Contains(s, "/") {
+		ip := net.ParseIP(s)
+		if ip == nil {
+			return nil, fmt.Errorf("invalid %s %q", configAccessCIDR, s)
+		}
+		if ip4 := ip.To4(); ip4 != nil {
+			cidr = ip4.String() + "/32"
+		} else {
+			cidr = ip.String() + "/128"
+		}
+	}
+
+	_, network, err := net.ParseCIDR(cidr)
+	if err != nil {
+		return nil, fmt.Errorf("invalid %s %q", configAccessCIDR, s)
+	}
+	// IPv4-mapped IPv6 networks must be given in their IPv4 form
+	if network.IP.To4() != nil && len(network.Mask) == net.IPv6len {
+		return nil, fmt.Errorf("invalid %s %q, IPv4-mapped networks aren't supported", configAccessCIDR, s)
+	}
+	if ones, bits := network.Mask.Size(); bits-ones > maxSize {
+		return nil, fmt.Errorf("%s %q is too large, at most %d host bits are allowed", configAccessCIDR, s, maxSize)
+	}
+
+	return network, nil
+}
+
+// accessRuleDescription generates the description of the rule of a lease, which identifies
+// the rule in the security group: it is random so that it is unique.
+func accessRuleDescription(roleName string) (string, error) {
+	suffix, err := randomString(randomCharset, accessRuleDescriptionRandomLength)
+	if err != nil {
+		return "", err
+	}
+
+	return "vault-" + sanitizeName(roleName) + "-" + suffix, nil
+}
+
+func (b *exoscaleBackend) writeAccess(
+	ctx context.Context,
+	req *logical.Request,
+	data *framework.FieldData,
+) (*logical.Response, error) {
+	roleName := data.Get(configVaultRoleName).(string)
+	role, err := getAccessRole(ctx, req.Storage, roleName)
+	if err != nil {
+		return nil, err
+	}
+	if role == nil {
+		return logical.ErrorResponse("access role %q not found", roleName), nil
+	}
+
+	source := data.Get(configAccessCIDR).(string)
+	if source == "" && req.Connection != nil {
+		source = req.Connection.RemoteAddr
+	}
+	if source == "" {
+		return logical.ErrorResponse("%s is required, the address of the client connection is unknown", configAccessCIDR), nil
+	}
+	cidr, err := parseAccessCIDR(source, role.MaxCIDRSize)
+	if err != nil {
+		return logical.ErrorResponse(err.Error()), nil
+	}
+
+	var ports portRange
+	if v := data.Get(configAccessPort).(string); v != "" {
+		if ports, err = parsePortRange(v); err != nil {
+			return logical.ErrorResponse(err.Error()), nil
+		}
+		if !role.allowsPorts(ports) {
+			return logical.ErrorResponse("%s %s isn't allowed by role %q", configAccessPort, ports, roleName), nil
+		}
+	} else {
+		if len(role.Ports) != 1 {
+			return logical.ErrorResponse("%s is required, role %q allows several ports", configAccessPort, roleName), nil
+		}
+		ports = role.Ports[0]
+	}
+
+	protocol := data.Get(configAccessProtocol).(string)
+	if protocol == "" {
+		if len(role.Protocols) != 1 {
+			return logical.ErrorResponse("%s is required, role %q allows several protocols", configAccessProtocol, roleName), nil
+		}
+		protocol = role.Protocols[0]
+	}
+	if !role.allowsProtocol(protocol) {
+		return logical.ErrorResponse("%s %q isn't allowed by role %q", configAccessProtocol, protocol, roleName), nil
+	}
+
+	exo, err := b.exoscale(ctx, req.Storage, role.Account)
+	if err != nil {
+		return logical.ErrorResponse(err.Error()), nil
+	}
+
+	description, err := accessRuleDescription(roleName)
+	if err != nil {
+		return nil, err
+	}
+
+	ruleID, err := exo.V3AddSecurityGroupRule(ctx, role.SecurityGroupID, securityGroupRule{
+		Protocol:    protocol,
+		Network:     cidr.String(),
+		StartPort:   ports.Start,
+		EndPort:     ports.End,
+		Description: description,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if err := putAccountLease(ctx, req.Storage, role.Account, SecretTypeAccess, ruleID); err != nil {
+		if err := exo.V3DeleteSecurityGroupRule(ctx, role.SecurityGroupID, ruleID); err != nil {
+			b.Logger().Warn("Failed to clean up security group rule", "security_group_id", role.SecurityGroupID, "rule_id", ruleID, "err", err)
+		}
+		return nil, err
+	}
+
+	res := b.Secret(SecretTypeAccess).Response(
+		map[string]interface{}{
+			configAccessSecurityGroupID: role.SecurityGroupID,
+			"rule_id":                   ruleID,
+			configAccessCIDR:            cidr.String(),
+			configAccessPort:            ports.String(),
+			configAccessProtocol:        protocol,
+		},
+		map[string]interface{}{
+			"role":                      roleName,
+			configAccessSecurityGroupID: role.SecurityGroupID,
+			"rule_id":                   ruleID,
+			configRoleAccount:           role.Account,
+		})
+	res.Secret.TTL = role.TTL
+	res.Secret.MaxTTL = role.MaxTTL
+	res.Secret.Renewable = true
+
+	b.Logger().Info("Security group rule added",
+		"role", roleName,
+		"security_group_id", role.SecurityGroupID,
+		"rule_id", ruleID,
+		"cidr", cidr.String(),
+		"port", ports.String(),
+		"protocol", protocol,
+		"entity_id", req.EntityID)
+
+	return res, nil
+}
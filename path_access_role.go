@@ -0,0 +1,345 @@
+package exoscale
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/vault/sdk/framework"
+	"github.com/hashicorp/vault/sdk/logical"
+)
+
+// AccessRole is a role temporarily allowing ingress traffic to a security group
+type AccessRole struct {
+	SecurityGroupID string        `json:"security_group_id"`
+	Ports           []portRange   `json:"ports"`
+	Protocols       []string      `json:"protocols"`
+	MaxCIDRSize     int           `json:"max_cidr_size,omitempty"`
+	TTL             time.Duration `json:"ttl,omitempty"`
+	MaxTTL          time.Duration `json:"max_ttl,omitempty"`
+	Account         string        `json:"account,omitempty"`
+}
+
+// portRange is an inclusive range of ports
+type portRange struct {
+	Start int64 `json:"start"`
+	End   int64 `json:"end"`
+}
+
+func (r portRange) String() string {
+	if r.Start == r.End {
+		return strconv.FormatInt(r.Start, 10)
+	}
+	return fmt.Sprintf("%d-%d", r.Start, r.End)
+}
+
+// contains returns whether a range of ports is within the range
+func (r portRange) contains(o portRange) bool {
+	return o.Start >= r.Start && o.End <= r.End
+}
+
+// parsePortRange parses a port or a range of ports formatted as <start>-<end>
+func parsePortRange(s string) (portRange, error) {
+	start, end, isRange := strings.Cut(strings.TrimSpace(s), "-")
+	if !isRange {
+		end = start
+	}
+
+	var r portRange
+	var err error
+	if r.Start, err = strconv.ParseInt(start, 10, 64); err != nil {
+		return portRange{}, fmt.Errorf("invalid port range %q", s)
+	}
+	if r.End, err = strconv.ParseInt(end, 10, 64); err != nil {
+		return portRange{}, fmt.Errorf("invalid port range %q", s)
+	}
+	if r.Start < 1 || r.End > 65535 || r.Start > r.End {
+		return portRange{}, fmt.Errorf("invalid port range %q, ports must be between 1 and 65535", s)
+	}
+
+	return r, nil
+}
+
+// allowsPorts returns whether a range of ports is within one of the ranges of the role
+func (r *AccessRole) allowsPorts(ports portRange) bool {
+	return slices.ContainsFunc(r.Ports, func(p portRange) bool { return p.contains(ports) })
+}
+
+// allowsProtocol returns whether a protocol is one of the protocols of the role
+func (r *AccessRole) allowsProtocol(protocol string) bool {
+	return slices.Contains(r.Protocols, protocol)
+}
+
+// accessProtocols are the protocols which can be allowed by access roles
+var accessProtocols = []string{"tcp", "udp"}
+
+const (
+	accessRoleStoragePathPrefix = "access-role/"
+
+	configAccessSecurityGroupID = "security_group_id"
+	configAccessPorts           = "ports"
+	configAccessProtocols       = "protocols"
+	configAccessMaxCIDRSize     = "max_cidr_size"
+)
+
+const (
+	pathListAccessRolesHelpSyn  = "List the configured access roles"
+	pathListAccessRolesHelpDesc = `
+This endpoint returns a list of the configured access roles.
+`
+
+	pathAccessRoleHelpSyn  = "Manage access roles"
+	pathAccessRoleHelpDesc = `
+Manage access roles, adding an ingress rule to an Exoscale security group for each
+lease of the access/<name> endpoint. The rule is deleted when the lease is revoked.
+
+Fields:
+	security_group_id: ID of the security group
+	ports: comma-separated list of ports or ranges of ports (<start>-<end>) which can be opened
+	protocols: comma-separated list of protocols which can be allowed, tcp or udp (optional, default: tcp)
+	max_cidr_size: maximum number of host bits of the source networks, 0 only allows
+		single addresses, 8 allows up to a /24 IPv4 or a /120 IPv6 network (optional, default: 0)
+	ttl: default TTL of the leases (optional, default: the default lease TTL of the mount)
+	max_ttl: maximum TTL of the leases (optional, default: the maximum lease TTL of the mount)
+	account: name of the account configured with config/account/<name> owning the security group (optional)
+
+Example:
+    vault write exoscale/access-role/bastion-ssh \
+	security_group_id=7b6a1c3e-4d2f-4b8e-9c5a-2e1f0d9c8b7a \
+	ports=22 \
+	ttl=1h max_ttl=4h
+`
+)
+
+func (b *exoscaleBackend) pathAccessRole() []*framework.Path {
+	return []*framework.Path{
+		{
+			Pattern: "access-role/" + framework.GenericNameRegex(configVaultRoleName),
+			Fields: map[string]*framework.FieldSchema{
+				configVaultRoleName: {
+					Type:        framework.TypeString,
+					Description: "Name of the access role",
+					Required:    true,
+				},
+				configAccessSecurityGroupID: {
+					Type:        framework.TypeString,
+					Description: "ID of the security group",
+				},
+				configAccessPorts: {
+					Type:        framework.TypeCommaStringSlice,
+					Description: "Comma-separated list of ports or ranges of ports which can be opened",
+				},
+				configAccessProtocols: {
+					Type:        framework.TypeCommaStringSlice,
+					Description: "Comma-separated list of protocols which can be allowed (optional, default: tcp)",
+				},
+				configAccessMaxCIDRSize: {
+					Type:        framework.TypeInt,
+					Description: "Maximum number of host bits of the source networks (optional, default: 0)",
+				},
+				configRoleTTL: {
+					Type:        framework.TypeDurationSecond,
+					Description: "Default TTL of the leases",
+				},
+				configRoleMaxTTL: {
+					Type:        framework.TypeDurationSecond,
+					Description: "Maximum TTL of the leases",
+				},
+				configRoleAccount: {
+					Type:        framework.TypeString,
+					Description: "Name of the account configured with config/account/<name> owning the security group (optional)",
+				},
+			},
+
+			Operations: map[logical.Operation]framework.OperationHandler{
+				logical.CreateOperation: &framework.PathOperation{Callback: b.writeAccessRole},
+				logical.UpdateOperation: &framework.PathOperation{Callback: b.writeAccessRole},
+				logical.ReadOperation:   &framework.PathOperation{Callback: b.readAccessRole},
+				logical.DeleteOperation: &framework.PathOperation{Callback: b.deleteAccessRole},
+			},
+
+			HelpSynopsis:    pathAccessRoleHelpSyn,
+			HelpDescription: pathAccessRoleHelpDesc,
+		},
+		{
+			Pattern: "access-role/?$",
+
+			Operations: map[logical.Operation]framework.OperationHandler{
+				logical.ListOperation: &framework.PathOperation{Callback: b.listAccessRoles},
+			},
+
+			HelpSynopsis:    pathListAccessRolesHelpSyn,
+			HelpDescription: pathListAccessRolesHelpDesc,
+		},
+	}
+}
+
+func getAccessRole(ctx context.Context, storage logical.Storage, name string) (*AccessRole, error) {
+	if name == "" {
+		return nil, errors.New("invalid role name")
+	}
+
+	entry, err := storage.Get(ctx, accessRoleStoragePathPrefix+name)
+	if err != nil {
+		return nil, fmt.Errorf("unable to retrieve access role %q: %w", name, err)
+	}
+	if entry == nil {
+		return nil, nil
+	}
+
+	var role AccessRole
+	if err := entry.DecodeJSON(&role); err != nil {
+		return nil, err
+	}
+
+	return &role, nil
+}
+
+func (b *exoscaleBackend) listAccessRoles(
+	ctx context.Context,
+	req *logical.Request,
+	_ *framework.FieldData,
+) (*logical.Response, error) {
+	roles, err := req.Storage.List(ctx, accessRoleStoragePathPrefix)
+	if err != nil {
+		return nil, err
+	}
+
+	return logical.ListResponse(roles), nil
+}
+
+func (b *exoscaleBackend) readAccessRole(
+	ctx context.Context,
+	req *logical.Request,
+	data *framework.FieldData,
+) (*logical.Response, error) {
+	role, err := getAccessRole(ctx, req.Storage, data.Get(configVaultRoleName).(string))
+	if err != nil {
+		return nil, err
+	}
+	if role == nil {
+		return nil, nil
+	}
+
+	ports := make([]string, 0, len(role.Ports))
+	for _, p := range role.Ports {
+		ports = append(ports, p.String())
+	}
+
+	res := &logical.Response{
+		Data: map[string]interface{}{
+			configAccessSecurityGroupID: role.SecurityGroupID,
+			configAccessPorts:           ports,
+			configAccessProtocols:       role.Protocols,
+			configAccessMaxCIDRSize:     role.MaxCIDRSize,
+			configRoleTTL:               role.TTL.Seconds(),
+			configRoleMaxTTL:            role.MaxTTL.Seconds(),
+		},
+	}
+	if role.Account != "" {
+		res.Data[configRoleAccount] = role.Account
+	}
+
+	return res, nil
+}
+
+func (b *exoscaleBackend) writeAccessRole(
+	ctx context.Context,
+	req *logical.Request,
+	data *framework.FieldData,
+) (*logical.Response, error) {
+	b.rolesLock.Lock()
+	defer b.rolesLock.Unlock()
+
+	name := data.Get(configVaultRoleName).(string)
+	role, err := getAccessRole(ctx, req.Storage, name)
+	if err != nil {
+		return nil, err
+	}
+	if role == nil {
+		role = &AccessRole{Protocols: []string{"tcp"}}
+	}
+
+	if v, ok := data.GetOk(configAccessSecurityGroupID); ok {
+		role.SecurityGroupID = v.(string)
+	}
+	if role.SecurityGroupID == "" {
+		return logical.ErrorResponse("%s is required", configAccessSecurityGroupID), nil
+	}
+
+	if v, ok := data.GetOk(configAccessPorts); ok {
+		role.Ports = nil
+		for _, s := range v.([]string) {
+			ports, err := parsePortRange(s)
+			if err != nil {
+				return logical.ErrorResponse(err.Error()), nil
+			}
+			role.Ports = append(role.Ports, ports)
+		}
+	}
+	if len(role.Ports) == 0 {
+		return logical.ErrorResponse("%s is required", configAccessPorts), nil
+	}
+
+	if v, ok := data.GetOk(configAccessProtocols); ok {
+		role.Protocols = v.([]string)
+	}
+	if len(role.Protocols) == 0 {
+		return logical.ErrorResponse("%s is required", configAccessProtocols), nil
+	}
+	for _, p := range role.Protocols {
+		if !slices.Contains(accessProtocols, p) {
+			return logical.ErrorResponse("%s must be one of %s", configAccessProtocols, strings.Join(accessProtocols, ", ")), nil
+		}
+	}
+
+	if v, ok := data.GetOk(configAccessMaxCIDRSize); ok {
+		role.MaxCIDRSize = v.(int)
+	}
+	if role.MaxCIDRSize < 0 || role.MaxCIDRSize > 128 {
+		return logical.ErrorResponse("%s must be between 0 and 128", configAccessMaxCIDRSize), nil
+	}
+
+	if v, ok := data.GetOk(configRoleTTL); ok {
+		role.TTL = time.Duration(v.(int)) * time.Second
+	}
+	if v, ok := data.GetOk(configRoleMaxTTL); ok {
+		role.MaxTTL = time.Duration(v.(int)) * time.Second
+	}
+	if role.MaxTTL != 0 && role.TTL > role.MaxTTL {
+		return logical.ErrorResponse("%s can't be greater than %s", configRoleTTL, configRoleMaxTTL), nil
+	}
+
+	if v, ok := data.GetOk(configRoleAccount); ok {
+		role.Account = v.(string)
+		if _, err := b.exoscale(ctx, req.Storage, role.Account); err != nil {
+			return logical.ErrorResponse(err.Error()), nil
+		}
+	}
+
+	entry, err := logical.StorageEntryJSON(accessRoleStoragePathPrefix+name, role)
+	if err != nil {
+		return nil, err
+	}
+	if err := req.Storage.Put(ctx, entry); err != nil {
+		return nil, err
+	}
+
+	return nil, nil
+}
+
+func (b *exoscaleBackend) deleteAccessRole(
+	ctx context.Context,
+	req *logical.Request,
+	data *framework.FieldData,
+) (*logical.Response, error) {
+	if err := req.Storage.Delete(ctx, accessRoleStoragePathPrefix+data.Get(configVaultRoleName).(string)); err != nil {
+		return nil, err
+	}
+
+	return nil, nil
+}
@@ -0,0 +1,187 @@
+package exoscale
+
+import (
+	"context"
+
+	"github.com/hashicorp/vault/sdk/logical"
+	"github.com/stretchr/testify/mock"
+
+	"github.com/exoscale/egoscale/v2/oapi"
+)
+
+func (ts *testSuite) TestPathAccess() {
+	sgID := ts.randomID()
+
+	res, err := ts.backend.HandleRequest(context.Background(), &logical.Request{
+		Storage:   ts.storage,
+		Operation: logical.CreateOperation,
+		Path:      accessRoleStoragePathPrefix + "bastion",
+		Data: map[string]interface{}{
+			configAccessSecurityGroupID: sgID,
+			configAccessPorts:           "22,65000-70000",
+		},
+	})
+	ts.Require().NoError(err)
+	ts.Require().ErrorContains(res.Error(), "invalid port range")
+
+	res, err = ts.backend.HandleRequest(context.Background(), &logical.Request{
+		Storage:   ts.storage,
+		Operation: logical.CreateOperation,
+		Path:      accessRoleStoragePathPrefix + "bastion",
+		Data: map[string]interface{}{
+			configAccessSecurityGroupID: sgID,
+			configAccessPorts:           "22,8000-8100",
+			configAccessMaxCIDRSize:     8,
+			configRoleTTL:               "1h",
+		},
+	})
+	ts.Require().NoError(err)
+	ts.Require().Nil(res)
+
+	for _, data := range []map[string]interface{}{
+		{configAccessPort: "22"},
+		{configAccessCIDR: "203.0.113.0/23", configAccessPort: "22"},
+		{configAccessCIDR: "::ffff:203.0.113.0/120", configAccessPort: "22"},
+		{configAccessCIDR: "203.0.113.7", configAccessPort: "8000-8200"},
+		{configAccessCIDR: "203.0.113.7"},
+		{configAccessCIDR: "203.0.113.7", configAccessPort: "22", configAccessProtocol: "udp"},
+	} {
+		res, err = ts.backend.HandleRequest(context.Background(), &logical.Request{
+			Storage:   ts.storage,
+			Operation: logical.UpdateOperation,
+			Path:      "access/bastion",
+			Data:      data,
+		})
+		ts.Require().NoError(err)
+		ts.Require().True(res.IsError(), data)
+	}
+
+	var added oapi.AddRuleToSecurityGroupJSONRequestBody
+	ruleID := ts.randomID()
+	state := oapi.OperationStateSuccess
+	mockClient := ts.backend.(*exoscaleBackend).exo.egoscaleClient.(*mockEgoscaleClient)
+	mockClient.
+		On("AddRuleToSecurityGroupWithResponse", mock.Anything, sgID, mock.Anything).
+		Run(func(args mock.Arguments) {
+			added = args.Get(2).(oapi.AddRuleToSecurityGroupJSONRequestBody)
+		}).
+		Return(&oapi.AddRuleToSecurityGroupResponse{JSON200: &oapi.Operation{State: &state}}, nil)
+	mockClient.
+		On("GetSecurityGroupWithResponse", mock.Anything, sgID).
+		Return(func(context.Context, string, ...oapi.RequestEditorFn) (*oapi.GetSecurityGroupResponse, error) {
+			protocol := oapi.SecurityGroupRuleProtocol(added.Protocol)
+			return &oapi.GetSecurityGroupResponse{JSON200: &oapi.SecurityGroup{Rules: &[]oapi.SecurityGroupRule{
+				{Id: ptr(ts.randomID()), Description: ptr("ssh from anywhere")},
+				{
+					Id:          ptr(ts.randomID()),
+					Description: added.Description,
+					Network:     ptr("0.0.0.0/0"),
+					Protocol:    &protocol,
+					StartPort:   added.StartPort,
+					EndPort:     added.EndPort,
+				},
+				{
+					Id:          &ruleID,
+					Description: added.Description,
+					Network:     added.Network,
+					Protocol:    &protocol,
+					StartPort:   added.StartPort,
+					EndPort:     added.EndPort,
+				},
+			}}}, nil
+		})
+
+	secret, err := ts.backend.HandleRequest(context.Background(), &logical.Request{
+		Storage:   ts.storage,
+		Operation: logical.UpdateOperation,
+		Path:      "access/bastion",
+		Data:      map[string]interface{}{configAccessCIDR: "2001:DB8::1/120", configAccessPort: "8000-8010"},
+	})
+	ts.Require().NoError(err)
+	ts.Require().Equal(ruleID, secret.Data["rule_id"])
+	ts.Require().Equal("2001:db8::/120", *added.Network)
+	ts.Require().Equal("2001:db8::/120", secret.Data[configAccessCIDR])
+	ts.Require().Equal(oapi.AddRuleToSecurityGroupJSONBodyProtocol("tcp"), added.Protocol)
+	ts.Require().Equal(int64(8000), *added.StartPort)
+	ts.Require().Equal(int64(8010), *added.EndPort)
+	ts.Require().Regexp(`^vault-bastion-[a-zA-Z0-9]{16}$`, *added.Description)
+
+	mockClient.
+		On("DeleteRuleFromSecurityGroupWithResponse", mock.Anything, sgID, ruleID).
+		Return(&oapi.DeleteRuleFromSecurityGroupResponse{JSON200: &oapi.Operation{State: &state}}, nil).
+		Once()
+
+	_, err = ts.backend.HandleRequest(context.Background(), &logical.Request{
+		Storage:   ts.storage,
+		Operation: logical.RevokeOperation,
+		Path:      secret.Secret.LeaseID,
+		Secret:    secret.Secret,
+	})
+	ts.Require().NoError(err)
+	mockClient.AssertExpectations(ts.T())
+}
+
+func (ts *testSuite) TestPathAccessRuleNotFound() {
+	sgID := ts.randomID()
+	ts.storeEntry(accessRoleStoragePathPrefix+"bastion", AccessRole{
+		SecurityGroupID: sgID,
+		Ports:           []portRange{{Start: 22, End: 22}},
+		Protocols:       []string{"tcp"},
+		MaxCIDRSize:     8,
+	})
+
+	var added oapi.AddRuleToSecurityGroupJSONRequestBody
+	ruleID := ts.randomID()
+	state := oapi.OperationStateSuccess
+	mockClient := ts.backend.(*exoscaleBackend).exo.egoscaleClient.(*mockEgoscaleClient)
+	mockClient.
+		On("AddRuleToSecurityGroupWithResponse", mock.Anything, sgID, mock.Anything).
+		Run(func(args mock.Arguments) {
+			added = args.Get(2).(oapi.AddRuleToSecurityGroupJSONRequestBody)
+		}).
+		Return(&oapi.AddRuleToSecurityGroupResponse{JSON200: &oapi.Operation{State: &state}}, nil)
+	// the rule added with the description of the lease doesn't match the requested one
+	mockClient.
+		On("GetSecurityGroupWithResponse", mock.Anything, sgID).
+		Return(func(context.Context, string, ...oapi.RequestEditorFn) (*oapi.GetSecurityGroupResponse, error) {
+			return &oapi.GetSecurityGroupResponse{JSON200: &oapi.SecurityGroup{Rules: &[]oapi.SecurityGroupRule{
+				{Id: &ruleID, Description: added.Description, Network: ptr("0.0.0.0/0")},
+			}}}, nil
+		})
+	mockClient.
+		On("DeleteRuleFromSecurityGroupWithResponse", mock.Anything, sgID, ruleID).
+		Return(&oapi.DeleteRuleFromSecurityGroupResponse{JSON200: &oapi.Operation{State: &state}}, nil).
+		Once()
+
+	_, err := ts.backend.HandleRequest(context.Background(), &logical.Request{
+		Storage:   ts.storage,
+		Operation: logical.UpdateOperation,
+		Path:      "access/bastion",
+		Data:      map[string]interface{}{configAccessCIDR: "203.0.113.7"},
+	})
+	ts.Require().ErrorIs(err, ErrNotFound)
+	mockClient.AssertExpectations(ts.T())
+}
+
+func (ts *testSuite) TestParseAccessCIDR() {
+	tests := []struct {
+		cidr string
+		want string
+	}{
+		{"203.0.113.7", "203.0.113.7/32"},
+		{"203.0.113.7/24", "203.0.113.0/24"},
+		{"::ffff:203.0.113.7", "203.0.113.7/32"},
+		{"2001:DB8::1", "2001:db8::1/128"},
+		{"2001:db8::1/120", "2001:db8::/120"},
+	}
+	for _, tt := range tests {
+		network, err := parseAccessCIDR(tt.cidr, 8)
+		ts.Require().NoError(err, tt.cidr)
+		ts.Require().Equal(tt.want, network.String(), tt.cidr)
+	}
+
+	for _, cidr := range []string{"", "203.0.113.7/33", "fe80::1%eth0", "example.com"} {
+		_, err := parseAccessCIDR(cidr, 8)
+		ts.Require().Error(err, cidr)
+	}
+}
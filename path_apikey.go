@@ -2,11 +2,15 @@ package exoscale
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"strings"
 	"time"
 
 	"github.com/hashicorp/vault/sdk/framework"
 	"github.com/hashicorp/vault/sdk/logical"
+
+	"github.com/exoscale/egoscale/v2/oapi"
 )
 
 const (
@@ -22,8 +26,10 @@ This endpoint dynamically generates Exoscale API key/secret credentials based
 on a role, depending on which the generated API key will be restricted to
 certain API operations.
 
-Note: the backend doesn't store the generated API credentials, there is no way
+Note: the backend doesn't store the generated API secrets, there is no way
 to recover an API secret after it's been returned during the secret creation.
+The API key, its name, the requester and the expiry of the lease are recorded
+until the lease is revoked, see issued/, then kept for 30 days, see lookup/.
 `
 )
 
@@ -60,13 +66,49 @@ func (b *exoscaleBackend) createAPIKey(
 		return logical.ErrorResponse("role %q not found", roleName), nil
 	}
 
+	if role.Strict && role.driftStatus() == roleDriftStatusDrifted {
+		return logical.ErrorResponse("role %q drifted from its IAM role (%s), rewrite the role to issue API keys",
+			roleName, strings.Join(role.Drift.Issues, ", ")), nil
+	}
+
+	if res, err := b.acquireKey(ctx, req.Storage, roleName, role); res != nil || err != nil {
+		return res, err
+	}
+	issuedOK := false
+	defer func() {
+		if issuedOK {
+			return
+		}
+		if err := b.releaseKey(ctx, req.Storage, roleName); err != nil {
+			b.Logger().Warn("Failed to release quota of unissued API key", "role", roleName, "err", err)
+		}
+	}()
+
+	exo, err := b.exoscale(ctx, req.Storage, role.Account)
+	if err != nil {
+		return nil, err
+	}
+
+	entity, err := b.entity(req)
+	if err != nil {
+		return nil, err
+	}
+
 	var res *logical.Response
 	if role.Version == "v2" {
-		apikey, err := b.exo.V2CreateAccessKey(ctx, roleName, req.DisplayName, *role)
+		name, err := b.keyName(req, entity, roleName, role, exo)
 		if err != nil {
 			return nil, err
 		}
 
+		apikey, err := exo.V2CreateAccessKey(ctx, name, *role)
+		if err != nil {
+			if res := exo.keyQuotaErrorResponse(ctx, err); res != nil {
+				return res, nil
+			}
+			return nil, err
+		}
+
 		lc, err := getLeaseConfig(ctx, req.Storage)
 		if err != nil {
 			return nil, err
@@ -92,6 +134,7 @@ func (b *exoscaleBackend) createAPIKey(
 				"role":                 roleName,
 				"expireTime":           time.Now().Add(lc.TTL),
 				"name":                 *apikey.Name,
+				"account":              role.Account,
 			})
 
 		res.Secret.TTL = lc.TTL
@@ -106,12 +149,65 @@ func (b *exoscaleBackend) createAPIKey(
 			"iam_name", *apikey.Name,
 			"renewable", res.Secret.Renewable)
 	} else {
-		apikey, err := b.exo.V3CreateAPIKey(ctx, roleName, req.DisplayName, *role)
+		name, err := b.keyName(req, entity, roleName, role, exo)
+		if err != nil {
+			return nil, err
+		}
+
+		// roles carrying their own policy get a dedicated IAM role per API key
+		var dynamicRoleID string
+		if role.IAMPolicy != nil {
+			dynamicRoleID, err = exo.V3CreateRole(ctx, name, roleName, *role.IAMPolicy)
+			if err != nil {
+				b.Logger().Info("Failed to create IAMv3 role",
+					"role", roleName,
+					"iam_name", req.DisplayName,
+					"err", err)
+				return nil, err
+			}
+			role.IAMRoleID = dynamicRoleID
+		}
+
+		var apikey *oapi.IamApiKeyCreated
+		if role.PoolSize > 0 && role.poolable() {
+			pooled, err := b.takePooledKey(ctx, req.Storage, roleName, role)
+			if err != nil {
+				return nil, err
+			}
+			b.refillPoolAsync(roleName)
+
+			if pooled != nil {
+				apikey = &oapi.IamApiKeyCreated{
+					Key:    &pooled.Key,
+					Name:   &pooled.Name,
+					Secret: &pooled.Secret,
+					RoleId: &pooled.IAMRoleID,
+				}
+			}
+		}
+
+		if apikey == nil {
+			apikey, err = exo.V3CreateAPIKey(ctx, name, *role)
+		}
 		if err != nil {
 			b.Logger().Info("Failed to create IAMv3 api key",
 				"role", roleName,
 				"iam_name", req.DisplayName,
 				"err", err)
+			if dynamicRoleID != "" {
+				if err := exo.V3DeleteRole(ctx, dynamicRoleID); err != nil {
+					b.Logger().Warn("Failed to clean up IAMv3 role",
+						"role", roleName,
+						"iam_role_id", dynamicRoleID,
+						"err", err)
+				}
+			}
+			if errors.Is(err, ErrNotFound) {
+				return logical.ErrorResponse("IAM role %q of role %q not found", role.IAMRoleID, roleName), nil
+			}
+			if res := exo.keyQuotaErrorResponse(ctx, err); res != nil {
+				return res, nil
+			}
 			return nil, err
 		}
 
@@ -120,6 +216,18 @@ func (b *exoscaleBackend) createAPIKey(
 			TTL = role.TTL
 		}
 
+		internalData := map[string]interface{}{
+			apiKeySecretDataAPIKey: *apikey.Key,
+			"role":                 roleName,
+			"expireTime":           time.Now().Add(TTL),
+			"name":                 *apikey.Name,
+			"version":              role.Version,
+			"account":              role.Account,
+		}
+		if dynamicRoleID != "" {
+			internalData["dynamic_iam_role_id"] = dynamicRoleID
+		}
+
 		res = b.Secret(SecretTypeAPIKey).Response(
 			// Information returned to the requester
 			map[string]interface{}{
@@ -128,13 +236,7 @@ func (b *exoscaleBackend) createAPIKey(
 				apiKeySecretDataAPISecret: *apikey.Secret,
 			},
 			// Information for internal use (e.g. revoke)
-			map[string]interface{}{
-				apiKeySecretDataAPIKey: *apikey.Key,
-				"role":                 roleName,
-				"expireTime":           time.Now().Add(TTL),
-				"name":                 *apikey.Name,
-				"version":              role.Version,
-			})
+			internalData)
 
 		res.Secret.TTL = TTL
 		res.Secret.MaxTTL = role.MaxTTL
@@ -151,5 +253,68 @@ func (b *exoscaleBackend) createAPIKey(
 			"renewable", res.Secret.Renewable)
 	}
 
+	// the key is recorded for auditing, and so that it isn't considered orphaned by tidy
+	now := time.Now()
+	issued := &issuedKey{
+		Key:         res.Secret.InternalData[apiKeySecretDataAPIKey].(string),
+		Name:        res.Secret.InternalData["name"].(string),
+		Role:        roleName,
+		DisplayName: req.DisplayName,
+		EntityID:    req.EntityID,
+		MountPoint:  req.MountPoint,
+		Version:     role.Version,
+		Account:     role.Account,
+		IssuedAt:    now,
+		ExpiresAt:   now.Add(res.Secret.TTL),
+	}
+	issued.setEntity(entity)
+	if err := putIssuedKey(ctx, req.Storage, issued); err != nil {
+		revokeReq := &logical.Request{Storage: req.Storage, Secret: res.Secret}
+		if _, err := b.secretAPIKeyRevoke(ctx, revokeReq, nil); err != nil {
+			b.Logger().Warn("Failed to clean up unrecorded API key", "role", roleName, "iam_key", issued.Key, "err", err)
+		}
+		return nil, err
+	}
+	issuedOK = true
+
+	if w := exo.keyQuotaWarning(ctx); w != "" {
+		res.AddWarning(w)
+	}
+
 	return res, nil
 }
+
+// entity returns the Vault entity of the requester, nil if the request isn't tied to an entity
+func (b *exoscaleBackend) entity(req *logical.Request) (*logical.Entity, error) {
+	if req.EntityID == "" {
+		return nil, nil
+	}
+
+	entity, err := b.System().EntityInfo(req.EntityID)
+	if err != nil {
+		return nil, fmt.Errorf("unable to retrieve entity %q: %w", req.EntityID, err)
+	}
+
+	return entity, nil
+}
+
+// keyName generates the name of an API key issued for a request
+func (b *exoscaleBackend) keyName(
+	req *logical.Request,
+	entity *logical.Entity,
+	roleName string,
+	role *Role,
+	exo *Exoscale,
+) (string, error) {
+	data := nameData{
+		RoleName:      roleName,
+		DisplayName:   req.DisplayName,
+		EntityID:      req.EntityID,
+		MountAccessor: req.MountAccessor,
+	}
+	if entity != nil {
+		data.Metadata = entity.Metadata
+	}
+
+	return exo.resourceName(data, role.NameTemplate)
+}
@@ -280,3 +280,64 @@ func (ts *testSuite) TestParseIAMAccessKeyResource() {
 		})
 	}
 }
+
+func (ts *testSuite) TestPathV3APIKeyDynamicRole() {
+	roleName := strings.TrimPrefix(ts.T().Name(), "TestSuite/")
+
+	ts.storeEntry(roleStoragePathPrefix+roleName, Role{
+		IAMPolicy: &oapi.IamPolicy{DefaultServiceStrategy: oapi.IamPolicyDefaultServiceStrategyAllow},
+		Renewable: true,
+		TTL:       12 * time.Second,
+		Version:   "v3",
+	})
+
+	dynamicRoleID := ts.randomID()
+	state := oapi.OperationStateSuccess
+	mockClient := ts.backend.(*exoscaleBackend).exo.egoscaleClient.(*mockEgoscaleClient)
+	mockClient.
+		On("CreateIamRoleWithResponse", mock.Anything, mock.Anything).
+		Run(func(args mock.Arguments) {
+			req := args.Get(1).(oapi.CreateIamRoleJSONRequestBody)
+			ts.Require().Regexp("^vault-"+roleName+"-test-[0-9]{19}$", req.Name)
+			ts.Require().Equal(oapi.IamPolicyDefaultServiceStrategyAllow, req.Policy.DefaultServiceStrategy)
+		}).
+		Return(&oapi.CreateIamRoleResponse{
+			JSON200: &oapi.Operation{
+				State: &state,
+				Reference: &struct {
+					Command *string `json:"command,omitempty"`
+					Id      *string `json:"id,omitempty"`
+					Link    *string `json:"link,omitempty"`
+				}{Id: &dynamicRoleID},
+			},
+		}, nil)
+
+	var apikeyname string
+	mockClient.
+		On("CreateApiKeyWithResponse", mock.Anything, mock.Anything).
+		Run(func(args mock.Arguments) {
+			req := args.Get(1).(oapi.CreateApiKeyJSONRequestBody)
+			ts.Require().Equal(dynamicRoleID, req.RoleId)
+			apikeyname = req.Name
+		}).
+		Return(&oapi.CreateApiKeyResponse{
+			JSON200: &oapi.IamApiKeyCreated{
+				Key:    &testIAMAccessKeyKey,
+				Name:   &apikeyname,
+				RoleId: &dynamicRoleID,
+				Secret: &testIAMAccessKeySecret,
+			},
+		}, nil)
+
+	res, err := ts.backend.HandleRequest(context.Background(), &logical.Request{
+		Storage:     ts.storage,
+		Operation:   logical.ReadOperation,
+		Path:        "apikey/" + roleName,
+		DisplayName: "test",
+	})
+	ts.Require().NoError(err)
+
+	ts.Require().Equal(testIAMAccessKeyKey, res.Data[apiKeySecretDataAPIKey])
+	ts.Require().Equal(dynamicRoleID, res.Secret.InternalData["dynamic_iam_role_id"])
+	ts.Require().Equal(12*time.Second, res.Secret.TTL)
+}
@@ -0,0 +1,416 @@
+package exoscale
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/hashicorp/vault/sdk/framework"
+	"github.com/hashicorp/vault/sdk/logical"
+)
+
+const (
+	configAccountStoragePathPrefix = "config/account/"
+
+	configAccountName = "name"
+
+	// accountLeaseStoragePathPrefix records the leases of an account other than API keys,
+	// which are recorded under issued/, as account-lease/<account>/<secret type>-<id>
+	accountLeaseStoragePathPrefix = "account-lease/"
+)
+
+// accountRoleStoragePathPrefixes are the storage paths of the roles which can reference an account
+var accountRoleStoragePathPrefixes = []string{
+	roleStoragePathPrefix,
+	sosRoleStoragePathPrefix,
+	sksRoleStoragePathPrefix,
+	dbaasRoleStoragePathPrefix,
+	dbaasStaticRoleStoragePathPrefix,
+	instancePasswordRoleStoragePathPrefix,
+	sshKeyRoleStoragePathPrefix,
+	accessRoleStoragePathPrefix,
+}
+
+const (
+	pathListAccountsHelpSyn  = "List the configured Exoscale accounts"
+	pathListAccountsHelpDesc = `
+This endpoint returns a list of the configured Exoscale accounts.
+`
+
+	pathConfigAccountHelpSyn  = "Configure the root API credentials of an additional Exoscale account"
+	pathConfigAccountHelpDesc = `
+Configure the root API credentials of an additional Exoscale organization, a role
+creates its API keys in this organization when its account field is set to the
+name of the account. Roles without an account use the credentials of config/root.
+
+The root API key of an account requires the same permissions as the one of
+config/root, they are verified when the account is written unless verify=false.
+Accounts use the API settings of config/root (operation_timeout, api_max_retries...),
+their root API key can't be rotated by Vault.
+
+An account can't be deleted while roles of any type reference it, or while leases
+of any type issued in it are outstanding, as they are revoked with its credentials.
+Static roles always use config/root.
+
+Example:
+    vault write exoscale/config/account/staging \
+	root_api_key=EXO... \
+	root_api_secret=...
+
+    vault write exoscale/role/ci account=staging iam-role=ci
+`
+)
+
+func (b *exoscaleBackend) pathConfigAccount() []*framework.Path {
+	return []*framework.Path{
+		{
+			Pattern: "config/account/" + framework.GenericNameRegex(configAccountName),
+			Fields: map[string]*framework.FieldSchema{
+				configAccountName: {
+					Type:        framework.TypeString,
+					Description: "Name of the account",
+					Required:    true,
+				},
+				configAPIEnvironment: {
+					Type:        framework.TypeString,
+					Description: "used only by the plugin developers, do not set",
+					Default:     "api",
+				},
+				configRootAPIKey: {
+					Type:         framework.TypeString,
+					Description:  "Exoscale API key (required)",
+					DisplayAttrs: &framework.DisplayAttributes{Sensitive: true},
+				},
+				configRootAPISecret: {
+					Type:         framework.TypeString,
+					Description:  "Exoscale API secret (required, write-only)",
+					DisplayAttrs: &framework.DisplayAttributes{Sensitive: true},
+				},
+				configAPIKeyNamePrefix: {
+					Type:        framework.TypeString,
+					Description: "Adds a prefix to the token name, just after 'vault' (optional)",
+					Default:     "",
+				},
+				configZone: {
+					Type:        framework.TypeString,
+					Description: "Exoscale API zone (optional, default: ch-gva-2)",
+					Default:     "ch-gva-2",
+				},
+				configVerify: {
+					Type: framework.TypeBool,
+					Description: `Verify the root credentials and their IAM permissions before saving the configuration
+				(optional, default: true)`,
+					Default: true,
+				},
+			},
+
+			Operations: map[logical.Operation]framework.OperationHandler{
+				logical.CreateOperation: &framework.PathOperation{Callback: b.pathConfigAccountWrite},
+				logical.UpdateOperation: &framework.PathOperation{Callback: b.pathConfigAccountWrite},
+				logical.ReadOperation:   &framework.PathOperation{Callback: b.pathConfigAccountRead},
+				logical.DeleteOperation: &framework.PathOperation{Callback: b.pathConfigAccountDelete},
+			},
+
+			HelpSynopsis:    pathConfigAccountHelpSyn,
+			HelpDescription: pathConfigAccountHelpDesc,
+		},
+		{
+			Pattern: "config/account/?$",
+
+			Operations: map[logical.Operation]framework.OperationHandler{
+				logical.ListOperation: &framework.PathOperation{Callback: b.pathConfigAccountList},
+			},
+
+			HelpSynopsis:    pathListAccountsHelpSyn,
+			HelpDescription: pathListAccountsHelpDesc,
+		},
+	}
+}
+
+func getAccountConfig(ctx context.Context, storage logical.Storage, name string) (*ExoscaleConfig, error) {
+	if name == "" {
+		return nil, errors.New("invalid account name")
+	}
+
+	entry, err := storage.Get(ctx, configAccountStoragePathPrefix+name)
+	if err != nil {
+		return nil, fmt.Errorf("unable to retrieve account %q: %w", name, err)
+	}
+	if entry == nil {
+		return nil, nil
+	}
+
+	var config ExoscaleConfig
+	if err := entry.DecodeJSON(&config); err != nil {
+		return nil, fmt.Errorf("failed to decode account %q: %w", name, err)
+	}
+
+	return &config, nil
+}
+
+// exoscale returns the client of an account, or the client of config/root if account is empty.
+// Account clients are cached until the account configuration changes.
+func (b *exoscaleBackend) exoscale(ctx context.Context, storage logical.Storage, account string) (*Exoscale, error) {
+	if account == "" {
+		return b.exo, nil
+	}
+
+	b.accountsLock.RLock()
+	exo, ok := b.accounts[account]
+	b.accountsLock.RUnlock()
+	if ok {
+		return exo, nil
+	}
+
+	config, err := getAccountConfig(ctx, storage, account)
+	if err != nil {
+		return nil, err
+	}
+	if config == nil {
+		return nil, fmt.Errorf("account %q is not configured", account)
+	}
+
+	rootConfig, err := getRootConfig(ctx, storage)
+	if err != nil {
+		return nil, err
+	}
+	if rootConfig != nil {
+		config.inheritAPISettings(rootConfig)
+	}
+
+	exo = &Exoscale{}
+	if err := exo.LoadConfig(*config); err != nil {
+		return nil, err
+	}
+
+	b.accountsLock.Lock()
+	defer b.accountsLock.Unlock()
+	if cached, ok := b.accounts[account]; ok {
+		return cached, nil
+	}
+	b.accounts[account] = exo
+
+	return exo, nil
+}
+
+// accountConfigured reports whether the credentials of an account, of config/root if empty,
+// are configured, periodic tasks skip the resources of accounts which aren't
+func (b *exoscaleBackend) accountConfigured(ctx context.Context, storage logical.Storage, account string) (bool, error) {
+	if account == "" {
+		return b.exo.isConfigured(), nil
+	}
+
+	config, err := getAccountConfig(ctx, storage, account)
+	if err != nil {
+		return false, err
+	}
+
+	return config != nil, nil
+}
+
+// invalidateAccount discards the cached client of an account
+func (b *exoscaleBackend) invalidateAccount(account string) {
+	b.accountsLock.Lock()
+	defer b.accountsLock.Unlock()
+
+	delete(b.accounts, account)
+}
+
+// invalidateAccounts discards the cached clients of all the accounts
+func (b *exoscaleBackend) invalidateAccounts() {
+	b.accountsLock.Lock()
+	defer b.accountsLock.Unlock()
+
+	b.accounts = make(map[string]*Exoscale)
+}
+
+func (b *exoscaleBackend) pathConfigAccountList(
+	ctx context.Context,
+	req *logical.Request,
+	_ *framework.FieldData,
+) (*logical.Response, error) {
+	accounts, err := req.Storage.List(ctx, configAccountStoragePathPrefix)
+	if err != nil {
+		return nil, err
+	}
+
+	return logical.ListResponse(accounts), nil
+}
+
+func (b *exoscaleBackend) pathConfigAccountRead(
+	ctx context.Context,
+	req *logical.Request,
+	data *framework.FieldData,
+) (*logical.Response, error) {
+	config, err := getAccountConfig(ctx, req.Storage, data.Get(configAccountName).(string))
+	if err != nil {
+		return nil, err
+	}
+	if config == nil {
+		return nil, nil
+	}
+
+	res := &logical.Response{Data: config.responseData()}
+	delete(res.Data, configRootRotationPeriod)
+
+	return res, nil
+}
+
+func (b *exoscaleBackend) pathConfigAccountWrite(
+	ctx context.Context,
+	req *logical.Request,
+	data *framework.FieldData,
+) (*logical.Response, error) {
+	name := data.Get(configAccountName).(string)
+
+	config := ExoscaleConfig{
+		APIEnvironment:   data.Get(configAPIEnvironment).(string),
+		Zone:             data.Get(configZone).(string),
+		APIKeyNamePrefix: data.Get(configAPIKeyNamePrefix).(string),
+		RootAPIKey:       data.Get(configRootAPIKey).(string),
+		RootAPISecret:    data.Get(configRootAPISecret).(string),
+	}
+
+	if config.RootAPIKey == "" || config.RootAPISecret == "" {
+		return nil, errMissingAPICredentials
+	}
+
+	res := &logical.Response{}
+
+	if data.Get(configVerify).(bool) {
+		warnings, err := verifyRootConfig(ctx, config)
+		if err != nil {
+			return nil, err
+		}
+		for _, w := range warnings {
+			res.AddWarning(w)
+		}
+	}
+
+	entry, err := logical.StorageEntryJSON(configAccountStoragePathPrefix+name, config)
+	if err != nil {
+		return nil, err
+	}
+	if err := req.Storage.Put(ctx, entry); err != nil {
+		return nil, err
+	}
+	b.invalidateAccount(name)
+
+	res.Data = config.responseData()
+	delete(res.Data, configRootRotationPeriod)
+
+	return res, nil
+}
+
+func (b *exoscaleBackend) pathConfigAccountDelete(
+	ctx context.Context,
+	req *logical.Request,
+	data *framework.FieldData,
+) (*logical.Response, error) {
+	name := data.Get(configAccountName).(string)
+
+	b.rolesLock.Lock()
+	defer b.rolesLock.Unlock()
+
+	for _, prefix := range accountRoleStoragePathPrefixes {
+		roles, err := req.Storage.List(ctx, prefix)
+		if err != nil {
+			return nil, err
+		}
+		for _, roleName := range roles {
+			entry, err := req.Storage.Get(ctx, prefix+roleName)
+			if err != nil {
+				return nil, err
+			}
+			if entry == nil {
+				continue
+			}
+			var role struct {
+				Account string `json:"account"`
+			}
+			if err := entry.DecodeJSON(&role); err != nil {
+				return nil, err
+			}
+			if role.Account == name {
+				return logical.ErrorResponse("account %q is used by role %q", name, prefix+roleName), nil
+			}
+		}
+	}
+
+	// outstanding leases are revoked with the credentials of their account
+	leases, err := req.Storage.List(ctx, accountLeaseStoragePathPrefix+name+"/")
+	if err != nil {
+		return nil, err
+	}
+	if len(leases) > 0 {
+		return logical.ErrorResponse("account %q is used by the lease of %s", name, leases[0]), nil
+	}
+
+	keys, err := req.Storage.List(ctx, issuedKeyStoragePathPrefix)
+	if err != nil {
+		return nil, err
+	}
+	for _, key := range keys {
+		issued, err := getIssuedKey(ctx, req.Storage, key)
+		if err != nil {
+			return nil, err
+		}
+		if issued != nil && issued.Account == name {
+			return logical.ErrorResponse("account %q is used by the lease of API key %q", name, key), nil
+		}
+	}
+
+	if err := req.Storage.Delete(ctx, configAccountStoragePathPrefix+name); err != nil {
+		return nil, err
+	}
+	b.invalidateAccount(name)
+
+	return nil, nil
+}
+
+// accountLease is the record of a lease of an account other than an API key lease
+type accountLease struct {
+	SecretType string    `json:"secret_type"`
+	ID         string    `json:"id"`
+	IssuedAt   time.Time `json:"issued_at"`
+}
+
+func accountLeaseStoragePath(account, secretType, id string) string {
+	return accountLeaseStoragePathPrefix + account + "/" + secretType + "-" + id
+}
+
+// putAccountLease records a lease of an account until it is revoked, so that the account isn't
+// deleted while the lease needs its credentials. Leases of config/root aren't recorded.
+func putAccountLease(ctx context.Context, storage logical.Storage, account, secretType, id string) error {
+	if account == "" {
+		return nil
+	}
+
+	entry, err := logical.StorageEntryJSON(accountLeaseStoragePath(account, secretType, id), accountLease{
+		SecretType: secretType,
+		ID:         id,
+		IssuedAt:   time.Now(),
+	})
+	if err != nil {
+		return err
+	}
+	if err := storage.Put(ctx, entry); err != nil {
+		return fmt.Errorf("unable to record lease %q of account %q: %w", id, account, err)
+	}
+
+	return nil
+}
+
+// deleteAccountLease deletes the record of a lease of an account once it is revoked
+func deleteAccountLease(ctx context.Context, storage logical.Storage, account, secretType, id string) error {
+	if account == "" {
+		return nil
+	}
+
+	if err := storage.Delete(ctx, accountLeaseStoragePath(account, secretType, id)); err != nil {
+		return fmt.Errorf("unable to delete record of lease %q of account %q: %w", id, account, err)
+	}
+
+	return nil
+}
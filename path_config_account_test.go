@@ -0,0 +1,351 @@
+package exoscale
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/vault/sdk/logical"
+	"github.com/stretchr/testify/mock"
+
+	"github.com/exoscale/egoscale/v2/oapi"
+)
+
+const testAccountName = "staging"
+
+// mockAccountClients returns a distinct mock client for each root API key,
+// clients can be set up beforehand by adding them to the returned map
+func (ts *testSuite) mockAccountClients() map[string]*mockEgoscaleClient {
+	rootClient := ts.backend.(*exoscaleBackend).exo.egoscaleClient.(*mockEgoscaleClient)
+	clients := map[string]*mockEgoscaleClient{"EXO0000": rootClient}
+
+	newEgoscaleClient = func(cfg ExoscaleConfig) (egoscaleClient, error) {
+		if _, ok := clients[cfg.RootAPIKey]; !ok {
+			clients[cfg.RootAPIKey] = new(mockEgoscaleClient)
+		}
+		return clients[cfg.RootAPIKey], nil
+	}
+
+	return clients
+}
+
+func (ts *testSuite) writeTestAccount(rootAPIKey string) {
+	_, err := ts.backend.HandleRequest(context.Background(), &logical.Request{
+		Storage:   ts.storage,
+		Operation: logical.CreateOperation,
+		Path:      configAccountStoragePathPrefix + testAccountName,
+		Data: map[string]interface{}{
+			configRootAPIKey:    rootAPIKey,
+			configRootAPISecret: "staging-secret",
+			configVerify:        false,
+		},
+	})
+	ts.Require().NoError(err)
+}
+
+func (ts *testSuite) TestPathConfigAccount() {
+	ts.writeTestAccount("EXOstaging")
+
+	res, err := ts.backend.HandleRequest(context.Background(), &logical.Request{
+		Storage:   ts.storage,
+		Operation: logical.ListOperation,
+		Path:      configAccountStoragePathPrefix,
+	})
+	ts.Require().NoError(err)
+	ts.Require().Equal([]string{testAccountName}, res.Data["keys"])
+
+	res, err = ts.backend.HandleRequest(context.Background(), &logical.Request{
+		Storage:   ts.storage,
+		Operation: logical.ReadOperation,
+		Path:      configAccountStoragePathPrefix + testAccountName,
+	})
+	ts.Require().NoError(err)
+	ts.Require().Equal("EXOstaging", res.Data[configRootAPIKey])
+	ts.Require().NotContains(res.Data, configRootAPISecret)
+	ts.Require().NotContains(res.Data, configRootRotationPeriod)
+
+	_, err = ts.backend.HandleRequest(context.Background(), &logical.Request{
+		Storage:   ts.storage,
+		Operation: logical.DeleteOperation,
+		Path:      configAccountStoragePathPrefix + testAccountName,
+	})
+	ts.Require().NoError(err)
+
+	config, err := getAccountConfig(context.Background(), ts.storage, testAccountName)
+	ts.Require().NoError(err)
+	ts.Require().Nil(config)
+}
+
+func (ts *testSuite) TestPathV3APIKeyAccount() {
+	clients := ts.mockAccountClients()
+	clients["EXOstaging"] = new(mockEgoscaleClient)
+	ts.writeTestAccount("EXOstaging")
+
+	iamRoleID := ts.randomID()
+	ts.storeEntry(roleStoragePathPrefix+testRoleName, Role{
+		IAMRoleID:   iamRoleID,
+		IAMRoleName: "ci",
+		Version:     "v3",
+		Renewable:   true,
+		Account:     testAccountName,
+	})
+
+	state := oapi.OperationStateSuccess
+	clients["EXOstaging"].
+		On("CreateApiKeyWithResponse", mock.Anything, mock.Anything).
+		Return(&oapi.CreateApiKeyResponse{
+			JSON200: &oapi.IamApiKeyCreated{
+				Key:    &testIAMAccessKeyKey,
+				Name:   ptr("vault-" + testRoleName),
+				RoleId: &iamRoleID,
+				Secret: &testIAMAccessKeySecret,
+			},
+		}, nil)
+	clients["EXOstaging"].
+		On("DeleteApiKeyWithResponse", mock.Anything, testIAMAccessKeyKey).
+		Return(&oapi.DeleteApiKeyResponse{JSON200: &oapi.Operation{State: &state}}, nil)
+
+	res, err := ts.backend.HandleRequest(context.Background(), &logical.Request{
+		Storage:     ts.storage,
+		Operation:   logical.ReadOperation,
+		Path:        "apikey/" + testRoleName,
+		DisplayName: "test",
+	})
+	ts.Require().NoError(err)
+	ts.Require().Equal(testAccountName, res.Secret.InternalData["account"])
+
+	_, err = ts.backend.HandleRequest(context.Background(), &logical.Request{
+		Storage:   ts.storage,
+		Operation: logical.RevokeOperation,
+		Path:      res.Secret.LeaseID,
+		Secret:    res.Secret,
+	})
+	ts.Require().NoError(err)
+
+	clients["EXOstaging"].AssertExpectations(ts.T())
+	clients["EXO0000"].AssertNotCalled(ts.T(), "CreateApiKeyWithResponse", mock.Anything, mock.Anything)
+
+	// the account client is reloaded once the account configuration changes
+	ts.writeTestAccount("EXOstaging2")
+	clients["EXOstaging2"] = new(mockEgoscaleClient)
+	clients["EXOstaging2"].
+		On("CreateApiKeyWithResponse", mock.Anything, mock.Anything).
+		Return(&oapi.CreateApiKeyResponse{
+			JSON200: &oapi.IamApiKeyCreated{
+				Key:    &testIAMAccessKeyKey,
+				Name:   ptr("vault-" + testRoleName),
+				RoleId: &iamRoleID,
+				Secret: &testIAMAccessKeySecret,
+			},
+		}, nil)
+
+	_, err = ts.backend.HandleRequest(context.Background(), &logical.Request{
+		Storage:     ts.storage,
+		Operation:   logical.ReadOperation,
+		Path:        "apikey/" + testRoleName,
+		DisplayName: "test",
+	})
+	ts.Require().NoError(err)
+	clients["EXOstaging2"].AssertExpectations(ts.T())
+
+	// the account is still used by the role
+	res, err = ts.backend.HandleRequest(context.Background(), &logical.Request{
+		Storage:   ts.storage,
+		Operation: logical.DeleteOperation,
+		Path:      configAccountStoragePathPrefix + testAccountName,
+	})
+	ts.Require().NoError(err)
+	ts.Require().True(res.IsError())
+}
+
+func (ts *testSuite) TestPathConfigAccountDeleteInUse() {
+	ts.writeTestAccount("EXOstaging")
+
+	deleteAccount := func() *logical.Response {
+		res, err := ts.backend.HandleRequest(context.Background(), &logical.Request{
+			Storage:   ts.storage,
+			Operation: logical.DeleteOperation,
+			Path:      configAccountStoragePathPrefix + testAccountName,
+		})
+		ts.Require().NoError(err)
+		return res
+	}
+
+	ts.storeEntry(sshKeyRoleStoragePathPrefix+"deploy", SSHKeyRole{Account: testAccountName})
+	res := deleteAccount()
+	ts.Require().EqualError(res.Error(), `account "staging" is used by role "ssh-key-role/deploy"`)
+
+	ts.Require().NoError(ts.storage.Delete(context.Background(), sshKeyRoleStoragePathPrefix+"deploy"))
+	ts.Require().NoError(putIssuedKey(context.Background(), ts.storage, &issuedKey{
+		Key:     testIAMAccessKeyKey,
+		Version: "v3",
+		Account: testAccountName,
+	}))
+	res = deleteAccount()
+	ts.Require().EqualError(res.Error(),
+		fmt.Sprintf(`account "staging" is used by the lease of API key %q`, testIAMAccessKeyKey))
+
+	ts.Require().NoError(ts.storage.Delete(context.Background(), issuedKeyStoragePathPrefix+testIAMAccessKeyKey))
+	ts.Require().Nil(deleteAccount())
+}
+
+func (ts *testSuite) TestPathRoleWriteUnknownAccount() {
+	res, err := ts.backend.HandleRequest(context.Background(), &logical.Request{
+		Storage:   ts.storage,
+		Operation: logical.CreateOperation,
+		Path:      roleStoragePathPrefix + testRoleName,
+		Data: map[string]interface{}{
+			configIAMRole:     ts.randomID(),
+			configRoleAccount: "unknown",
+		},
+	})
+	ts.Require().NoError(err)
+	ts.Require().EqualError(res.Error(), `account "unknown" is not configured`)
+}
+
+func (ts *testSuite) TestPathConfigAccountDeleteLeases() {
+	clients := ts.mockAccountClients()
+	clients["EXOstaging"] = new(mockEgoscaleClient)
+	ts.writeTestAccount("EXOstaging")
+
+	sgID := ts.randomID()
+	var username oapi.DbaasUserUsername
+	var rule oapi.AddRuleToSecurityGroupJSONRequestBody
+	ruleID := ts.randomID()
+	state := oapi.OperationStateSuccess
+	op := &oapi.Operation{State: &state}
+	mockClient := clients["EXOstaging"]
+	mockClient.
+		On("RegisterSshKeyWithResponse", mock.Anything, mock.Anything).
+		Return(&oapi.RegisterSshKeyResponse{JSON200: op}, nil)
+	mockClient.
+		On("DeleteSshKeyWithResponse", mock.Anything, mock.Anything).
+		Return(&oapi.DeleteSshKeyResponse{JSON200: op}, nil)
+	mockClient.
+		On("AddRuleToSecurityGroupWithResponse", mock.Anything, sgID, mock.Anything).
+		Run(func(args mock.Arguments) { rule = args.Get(2).(oapi.AddRuleToSecurityGroupJSONRequestBody) }).
+		Return(&oapi.AddRuleToSecurityGroupResponse{JSON200: op}, nil)
+	mockClient.
+		On("GetSecurityGroupWithResponse", mock.Anything, sgID).
+		Return(func(context.Context, string, ...oapi.RequestEditorFn) (*oapi.GetSecurityGroupResponse, error) {
+			protocol := oapi.SecurityGroupRuleProtocol(rule.Protocol)
+			return &oapi.GetSecurityGroupResponse{JSON200: &oapi.SecurityGroup{Rules: &[]oapi.SecurityGroupRule{{
+				Id:          &ruleID,
+				Description: rule.Description,
+				Network:     rule.Network,
+				Protocol:    &protocol,
+				StartPort:   rule.StartPort,
+				EndPort:     rule.EndPort,
+			}}}}, nil
+		})
+	mockClient.
+		On("DeleteRuleFromSecurityGroupWithResponse", mock.Anything, sgID, ruleID).
+		Return(&oapi.DeleteRuleFromSecurityGroupResponse{JSON200: op}, nil)
+	mockClient.
+		On("CreateDbaasPostgresUserWithResponse", mock.Anything, oapi.DbaasServiceName("app-db"), mock.Anything).
+		Run(func(args mock.Arguments) {
+			username = args.Get(2).(oapi.CreateDbaasPostgresUserJSONRequestBody).Username
+		}).
+		Return(&oapi.CreateDbaasPostgresUserResponse{JSON200: op}, nil)
+	mockClient.
+		On("GetDbaasServicePgWithResponse", mock.Anything, oapi.DbaasServiceName("app-db")).
+		Return(func(context.Context, oapi.DbaasServiceName, ...oapi.RequestEditorFn) (*oapi.GetDbaasServicePgResponse, error) {
+			service := &oapi.DbaasServicePg{}
+			service.Users = &[]struct {
+				AllowReplication *bool   `json:"allow-replication,omitempty"`
+				Password         *string `json:"password,omitempty"`
+				Type             string  `json:"type"`
+				Username         string  `json:"username"`
+			}{{Username: string(username), Password: ptr("userpass"), Type: "normal"}}
+			return &oapi.GetDbaasServicePgResponse{JSON200: service}, nil
+		})
+	mockClient.
+		On("DeleteDbaasPostgresUserWithResponse", mock.Anything, oapi.DbaasServiceName("app-db"), mock.Anything).
+		Return(&oapi.DeleteDbaasPostgresUserResponse{JSON200: op}, nil)
+
+	tests := []struct {
+		name       string
+		rolePath   string
+		role       interface{}
+		operation  logical.Operation
+		path       string
+		data       map[string]interface{}
+		secretType string
+	}{
+		{
+			name:       "ssh key",
+			rolePath:   sshKeyRoleStoragePathPrefix + "deploy",
+			role:       SSHKeyRole{Account: testAccountName},
+			operation:  logical.ReadOperation,
+			path:       "ssh-key/deploy",
+			secretType: SecretTypeSSHKey,
+		},
+		{
+			name:     "access",
+			rolePath: accessRoleStoragePathPrefix + "bastion",
+			role: AccessRole{
+				SecurityGroupID: sgID,
+				Ports:           []portRange{{Start: 22, End: 22}},
+				Protocols:       []string{"tcp"},
+				MaxCIDRSize:     8,
+				Account:         testAccountName,
+			},
+			operation:  logical.UpdateOperation,
+			path:       "access/bastion",
+			data:       map[string]interface{}{configAccessCIDR: "203.0.113.7"},
+			secretType: SecretTypeAccess,
+		},
+		{
+			name:       "dbaas user",
+			rolePath:   dbaasRoleStoragePathPrefix + "app",
+			role:       DBaaSRole{ServiceType: dbaasTypePg, Service: "app-db", Account: testAccountName},
+			operation:  logical.ReadOperation,
+			path:       "dbaas-creds/app",
+			secretType: SecretTypeDBaaSUser,
+		},
+	}
+
+	for _, tt := range tests {
+		ts.Run(tt.name, func() {
+			ts.storeEntry(tt.rolePath, tt.role)
+			secret, err := ts.backend.HandleRequest(context.Background(), &logical.Request{
+				Storage:   ts.storage,
+				Operation: tt.operation,
+				Path:      tt.path,
+				Data:      tt.data,
+			})
+			ts.Require().NoError(err)
+			ts.Require().False(secret.IsError())
+
+			// the role is deleted, the lease still requires the account
+			ts.Require().NoError(ts.storage.Delete(context.Background(), tt.rolePath))
+
+			res, err := ts.backend.HandleRequest(context.Background(), &logical.Request{
+				Storage:   ts.storage,
+				Operation: logical.DeleteOperation,
+				Path:      configAccountStoragePathPrefix + testAccountName,
+			})
+			ts.Require().NoError(err)
+			ts.Require().ErrorContains(res.Error(), `account "staging" is used by the lease of `+tt.secretType+"-")
+
+			_, err = ts.backend.HandleRequest(context.Background(), &logical.Request{
+				Storage:   ts.storage,
+				Operation: logical.RevokeOperation,
+				Path:      secret.Secret.LeaseID,
+				Secret:    secret.Secret,
+			})
+			ts.Require().NoError(err)
+
+			leases, err := ts.storage.List(context.Background(), accountLeaseStoragePathPrefix+testAccountName+"/")
+			ts.Require().NoError(err)
+			ts.Require().Empty(leases)
+		})
+	}
+
+	res, err := ts.backend.HandleRequest(context.Background(), &logical.Request{
+		Storage:   ts.storage,
+		Operation: logical.DeleteOperation,
+		Path:      configAccountStoragePathPrefix + testAccountName,
+	})
+	ts.Require().NoError(err)
+	ts.Require().Nil(res)
+}
@@ -3,6 +3,9 @@ package exoscale
 import (
 	"context"
 	"errors"
+	"fmt"
+	"strings"
+	"time"
 
 	"github.com/hashicorp/vault/sdk/framework"
 	"github.com/hashicorp/vault/sdk/logical"
@@ -11,11 +14,22 @@ import (
 const (
 	configRootStoragePath = "config/root"
 
-	configAPIEnvironment   = "api_environment"
-	configRootAPIKey       = "root_api_key"
-	configRootAPISecret    = "root_api_secret"
-	configZone             = "zone"
-	configAPIKeyNamePrefix = "api_key_name_prefix"
+	configAPIEnvironment     = "api_environment"
+	configRootAPIKey         = "root_api_key"
+	configRootAPISecret      = "root_api_secret"
+	configZone               = "zone"
+	configAPIKeyNamePrefix   = "api_key_name_prefix"
+	configRootRotationPeriod = "root_rotation_period"
+	configVerify             = "verify"
+
+	configOperationTimeout      = "operation_timeout"
+	configOperationPollInterval = "operation_poll_interval"
+
+	configAPIMaxRetries   = "api_max_retries"
+	configAPIRetryWaitMax = "api_retry_wait_max"
+	configAPIRateLimit    = "api_rate_limit"
+
+	configQuotaWarningThreshold = "quota_warning_threshold"
 )
 
 var (
@@ -32,6 +46,9 @@ IAM API Keys (recommended)
 The root API Key must have the permissions to perform the following IAM operations:
 create-api-key, delete-api-key, get-api-key, list-api-keys, list-iam-roles, get-iam-role"
 
+These permissions are verified when the configuration is written, verification can
+be skipped with verify=false (e.g. for legacy IAM Access Keys).
+
 This can be achieved with the following role policy :
 
 {
@@ -52,6 +69,35 @@ This can be achieved with the following role policy :
 It is possible to restrict the creation of keys to a predefined list of roles by using the
 parameters.role_id variable in the CEL expression, please refer to the IAM documentation for more information.
 
+Roles defined with an inline IAM policy additionally require the create-iam-role and
+delete-iam-role operations, since a dedicated IAM role is created for each API key.
+
+Reading this endpoint returns the root API key along with its name and IAM role as
+resolved by the API, the root API secret is write-only and never returned.
+
+Writing this endpoint only updates the fields which are set, the others keep their
+current value. root_api_secret can be left out as long as root_api_key doesn't change,
+e.g. to update the settings after the root API key has been rotated.
+
+The root API Key can be rotated with the config/rotate-root endpoint, or automatically
+by setting root_rotation_period, in which case the first rotation happens one period
+after the configuration is written. Only IAM API Keys (v3) can be rotated.
+
+API calls failing with a connection error, a 429 or a 5xx status code are retried up to
+api_max_retries times with an exponential backoff honoring the Retry-After header, the
+delay between two attempts is bounded by api_retry_wait_max. Calls which may have been
+processed, such as creations, are only retried on a 429 or when the connection to the
+API couldn't be established. The rate of API calls can be
+limited with api_rate_limit (requests per second, shared by all operations).
+
+When an API key can't be created, the IAM API key quota of the organization is checked
+to report whether it is exhausted. With quota_warning_threshold set, issuing an API key
+returns a warning once the quota usage reaches this percentage. Both require the
+root API key to be allowed to get quotas, see also the quota endpoint.
+
+API key names
+=============
+` + nameTemplateHelp + `
 Legacy IAM Access Keys (deprecated)
 ===================================
 With legacy IAM the Access Keys that are created must have a subset of the permissions of the
@@ -66,6 +112,88 @@ type ExoscaleConfig struct {
 	RootAPISecret    string `json:"root_api_secret"`
 	Zone             string `json:"zone"`
 	APIKeyNamePrefix string `json:"api_key_name_prefix"`
+
+	RootRotationPeriod time.Duration `json:"root_rotation_period,omitempty"`
+	RootLastRotated    time.Time     `json:"root_last_rotated,omitempty"`
+
+	OperationTimeout      time.Duration `json:"operation_timeout,omitempty"`
+	OperationPollInterval time.Duration `json:"operation_poll_interval,omitempty"`
+
+	APIMaxRetries   *int          `json:"api_max_retries,omitempty"`
+	APIRetryWaitMax time.Duration `json:"api_retry_wait_max,omitempty"`
+	APIRateLimit    int           `json:"api_rate_limit,omitempty"`
+
+	QuotaWarningThreshold int `json:"quota_warning_threshold,omitempty"`
+
+	NameTemplate string `json:"name_template,omitempty"`
+}
+
+// responseData returns the config as exposed by the API, the root API secret is write-only
+func (c *ExoscaleConfig) responseData() map[string]interface{} {
+	data := map[string]interface{}{
+		configAPIEnvironment:     c.APIEnvironment,
+		configRootAPIKey:         c.RootAPIKey,
+		configZone:               c.Zone,
+		configAPIKeyNamePrefix:   c.APIKeyNamePrefix,
+		configRootRotationPeriod: int64(c.RootRotationPeriod.Seconds()),
+	}
+	if c.OperationTimeout != 0 {
+		data[configOperationTimeout] = int64(c.OperationTimeout.Seconds())
+	}
+	if c.OperationPollInterval != 0 {
+		data[configOperationPollInterval] = int64(c.OperationPollInterval.Seconds())
+	}
+	if c.APIMaxRetries != nil {
+		data[configAPIMaxRetries] = *c.APIMaxRetries
+	}
+	if c.APIRetryWaitMax != 0 {
+		data[configAPIRetryWaitMax] = int64(c.APIRetryWaitMax.Seconds())
+	}
+	if c.APIRateLimit != 0 {
+		data[configAPIRateLimit] = c.APIRateLimit
+	}
+	if c.QuotaWarningThreshold != 0 {
+		data[configQuotaWarningThreshold] = c.QuotaWarningThreshold
+	}
+	if c.NameTemplate != "" {
+		data[configNameTemplate] = c.NameTemplate
+	}
+	if !c.RootLastRotated.IsZero() {
+		data["root_last_rotated"] = c.RootLastRotated.Format(time.RFC3339)
+	}
+
+	return data
+}
+
+// inheritAPISettings copies the API settings and the name template of the root configuration
+// to an account configuration
+func (c *ExoscaleConfig) inheritAPISettings(root *ExoscaleConfig) {
+	c.OperationTimeout = root.OperationTimeout
+	c.OperationPollInterval = root.OperationPollInterval
+	c.APIMaxRetries = root.APIMaxRetries
+	c.APIRetryWaitMax = root.APIRetryWaitMax
+	c.APIRateLimit = root.APIRateLimit
+	c.QuotaWarningThreshold = root.QuotaWarningThreshold
+	c.NameTemplate = root.NameTemplate
+}
+
+func getRootConfig(ctx context.Context, storage logical.Storage) (*ExoscaleConfig, error) {
+	var config ExoscaleConfig
+
+	entry, err := storage.Get(ctx, configRootStoragePath)
+	if err != nil {
+		return nil, fmt.Errorf("unable to retrieve backend config from storage: %w", err)
+	}
+
+	if entry == nil {
+		return nil, nil
+	}
+
+	if err := entry.DecodeJSON(&config); err != nil {
+		return nil, fmt.Errorf("failed to decode backend config %w", err)
+	}
+
+	return &config, nil
 }
 
 func (b *exoscaleBackend) pathConfigRoot() *framework.Path {
@@ -84,7 +212,7 @@ func (b *exoscaleBackend) pathConfigRoot() *framework.Path {
 			},
 			configRootAPISecret: {
 				Type:         framework.TypeString,
-				Description:  "Exoscale API secret (required)",
+				Description:  "Exoscale API secret (required when root_api_key changes, write-only)",
 				DisplayAttrs: &framework.DisplayAttributes{Sensitive: true},
 			},
 			configAPIKeyNamePrefix: {
@@ -100,6 +228,52 @@ func (b *exoscaleBackend) pathConfigRoot() *framework.Path {
 note: API Keys are global, this only changes the zone used to perform API calls`,
 				Default: "ch-gva-2",
 			},
+			configRootRotationPeriod: {
+				Type: framework.TypeDurationSecond,
+				Description: `Period after which the root API key is automatically rotated, starting from
+				the write of the configuration (optional, default: 0, automatic rotation disabled)`,
+			},
+			configOperationTimeout: {
+				Type:        framework.TypeDurationSecond,
+				Description: "Maximum time to wait for asynchronous API operations to complete (optional, default: 2m)",
+				Default:     int(defaultOperationTimeout.Seconds()),
+			},
+			configOperationPollInterval: {
+				Type: framework.TypeDurationSecond,
+				Description: `Initial interval between two polls of an asynchronous API operation, doubled after
+				each poll up to 10s (optional, default: 1s)`,
+				Default: int(defaultOperationPollInterval.Seconds()),
+			},
+			configAPIMaxRetries: {
+				Type:        framework.TypeInt,
+				Description: "Maximum number of retries of a failed API call, 0 disables retries (optional, default: 4)",
+				Default:     defaultAPIMaxRetries,
+			},
+			configAPIRetryWaitMax: {
+				Type: framework.TypeDurationSecond,
+				Description: `Maximum time to wait between two attempts of an API call, including the delay
+				requested by the Retry-After header (optional, default: 30s)`,
+				Default: int(defaultAPIRetryWaitMax.Seconds()),
+			},
+			configAPIRateLimit: {
+				Type:        framework.TypeInt,
+				Description: "Maximum number of API calls per second (optional, default: 0, unlimited)",
+			},
+			configQuotaWarningThreshold: {
+				Type: framework.TypeInt,
+				Description: `Usage of the IAM API key quota of the organization, in percent, above which issuing
+				an API key returns a warning (optional, default: 0, disabled)`,
+			},
+			configNameTemplate: {
+				Type:        framework.TypeString,
+				Description: "Go template generating the names of the API keys (optional, see the help of this endpoint)",
+			},
+			configVerify: {
+				Type: framework.TypeBool,
+				Description: `Verify the root credentials and their IAM permissions before saving the configuration
+				(optional, default: true)`,
+				Default: true,
+			},
 		},
 
 		Operations: map[logical.Operation]framework.OperationHandler{
@@ -118,19 +292,34 @@ func (b *exoscaleBackend) pathConfigRootRead(
 	req *logical.Request,
 	_ *framework.FieldData,
 ) (*logical.Response, error) {
-	if !b.exo.configured {
+	config, err := getRootConfig(ctx, req.Storage)
+	if err != nil {
+		return nil, err
+	}
+	if config == nil || !b.exo.isConfigured() {
 		return nil, ErrorBackendNotConfigured
 	}
 
-	return &logical.Response{
-		Data: map[string]interface{}{
-			configAPIEnvironment:   b.exo.reqEndpoint.Env(),
-			configRootAPIKey:       b.exo.apiKey,
-			configRootAPISecret:    b.exo.apiSecret,
-			configZone:             b.exo.reqEndpoint.Zone(),
-			configAPIKeyNamePrefix: b.exo.apiKeyNamePrefix,
-		},
-	}, nil
+	res := &logical.Response{Data: config.responseData()}
+	res.Data["root_api_key_valid"] = false
+
+	key, err := b.exo.V3GetAPIKey(ctx, config.RootAPIKey)
+	if err != nil {
+		res.AddWarning(fmt.Sprintf("unable to validate the root API key: %s", err))
+		return res, nil
+	}
+	res.Data["root_api_key_valid"] = true
+	res.Data["root_api_key_name"] = *key.Name
+	res.Data["root_iam_role_id"] = *key.RoleId
+
+	iamrole, err := b.exo.V3GetRole(ctx, *key.RoleId)
+	if err != nil {
+		res.AddWarning(fmt.Sprintf("unable to retrieve the IAM role of the root API key: %s", err))
+		return res, nil
+	}
+	res.Data["root_iam_role_name"] = *iamrole.Name
+
+	return res, nil
 }
 
 func (b *exoscaleBackend) pathConfigRootWrite(
@@ -138,17 +327,120 @@ func (b *exoscaleBackend) pathConfigRootWrite(
 	req *logical.Request,
 	data *framework.FieldData,
 ) (*logical.Response, error) {
-	config := ExoscaleConfig{
-		APIEnvironment:   data.Get(configAPIEnvironment).(string),
-		Zone:             data.Get(configZone).(string),
-		APIKeyNamePrefix: data.Get(configAPIKeyNamePrefix).(string),
-		RootAPIKey:       data.Get(configRootAPIKey).(string),
-		RootAPISecret:    data.Get(configRootAPISecret).(string),
+	b.rootConfigLock.Lock()
+	defer b.rootConfigLock.Unlock()
+
+	stored, err := getRootConfig(ctx, req.Storage)
+	if err != nil {
+		return nil, err
+	}
+
+	var config ExoscaleConfig
+	if stored != nil {
+		config = *stored
+	}
+
+	// fields left out of the request keep their stored value, or get their default on creation
+	fieldValue := func(key string) (interface{}, bool) {
+		if v, ok := data.GetOk(key); ok {
+			return v, true
+		}
+		return data.Get(key), stored == nil
+	}
+
+	if v, ok := fieldValue(configAPIEnvironment); ok {
+		config.APIEnvironment = v.(string)
+	}
+	if v, ok := fieldValue(configZone); ok {
+		config.Zone = v.(string)
+	}
+	if v, ok := fieldValue(configAPIKeyNamePrefix); ok {
+		config.APIKeyNamePrefix = v.(string)
 	}
 
+	// the stored secret is kept as long as the key doesn't change, it is write-only
+	// and only known to Vault after a rotation
+	if v, ok := data.GetOk(configRootAPIKey); ok && v.(string) != config.RootAPIKey {
+		config.RootAPIKey = v.(string)
+		config.RootAPISecret = ""
+	}
+	if v, ok := data.GetOk(configRootAPISecret); ok {
+		config.RootAPISecret = v.(string)
+	}
 	if config.RootAPIKey == "" || config.RootAPISecret == "" {
 		return nil, errMissingAPICredentials
 	}
+	if stored == nil || config.RootAPIKey != stored.RootAPIKey || config.RootAPISecret != stored.RootAPISecret {
+		config.RootLastRotated = time.Time{}
+	}
+
+	if v, ok := fieldValue(configRootRotationPeriod); ok {
+		config.RootRotationPeriod = time.Duration(v.(int)) * time.Second
+	}
+	if v, ok := fieldValue(configOperationTimeout); ok {
+		config.OperationTimeout = time.Duration(v.(int)) * time.Second
+	}
+	if v, ok := fieldValue(configOperationPollInterval); ok {
+		config.OperationPollInterval = time.Duration(v.(int)) * time.Second
+	}
+	if v, ok := fieldValue(configAPIMaxRetries); ok {
+		maxRetries := v.(int)
+		config.APIMaxRetries = &maxRetries
+	}
+	if v, ok := fieldValue(configAPIRetryWaitMax); ok {
+		config.APIRetryWaitMax = time.Duration(v.(int)) * time.Second
+	}
+	if v, ok := fieldValue(configAPIRateLimit); ok {
+		config.APIRateLimit = v.(int)
+	}
+	if v, ok := fieldValue(configQuotaWarningThreshold); ok {
+		config.QuotaWarningThreshold = v.(int)
+	}
+	if v, ok := fieldValue(configNameTemplate); ok {
+		config.NameTemplate = v.(string)
+	}
+
+	if config.RootRotationPeriod != 0 && config.RootRotationPeriod < minRootRotationPeriod {
+		return nil, fmt.Errorf("%s must be at least %s", configRootRotationPeriod, minRootRotationPeriod)
+	}
+
+	if config.APIMaxRetries != nil && *config.APIMaxRetries < 0 {
+		return nil, fmt.Errorf("%s must not be negative", configAPIMaxRetries)
+	}
+	if config.APIRateLimit < 0 {
+		return nil, fmt.Errorf("%s must not be negative", configAPIRateLimit)
+	}
+	if config.QuotaWarningThreshold < 0 || config.QuotaWarningThreshold > 100 {
+		return nil, fmt.Errorf("%s must be between 0 and 100", configQuotaWarningThreshold)
+	}
+	if err := validateNameTemplate(config.NameTemplate); err != nil {
+		return nil, err
+	}
+
+	res := &logical.Response{}
+
+	verify := data.Get(configVerify).(bool)
+	if verify {
+		warnings, err := verifyRootConfig(ctx, config)
+		if err != nil {
+			return nil, err
+		}
+		for _, w := range warnings {
+			res.AddWarning(w)
+		}
+	}
+
+	// RootLastRotated is only kept for credentials which are unchanged, and thus already checked
+	if config.RootRotationPeriod != 0 && config.RootLastRotated.IsZero() {
+		// verifyRootConfig already ensures that the root API key is an IAM API Key (v3)
+		if !verify {
+			if err := verifyRootKeyRotatable(ctx, config); err != nil {
+				return nil, err
+			}
+		}
+		// newly written credentials are as fresh as rotated ones
+		config.RootLastRotated = time.Now()
+	}
 
 	entry, err := logical.StorageEntryJSON(configRootStoragePath, config)
 	if err != nil {
@@ -162,17 +454,72 @@ func (b *exoscaleBackend) pathConfigRootWrite(
 	if err := req.Storage.Put(ctx, entry); err != nil {
 		return nil, err
 	}
+	// account clients inherit the API settings
+	b.invalidateAccounts()
 
-	res := &logical.Response{
-		Data: map[string]interface{}{
-			configAPIEnvironment:   b.exo.reqEndpoint.Env(),
-			configRootAPIKey:       b.exo.apiKey,
-			configRootAPISecret:    b.exo.apiSecret,
-			configZone:             b.exo.reqEndpoint.Zone(),
-			configAPIKeyNamePrefix: b.exo.apiKeyNamePrefix,
-		},
+	res.Data = config.responseData()
+	return res, nil
+}
+
+// verifyRootKeyRotatable checks that the root API key is an IAM API Key (v3), legacy
+// IAM Access Keys can't be rotated
+func verifyRootKeyRotatable(ctx context.Context, config ExoscaleConfig) error {
+	exo := &Exoscale{}
+	if err := exo.LoadConfig(config); err != nil {
+		return err
 	}
-	res.AddWarning("Access to the /config/root endpoint should be controlled via ACLs as it will return sensitive informations")
 
-	return res, nil
+	if _, err := exo.V3GetAPIKey(ctx, config.RootAPIKey); err != nil {
+		return fmt.Errorf("%s requires the root API key to be an IAM API Key (v3): %w", configRootRotationPeriod, err)
+	}
+
+	return nil
+}
+
+// verifyRootConfig checks that the root credentials are valid and that their IAM role
+// allows the operations required by the backend. An error is returned if the credentials
+// are invalid or lack permissions, warnings are returned if permissions can't be verified.
+func verifyRootConfig(ctx context.Context, config ExoscaleConfig) ([]string, error) {
+	exo := &Exoscale{}
+	if err := exo.LoadConfig(config); err != nil {
+		return nil, err
+	}
+
+	key, err := exo.V3GetAPIKey(ctx, config.RootAPIKey)
+	if err != nil {
+		return nil, fmt.Errorf("unable to verify the root credentials (set %s=false to skip verification): %w",
+			configVerify, err)
+	}
+
+	iamrole, err := exo.V3GetRole(ctx, *key.RoleId)
+	if err != nil {
+		return nil, fmt.Errorf("unable to retrieve the IAM role of the root API key (set %s=false to skip verification): %w",
+			configVerify, err)
+	}
+	if iamrole.Policy == nil {
+		return []string{fmt.Sprintf("IAM role %q has no policy, its permissions could not be verified", *iamrole.Name)}, nil
+	}
+
+	var missing, undetermined []string
+	for _, op := range requiredRootOperations {
+		switch evaluateIAMPolicy(iamrole.Policy, "iam", op) {
+		case iamPolicyDenied:
+			missing = append(missing, op)
+		case iamPolicyUndetermined:
+			undetermined = append(undetermined, op)
+		}
+	}
+
+	if len(missing) > 0 {
+		return nil, fmt.Errorf("the IAM role %q of the root API key doesn't allow the following operations: %s",
+			*iamrole.Name, strings.Join(missing, ", "))
+	}
+
+	var warnings []string
+	if len(undetermined) > 0 {
+		warnings = append(warnings, fmt.Sprintf("the IAM role %q of the root API key relies on rules that could not be "+
+			"evaluated, make sure it allows the following operations: %s", *iamrole.Name, strings.Join(undetermined, ", ")))
+	}
+
+	return warnings, nil
 }
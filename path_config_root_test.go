@@ -2,9 +2,14 @@ package exoscale
 
 import (
 	"context"
+	"errors"
 	"testing"
+	"time"
 
 	"github.com/hashicorp/vault/sdk/logical"
+	"github.com/stretchr/testify/mock"
+
+	"github.com/exoscale/egoscale/v2/oapi"
 )
 
 const (
@@ -15,6 +20,19 @@ const (
 )
 
 func (ts *testSuite) TestPathConfigRootRead() {
+	rootRoleID := ts.randomID()
+	mockClient := ts.backend.(*exoscaleBackend).exo.egoscaleClient.(*mockEgoscaleClient)
+	mockClient.
+		On("GetApiKeyWithResponse", mock.Anything, "EXO0000").
+		Return(&oapi.GetApiKeyResponse{
+			JSON200: &oapi.IamApiKey{Key: ptr("EXO0000"), Name: ptr("vault-root"), RoleId: &rootRoleID},
+		}, nil)
+	mockClient.
+		On("GetIamRoleWithResponse", mock.Anything, rootRoleID).
+		Return(&oapi.GetIamRoleResponse{
+			JSON200: &oapi.IamRole{Id: &rootRoleID, Name: ptr("vault-root-role")},
+		}, nil)
+
 	res, err := ts.backend.HandleRequest(context.Background(), &logical.Request{
 		Storage:   ts.storage,
 		Operation: logical.ReadOperation,
@@ -24,16 +42,78 @@ func (ts *testSuite) TestPathConfigRootRead() {
 		ts.FailNow("request failed", err)
 	}
 
+	ts.Require().Empty(res.Warnings)
 	ts.Require().Equal(map[string]interface{}{
-		"api_environment":     "api",
-		"root_api_key":        "EXO0000",
-		"root_api_secret":     "xxxxxxxx",
-		"api_key_name_prefix": "",
-		"zone":                "ch-gva-2",
+		"api_environment":      "api",
+		"root_api_key":         "EXO0000",
+		"api_key_name_prefix":  "",
+		"zone":                 "ch-gva-2",
+		"root_rotation_period": int64(0),
+		"root_api_key_valid":   true,
+		"root_api_key_name":    "vault-root",
+		"root_iam_role_id":     rootRoleID,
+		"root_iam_role_name":   "vault-root-role",
 	}, res.Data)
 }
 
+func (ts *testSuite) TestPathConfigRootReadInvalidKey() {
+	ts.backend.(*exoscaleBackend).exo.egoscaleClient.(*mockEgoscaleClient).
+		On("GetApiKeyWithResponse", mock.Anything, "EXO0000").
+		Return(nil, errors.New("invalid request: API Key not in organization"))
+
+	res, err := ts.backend.HandleRequest(context.Background(), &logical.Request{
+		Storage:   ts.storage,
+		Operation: logical.ReadOperation,
+		Path:      configRootStoragePath,
+	})
+	ts.Require().NoError(err)
+
+	ts.Require().Equal(false, res.Data["root_api_key_valid"])
+	ts.Require().NotContains(res.Data, configRootAPISecret)
+	ts.Require().Len(res.Warnings, 1)
+	ts.Require().Contains(res.Warnings[0], "unable to validate the root API key")
+}
+
+// mockRootIAMRole sets up the mock so that the test root API key is bound to an IAM role with policy
+func (ts *testSuite) mockRootIAMRole(policy *oapi.IamPolicy) {
+	rootRoleID := ts.randomID()
+	mockClient := ts.backend.(*exoscaleBackend).exo.egoscaleClient.(*mockEgoscaleClient)
+	mockClient.
+		On("GetApiKeyWithResponse", mock.Anything, testConfigRootAPIKey).
+		Return(&oapi.GetApiKeyResponse{
+			JSON200: &oapi.IamApiKey{Key: ptr(testConfigRootAPIKey), Name: ptr("vault-root"), RoleId: &rootRoleID},
+		}, nil)
+	mockClient.
+		On("GetIamRoleWithResponse", mock.Anything, rootRoleID).
+		Return(&oapi.GetIamRoleResponse{
+			JSON200: &oapi.IamRole{Id: &rootRoleID, Name: ptr("vault-root-role"), Policy: policy},
+		}, nil)
+}
+
+func testRootIAMPolicy(expression string) *oapi.IamPolicy {
+	rules := oapi.IamServicePolicyTypeRules
+	allow := oapi.IamServicePolicyRuleActionAllow
+	return &oapi.IamPolicy{
+		DefaultServiceStrategy: oapi.IamPolicyDefaultServiceStrategyDeny,
+		Services: oapi.IamPolicy_Services{
+			AdditionalProperties: map[string]oapi.IamServicePolicy{
+				"iam": {
+					Type: &rules,
+					Rules: &[]oapi.IamServicePolicyRule{{
+						Action:     &allow,
+						Expression: &expression,
+					}},
+				},
+			},
+		},
+	}
+}
+
 func (ts *testSuite) TestPathConfigRootWrite() {
+	ts.mockRootIAMRole(testRootIAMPolicy(
+		"operation in ['create-api-key', 'delete-api-key', 'get-api-key', 'list-api-keys', 'list-iam-roles', 'get-iam-role']",
+	))
+
 	tests := []struct {
 		name     string
 		data     map[string]interface{}
@@ -53,27 +133,43 @@ func (ts *testSuite) TestPathConfigRootWrite() {
 				configRootAPISecret: testConfigRootAPISecret,
 			},
 			expected: ExoscaleConfig{
-				APIEnvironment: "api",
-				RootAPIKey:     testConfigRootAPIKey,
-				RootAPISecret:  testConfigRootAPISecret,
-				Zone:           "ch-gva-2",
+				APIEnvironment:        "api",
+				RootAPIKey:            testConfigRootAPIKey,
+				RootAPISecret:         testConfigRootAPISecret,
+				Zone:                  "ch-gva-2",
+				OperationTimeout:      defaultOperationTimeout,
+				OperationPollInterval: defaultOperationPollInterval,
+				APIMaxRetries:         ptr(defaultAPIMaxRetries),
+				APIRetryWaitMax:       defaultAPIRetryWaitMax,
 			},
 		},
 		{
 			name: "full",
 			data: map[string]interface{}{
-				configAPIEnvironment:   testConfigAPIEnvironment,
-				configRootAPIKey:       testConfigRootAPIKey,
-				configRootAPISecret:    testConfigRootAPISecret,
-				configAPIKeyNamePrefix: "toto",
-				configZone:             testConfigZone,
+				configAPIEnvironment:        testConfigAPIEnvironment,
+				configRootAPIKey:            testConfigRootAPIKey,
+				configRootAPISecret:         testConfigRootAPISecret,
+				configAPIKeyNamePrefix:      "toto",
+				configZone:                  testConfigZone,
+				configRootRotationPeriod:    "720h",
+				configOperationTimeout:      "5m",
+				configOperationPollInterval: "2s",
+				configAPIMaxRetries:         0,
+				configAPIRetryWaitMax:       "1m",
+				configAPIRateLimit:          20,
 			},
 			expected: ExoscaleConfig{
-				APIEnvironment:   testConfigAPIEnvironment,
-				RootAPIKey:       testConfigRootAPIKey,
-				RootAPISecret:    testConfigRootAPISecret,
-				Zone:             testConfigZone,
-				APIKeyNamePrefix: "toto",
+				APIEnvironment:        testConfigAPIEnvironment,
+				RootAPIKey:            testConfigRootAPIKey,
+				RootAPISecret:         testConfigRootAPISecret,
+				Zone:                  testConfigZone,
+				APIKeyNamePrefix:      "toto",
+				RootRotationPeriod:    720 * time.Hour,
+				OperationTimeout:      5 * time.Minute,
+				OperationPollInterval: 2 * time.Second,
+				APIMaxRetries:         ptr(0),
+				APIRetryWaitMax:       time.Minute,
+				APIRateLimit:          20,
 			},
 		},
 	}
@@ -83,7 +179,7 @@ func (ts *testSuite) TestPathConfigRootWrite() {
 			var actualBackendConfig ExoscaleConfig
 			tt.storage = &logical.InmemStorage{}
 
-			_, err := ts.backend.HandleRequest(context.Background(), &logical.Request{
+			res, err := ts.backend.HandleRequest(context.Background(), &logical.Request{
 				Storage:   tt.storage,
 				Operation: logical.CreateOperation,
 				Path:      configRootStoragePath,
@@ -95,6 +191,8 @@ func (ts *testSuite) TestPathConfigRootWrite() {
 			}
 
 			if err == nil {
+				ts.Require().NotContains(res.Data, configRootAPISecret)
+
 				entry, err := tt.storage.Get(context.Background(), configRootStoragePath)
 				if err != nil {
 					ts.FailNow("unable to retrieve entry from storage", err)
@@ -103,8 +201,144 @@ func (ts *testSuite) TestPathConfigRootWrite() {
 					ts.FailNow("unable to JSON-decode entry", err)
 				}
 
+				// automatic rotation is scheduled from the write of the configuration
+				if tt.expected.RootRotationPeriod != 0 {
+					ts.Require().WithinDuration(time.Now(), actualBackendConfig.RootLastRotated, time.Minute)
+					actualBackendConfig.RootLastRotated = time.Time{}
+				}
+
 				ts.Require().Equal(tt.expected, actualBackendConfig)
 			}
 		})
 	}
 }
+
+func (ts *testSuite) TestPathConfigRootWriteVerify() {
+	tests := []struct {
+		name         string
+		policy       *oapi.IamPolicy
+		verify       bool
+		wantErr      string
+		wantWarnings int
+	}{
+		{
+			name:   "missing operations",
+			policy: testRootIAMPolicy("operation in ['create-api-key', 'get-api-key']"),
+			verify: true,
+			wantErr: `the IAM role "vault-root-role" of the root API key doesn't allow the following operations: ` +
+				"delete-api-key, list-api-keys, list-iam-roles, get-iam-role",
+		},
+		{
+			name:         "undetermined rules",
+			policy:       testRootIAMPolicy("operation == 'create-api-key' || parameters.role_id == 'x'"),
+			verify:       true,
+			wantWarnings: 1,
+		},
+		{
+			name:   "verification disabled",
+			policy: testRootIAMPolicy("operation == 'get-api-key'"),
+			verify: false,
+		},
+	}
+
+	for _, tt := range tests {
+		ts.Run(tt.name, func() {
+			ts.backend.(*exoscaleBackend).exo.egoscaleClient.(*mockEgoscaleClient).ExpectedCalls = nil
+			ts.mockRootIAMRole(tt.policy)
+
+			res, err := ts.backend.HandleRequest(context.Background(), &logical.Request{
+				Storage:   &logical.InmemStorage{},
+				Operation: logical.CreateOperation,
+				Path:      configRootStoragePath,
+				Data: map[string]interface{}{
+					configRootAPIKey:    testConfigRootAPIKey,
+					configRootAPISecret: testConfigRootAPISecret,
+					configVerify:        tt.verify,
+				},
+			})
+			if tt.wantErr != "" {
+				ts.Require().EqualError(err, tt.wantErr)
+				return
+			}
+			ts.Require().NoError(err)
+			ts.Require().Len(res.Warnings, tt.wantWarnings)
+		})
+	}
+}
+
+func (ts *testSuite) TestPathConfigRootWriteInvalidCredentials() {
+	ts.backend.(*exoscaleBackend).exo.egoscaleClient.(*mockEgoscaleClient).
+		On("GetApiKeyWithResponse", mock.Anything, testConfigRootAPIKey).
+		Return(nil, errors.New("invalid request: API Key not in organization"))
+
+	_, err := ts.backend.HandleRequest(context.Background(), &logical.Request{
+		Storage:   &logical.InmemStorage{},
+		Operation: logical.CreateOperation,
+		Path:      configRootStoragePath,
+		Data: map[string]interface{}{
+			configRootAPIKey:    testConfigRootAPIKey,
+			configRootAPISecret: testConfigRootAPISecret,
+		},
+	})
+	ts.Require().ErrorContains(err, "unable to verify the root credentials (set verify=false to skip verification)")
+}
+
+func (ts *testSuite) TestPathConfigRootWriteRotationLegacyKey() {
+	ts.backend.(*exoscaleBackend).exo.egoscaleClient.(*mockEgoscaleClient).
+		On("GetApiKeyWithResponse", mock.Anything, testConfigRootAPIKey).
+		Return(nil, errors.New("invalid request: API Key not in organization"))
+
+	_, err := ts.backend.HandleRequest(context.Background(), &logical.Request{
+		Storage:   &logical.InmemStorage{},
+		Operation: logical.CreateOperation,
+		Path:      configRootStoragePath,
+		Data: map[string]interface{}{
+			configRootAPIKey:         testConfigRootAPIKey,
+			configRootAPISecret:      testConfigRootAPISecret,
+			configRootRotationPeriod: "720h",
+			configVerify:             false,
+		},
+	})
+	ts.Require().ErrorContains(err, "root_rotation_period requires the root API key to be an IAM API Key (v3)")
+}
+
+func (ts *testSuite) TestPathConfigRootWriteUpdate() {
+	config, err := getRootConfig(context.Background(), ts.storage)
+	ts.Require().NoError(err)
+	config.RootRotationPeriod = 24 * time.Hour
+	config.RootLastRotated = time.Now().Add(-time.Hour)
+	config.APIRateLimit = 10
+	ts.storeEntry(configRootStoragePath, config)
+
+	// settings are updated without the root API secret, only known to Vault after a rotation
+	_, err = ts.backend.HandleRequest(context.Background(), &logical.Request{
+		Storage:   ts.storage,
+		Operation: logical.UpdateOperation,
+		Path:      configRootStoragePath,
+		Data: map[string]interface{}{
+			configNameTemplate: "vault-{{ .RoleName }}-{{ unix_time_nano }}",
+			configVerify:       false,
+		},
+	})
+	ts.Require().NoError(err)
+
+	updated, err := getRootConfig(context.Background(), ts.storage)
+	ts.Require().NoError(err)
+	ts.Require().Equal("xxxxxxxx", updated.RootAPISecret)
+	ts.Require().True(config.RootLastRotated.Equal(updated.RootLastRotated))
+	ts.Require().Equal(24*time.Hour, updated.RootRotationPeriod)
+	ts.Require().Equal(10, updated.APIRateLimit)
+	ts.Require().Equal("vault-{{ .RoleName }}-{{ unix_time_nano }}", updated.NameTemplate)
+
+	// a new root API key requires its secret
+	_, err = ts.backend.HandleRequest(context.Background(), &logical.Request{
+		Storage:   ts.storage,
+		Operation: logical.UpdateOperation,
+		Path:      configRootStoragePath,
+		Data: map[string]interface{}{
+			configRootAPIKey: testConfigRootAPIKey,
+			configVerify:     false,
+		},
+	})
+	ts.Require().ErrorIs(err, errMissingAPICredentials)
+}
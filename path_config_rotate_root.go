@@ -0,0 +1,169 @@
+package exoscale
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/hashicorp/vault/sdk/framework"
+	"github.com/hashicorp/vault/sdk/logical"
+)
+
+const minRootRotationPeriod = time.Hour
+
+const (
+	pathConfigRotateRootHelpSyn  = "Rotate the root Exoscale API credentials"
+	pathConfigRotateRootHelpDesc = `
+Replace the root API key by a new key bound to the same IAM role, then delete
+the previous key. After a rotation the root API secret is only known to Vault.
+
+Only IAM API Keys (v3) can be rotated, the root API key must be allowed to
+perform the get-api-key, create-api-key and delete-api-key operations.
+
+Rotation can be performed automatically by setting root_rotation_period
+on the config/root endpoint.
+
+Example:
+    vault write -f exoscale/config/rotate-root
+`
+)
+
+func (b *exoscaleBackend) pathConfigRotateRoot() *framework.Path {
+	return &framework.Path{
+		Pattern: "config/rotate-root",
+
+		Operations: map[logical.Operation]framework.OperationHandler{
+			logical.UpdateOperation: &framework.PathOperation{Callback: b.pathConfigRotateRootWrite},
+		},
+
+		HelpSynopsis:    pathConfigRotateRootHelpSyn,
+		HelpDescription: pathConfigRotateRootHelpDesc,
+	}
+}
+
+func (b *exoscaleBackend) pathConfigRotateRootWrite(
+	ctx context.Context,
+	req *logical.Request,
+	_ *framework.FieldData,
+) (*logical.Response, error) {
+	b.rootConfigLock.Lock()
+	defer b.rootConfigLock.Unlock()
+
+	config, err := getRootConfig(ctx, req.Storage)
+	if err != nil {
+		return nil, err
+	}
+	if config == nil {
+		return nil, ErrorBackendNotConfigured
+	}
+
+	res := &logical.Response{
+		Data: map[string]interface{}{},
+	}
+
+	if err := b.rotateRoot(ctx, req.Storage, config); err != nil {
+		var derr *rootKeyDeletionError
+		if !errors.As(err, &derr) {
+			return nil, err
+		}
+		res.AddWarning(err.Error())
+	}
+
+	res.Data[configRootAPIKey] = config.RootAPIKey
+	return res, nil
+}
+
+// rootKeyDeletionError is returned when the root credentials were rotated
+// successfully but the previous root API key could not be deleted
+type rootKeyDeletionError struct {
+	key string
+	err error
+}
+
+func (e *rootKeyDeletionError) Error() string {
+	return fmt.Sprintf("the root API key was rotated but the previous key %q could not be deleted, "+
+		"it must be deleted manually: %s", e.key, e.err)
+}
+
+func (e *rootKeyDeletionError) Unwrap() error {
+	return e.err
+}
+
+// rotateRoot replaces the root API key by a new key bound to the same IAM role,
+// config is updated in place. Callers must hold rootConfigLock.
+func (b *exoscaleBackend) rotateRoot(ctx context.Context, storage logical.Storage, config *ExoscaleConfig) error {
+	previousKey := config.RootAPIKey
+
+	rootKey, err := b.exo.V3GetAPIKey(ctx, previousKey)
+	if err != nil {
+		return fmt.Errorf("unable to retrieve the root API key, only IAM API Keys (v3) can be rotated: %w", err)
+	}
+
+	name, err := b.exo.resourceName(nameData{RoleName: "root", DisplayName: "rotated"}, "")
+	if err != nil {
+		return err
+	}
+
+	apikey, err := b.exo.V3CreateAPIKey(ctx, name, Role{IAMRoleID: *rootKey.RoleId})
+	if err != nil {
+		return fmt.Errorf("unable to create a new root API key: %w", err)
+	}
+
+	rotated := *config
+	rotated.RootAPIKey = *apikey.Key
+	rotated.RootAPISecret = *apikey.Secret
+	rotated.RootLastRotated = time.Now()
+
+	entry, err := logical.StorageEntryJSON(configRootStoragePath, rotated)
+	if err != nil {
+		return err
+	}
+	if err := storage.Put(ctx, entry); err != nil {
+		// the previous key is still in use, the new one is discarded
+		if err := b.exo.V3DeleteAPIKey(ctx, *apikey.Key); err != nil {
+			b.Logger().Warn("Failed to clean up new root API key", "iam_key", *apikey.Key, "err", err)
+		}
+		return err
+	}
+
+	if err := b.exo.LoadConfig(rotated); err != nil {
+		return err
+	}
+	*config = rotated
+
+	b.Logger().Info("Root API key rotated", "iam_key", rotated.RootAPIKey, "previous_iam_key", previousKey)
+
+	// the previous key is deleted using the new credentials
+	if err := b.exo.V3DeleteAPIKey(ctx, previousKey); err != nil && !errors.Is(err, ErrNotFound) {
+		b.Logger().Warn("Failed to delete previous root API key", "iam_key", previousKey, "err", err)
+		return &rootKeyDeletionError{key: previousKey, err: err}
+	}
+
+	return nil
+}
+
+// rotateRootIfDue rotates the root API key if root_rotation_period has elapsed
+func (b *exoscaleBackend) rotateRootIfDue(ctx context.Context, storage logical.Storage) error {
+	b.rootConfigLock.Lock()
+	defer b.rootConfigLock.Unlock()
+
+	config, err := getRootConfig(ctx, storage)
+	if err != nil {
+		return err
+	}
+	if config == nil || config.RootRotationPeriod == 0 {
+		return nil
+	}
+
+	if time.Now().Before(config.RootLastRotated.Add(config.RootRotationPeriod)) {
+		return nil
+	}
+
+	if err := b.rotateRoot(ctx, storage, config); err != nil {
+		b.Logger().Error("Failed to rotate root API key", "err", err)
+		return err
+	}
+
+	return nil
+}
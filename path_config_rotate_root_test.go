@@ -0,0 +1,118 @@
+package exoscale
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/hashicorp/vault/sdk/logical"
+	"github.com/stretchr/testify/mock"
+
+	"github.com/exoscale/egoscale/v2/oapi"
+)
+
+func (ts *testSuite) mockRootRotation(deleteErr error) (newKey string) {
+	rootRoleID := ts.randomID()
+	newKey = "EXOnewroot"
+	newSecret := "newrootsecret"
+	state := oapi.OperationStateSuccess
+
+	mockClient := ts.backend.(*exoscaleBackend).exo.egoscaleClient.(*mockEgoscaleClient)
+	mockClient.
+		On("GetApiKeyWithResponse", mock.Anything, "EXO0000").
+		Return(&oapi.GetApiKeyResponse{
+			JSON200: &oapi.IamApiKey{Key: ptr("EXO0000"), RoleId: &rootRoleID},
+		}, nil)
+	mockClient.
+		On("CreateApiKeyWithResponse", mock.Anything, mock.Anything).
+		Run(func(args mock.Arguments) {
+			ts.Require().Equal(rootRoleID, args.Get(1).(oapi.CreateApiKeyJSONRequestBody).RoleId)
+		}).
+		Return(&oapi.CreateApiKeyResponse{
+			JSON200: &oapi.IamApiKeyCreated{Key: &newKey, Name: ptr("vault-root"), RoleId: &rootRoleID, Secret: &newSecret},
+		}, nil)
+
+	if deleteErr != nil {
+		mockClient.
+			On("DeleteApiKeyWithResponse", mock.Anything, "EXO0000").
+			Return(nil, deleteErr)
+	} else {
+		mockClient.
+			On("DeleteApiKeyWithResponse", mock.Anything, "EXO0000").
+			Return(&oapi.DeleteApiKeyResponse{JSON200: &oapi.Operation{State: &state}}, nil)
+	}
+
+	return newKey
+}
+
+func (ts *testSuite) TestPathConfigRotateRoot() {
+	newKey := ts.mockRootRotation(nil)
+
+	res, err := ts.backend.HandleRequest(context.Background(), &logical.Request{
+		Storage:   ts.storage,
+		Operation: logical.UpdateOperation,
+		Path:      "config/rotate-root",
+	})
+	ts.Require().NoError(err)
+	ts.Require().Empty(res.Warnings)
+	ts.Require().Equal(newKey, res.Data[configRootAPIKey])
+
+	config, err := getRootConfig(context.Background(), ts.storage)
+	ts.Require().NoError(err)
+	ts.Require().Equal(newKey, config.RootAPIKey)
+	ts.Require().Equal("newrootsecret", config.RootAPISecret)
+	ts.Require().WithinDuration(time.Now(), config.RootLastRotated, time.Minute)
+	ts.Require().Equal(newKey, ts.backend.(*exoscaleBackend).exo.apiKey)
+
+	ts.backend.(*exoscaleBackend).exo.egoscaleClient.(*mockEgoscaleClient).
+		AssertCalled(ts.T(), "DeleteApiKeyWithResponse", mock.Anything, "EXO0000")
+}
+
+func (ts *testSuite) TestPathConfigRotateRootDeletionFailure() {
+	newKey := ts.mockRootRotation(errors.New("boom"))
+
+	res, err := ts.backend.HandleRequest(context.Background(), &logical.Request{
+		Storage:   ts.storage,
+		Operation: logical.UpdateOperation,
+		Path:      "config/rotate-root",
+	})
+	ts.Require().NoError(err)
+	ts.Require().Len(res.Warnings, 1)
+	ts.Require().Contains(res.Warnings[0], `previous key "EXO0000" could not be deleted`)
+
+	config, err := getRootConfig(context.Background(), ts.storage)
+	ts.Require().NoError(err)
+	ts.Require().Equal(newKey, config.RootAPIKey)
+}
+
+func (ts *testSuite) TestPeriodicRotateRoot() {
+	config, err := getRootConfig(context.Background(), ts.storage)
+	ts.Require().NoError(err)
+	config.RootRotationPeriod = 24 * time.Hour
+	config.RootLastRotated = time.Now().Add(-time.Hour)
+	ts.storeEntry(configRootStoragePath, config)
+
+	// not due yet
+	_, err = ts.backend.HandleRequest(context.Background(), &logical.Request{
+		Storage:   ts.storage,
+		Operation: logical.RollbackOperation,
+	})
+	ts.Require().NoError(err)
+	ts.backend.(*exoscaleBackend).exo.egoscaleClient.(*mockEgoscaleClient).
+		AssertNotCalled(ts.T(), "CreateApiKeyWithResponse", mock.Anything, mock.Anything)
+
+	config.RootLastRotated = time.Now().Add(-25 * time.Hour)
+	ts.storeEntry(configRootStoragePath, config)
+	newKey := ts.mockRootRotation(nil)
+
+	_, err = ts.backend.HandleRequest(context.Background(), &logical.Request{
+		Storage:   ts.storage,
+		Operation: logical.RollbackOperation,
+	})
+	ts.Require().NoError(err)
+
+	config, err = getRootConfig(context.Background(), ts.storage)
+	ts.Require().NoError(err)
+	ts.Require().Equal(newKey, config.RootAPIKey)
+	ts.Require().Equal(24*time.Hour, config.RootRotationPeriod)
+}
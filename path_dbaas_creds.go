@@ -0,0 +1,194 @@
+package exoscale
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/vault/sdk/framework"
+	"github.com/hashicorp/vault/sdk/logical"
+)
+
+const (
+	// dbaasUsernamePrefix is the prefix of the users created for leases
+	dbaasUsernamePrefix = "v_"
+
+	// dbaasUsernameRoleLength is the maximum length of the role name in the usernames,
+	// keeping them within the 32 characters allowed by MySQL
+	dbaasUsernameRoleLength = 16
+
+	dbaasUsernameCharset = "abcdefghijklmnopqrstuvwxyz0123456789"
+)
+
+const (
+	pathDBaaSCredsHelpSyn  = "Create a user of a DBaaS service"
+	pathDBaaSCredsHelpDesc = `
+This endpoint creates a uniquely named user of the DBaaS service of a role and
+returns its username, password and connection URI. The users of Kafka services
+are granted the topic ACLs of the role, and their client certificate and key are
+returned in access_cert and access_key. The user and its ACLs are deleted when the
+lease is revoked.
+
+Example:
+    vault read exoscale/dbaas-creds/app
+`
+)
+
+func (b *exoscaleBackend) pathDBaaSCreds() *framework.Path {
+	return &framework.Path{
+		Pattern: "dbaas-creds/" + framework.GenericNameRegex(configVaultRoleName),
+		Fields: map[string]*framework.FieldSchema{
+			configVaultRoleName: {
+				Type:        framework.TypeString,
+				Description: "Name of the DBaaS role",
+				Required:    true,
+			},
+		},
+
+		Operations: map[logical.Operation]framework.OperationHandler{
+			logical.ReadOperation: &framework.PathOperation{Callback: b.readDBaaSCreds},
+		},
+
+		HelpSynopsis:    pathDBaaSCredsHelpSyn,
+		HelpDescription: pathDBaaSCredsHelpDesc,
+	}
+}
+
+// dbaasUsername generates a unique username for a role, v_<role>_<random>
+func dbaasUsername(roleName string) (string, error) {
+	role := strings.Map(func(r rune) rune {
+		switch {
+		case r >= 'a' && r <= 'z', r >= '0' && r <= '9':
+			return r
+		default:
+			return '_'
+		}
+	}, strings.ToLower(roleName))
+	if len(role) > dbaasUsernameRoleLength {
+		role = role[:dbaasUsernameRoleLength]
+	}
+
+	suffix, err := randomString(dbaasUsernameCharset, 10)
+	if err != nil {
+		return "", err
+	}
+
+	return dbaasUsernamePrefix + role + "_" + suffix, nil
+}
+
+func (b *exoscaleBackend) readDBaaSCreds(
+	ctx context.Context,
+	req *logical.Request,
+	data *framework.FieldData,
+) (*logical.Response, error) {
+	roleName := data.Get(configVaultRoleName).(string)
+	role, err := getDBaaSRole(ctx, req.Storage, roleName)
+	if err != nil {
+		return nil, err
+	}
+	if role == nil {
+		return logical.ErrorResponse("DBaaS role %q not found", roleName), nil
+	}
+
+	exo, err := b.exoscale(ctx, req.Storage, role.Account)
+	if err != nil {
+		return logical.ErrorResponse(err.Error()), nil
+	}
+
+	username, err := dbaasUsername(roleName)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := exo.V3CreateDBaaSUser(ctx, role.Zone, role.ServiceType, role.Service, username); err != nil {
+		return nil, err
+	}
+
+	user, err := setupDBaaSUser(ctx, exo, role, username)
+	if err != nil {
+		if err := deleteDBaaSUser(ctx, exo, role.Zone, role.ServiceType, role.Service, username); err != nil {
+			b.Logger().Warn("Failed to clean up DBaaS user", "service", role.Service, "username", username, "err", err)
+		}
+		return nil, err
+	}
+
+	if err := putAccountLease(ctx, req.Storage, role.Account, SecretTypeDBaaSUser, username); err != nil {
+		if err := deleteDBaaSUser(ctx, exo, role.Zone, role.ServiceType, role.Service, username); err != nil {
+			b.Logger().Warn("Failed to clean up DBaaS user", "service", role.Service, "username", username, "err", err)
+		}
+		return nil, err
+	}
+
+	res := b.Secret(SecretTypeDBaaSUser).Response(
+		user.responseData(),
+		map[string]interface{}{
+			"role":                 roleName,
+			configDBaaSServiceType: role.ServiceType,
+			configDBaaSService:     role.Service,
+			configZone:             role.Zone,
+			configRoleAccount:      role.Account,
+			"username":             username,
+		})
+	res.Secret.TTL = role.TTL
+	res.Secret.MaxTTL = role.MaxTTL
+	res.Secret.Renewable = true
+
+	b.Logger().Info("DBaaS user created",
+		"role", roleName,
+		"service", role.Service,
+		"username", username,
+		"ttl", fmt.Sprint(res.Secret.TTL),
+		"entity_id", req.EntityID)
+
+	return res, nil
+}
+
+// setupDBaaSUser grants the Kafka ACLs of a role to a new user and returns its credentials
+func setupDBaaSUser(
+	ctx context.Context,
+	exo *Exoscale,
+	role *DBaaSRole,
+	username string,
+) (*dbaasUser, error) {
+	if role.ServiceType == dbaasTypeKafka {
+		for _, acl := range role.KafkaACLs {
+			if err := exo.V3CreateKafkaTopicACL(ctx, role.Zone, role.Service, username, acl); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	return exo.V3GetDBaaSUser(ctx, role.Zone, role.ServiceType, role.Service, username)
+}
+
+// deleteDBaaSUser deletes a user of a DBaaS service, and its ACLs for Kafka services
+func deleteDBaaSUser(ctx context.Context, exo *Exoscale, zone, serviceType, service, username string) error {
+	if serviceType == dbaasTypeKafka {
+		if err := exo.V3DeleteKafkaTopicACLs(ctx, zone, service, username); err != nil && !errors.Is(err, ErrNotFound) {
+			return err
+		}
+	}
+
+	if err := exo.V3DeleteDBaaSUser(ctx, zone, serviceType, service, username); err != nil && !errors.Is(err, ErrNotFound) {
+		return err
+	}
+
+	return nil
+}
+
+func (u *dbaasUser) responseData() map[string]interface{} {
+	data := map[string]interface{}{
+		"username": u.Username,
+		"password": u.Password,
+		"uri":      u.URI,
+	}
+	if u.AccessCert != "" {
+		data["access_cert"] = u.AccessCert
+		data["access_key"] = u.AccessKey
+		data["access_cert_expiry"] = u.AccessCertExpiry.Format(time.RFC3339)
+	}
+
+	return data
+}
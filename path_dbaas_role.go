@@ -0,0 +1,330 @@
+package exoscale
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/vault/sdk/framework"
+	"github.com/hashicorp/vault/sdk/logical"
+)
+
+// DBaaSRole is a role creating users of a DBaaS service for leases
+type DBaaSRole struct {
+	ServiceType string        `json:"service_type"`
+	Service     string        `json:"service"`
+	Zone        string        `json:"zone,omitempty"`
+	TTL         time.Duration `json:"ttl,omitempty"`
+	MaxTTL      time.Duration `json:"max_ttl,omitempty"`
+	Account     string        `json:"account,omitempty"`
+
+	// KafkaACLs are granted to the users of Kafka services
+	KafkaACLs []kafkaACL `json:"kafka_acls,omitempty"`
+}
+
+// kafkaACL is a permission on the Kafka topics matching a pattern
+type kafkaACL struct {
+	Permission string `json:"permission"`
+	Topic      string `json:"topic"`
+}
+
+func (acl kafkaACL) String() string {
+	return acl.Permission + ":" + acl.Topic
+}
+
+// kafkaACLPermissions are the permissions which can be granted on Kafka topics
+var kafkaACLPermissions = []string{"read", "write", "readwrite", "admin"}
+
+// parseKafkaACL parses an ACL formatted as <permission>:<topic pattern>
+func parseKafkaACL(s string) (kafkaACL, error) {
+	permission, topic, ok := strings.Cut(s, ":")
+	if !ok || topic == "" {
+		return kafkaACL{}, fmt.Errorf("invalid Kafka ACL %q, expected <permission>:<topic pattern>", s)
+	}
+	if !slices.Contains(kafkaACLPermissions, permission) {
+		return kafkaACL{}, fmt.Errorf("invalid Kafka ACL %q, permission must be one of %s",
+			s, strings.Join(kafkaACLPermissions, ", "))
+	}
+
+	return kafkaACL{Permission: permission, Topic: topic}, nil
+}
+
+const (
+	dbaasRoleStoragePathPrefix = "dbaas-role/"
+
+	configDBaaSServiceType = "service_type"
+	configDBaaSService     = "service"
+	configDBaaSKafkaACLs   = "kafka_acls"
+)
+
+const (
+	pathListDBaaSRolesHelpSyn  = "List the configured DBaaS roles"
+	pathListDBaaSRolesHelpDesc = `
+This endpoint returns a list of the configured DBaaS roles.
+`
+
+	pathDBaaSRoleHelpSyn  = "Manage DBaaS roles"
+	pathDBaaSRoleHelpDesc = `
+Manage DBaaS roles, creating a user of an Exoscale managed database service for
+each lease of the dbaas-creds/<name> endpoint. The user is deleted when the lease
+is revoked.
+
+The users of Kafka services are granted the topic ACLs of the role, deleted with them.
+
+Fields:
+	service_type: type of the service, one of pg, mysql, kafka, opensearch
+	service: name of the service
+	zone: zone of the service (optional, default: the zone of config/root or of the account)
+	ttl: default TTL of the leases (optional, default: the default lease TTL of the mount)
+	max_ttl: maximum TTL of the leases (optional, default: the maximum lease TTL of the mount)
+	account: name of the account configured with config/account/<name> owning the service (optional)
+	kafka_acls: comma-separated list of <permission>:<topic pattern> granted to the users of a Kafka
+		service, permission is one of read, write, readwrite, admin
+
+Example:
+    vault write exoscale/dbaas-role/app \
+	service_type=pg \
+	service=app-db \
+	zone=ch-gva-2 \
+	ttl=1h max_ttl=24h
+
+    vault write exoscale/dbaas-role/orders-consumer \
+	service_type=kafka \
+	service=events \
+	kafka_acls=read:orders.*,readwrite:orders-consumer.*
+`
+)
+
+func (b *exoscaleBackend) pathDBaaSRole() []*framework.Path {
+	return []*framework.Path{
+		{
+			Pattern: "dbaas-role/" + framework.GenericNameRegex(configVaultRoleName),
+			Fields: map[string]*framework.FieldSchema{
+				configVaultRoleName: {
+					Type:        framework.TypeString,
+					Description: "Name of the DBaaS role",
+					Required:    true,
+				},
+				configDBaaSServiceType: {
+					Type:          framework.TypeString,
+					Description:   "Type of the service",
+					AllowedValues: []interface{}{dbaasTypePg, dbaasTypeMysql, dbaasTypeKafka, dbaasTypeOpensearch},
+				},
+				configDBaaSService: {
+					Type:        framework.TypeString,
+					Description: "Name of the service",
+				},
+				configZone: {
+					Type:        framework.TypeString,
+					Description: "Zone of the service (optional, default: the zone of config/root or of the account)",
+				},
+				configRoleTTL: {
+					Type:        framework.TypeDurationSecond,
+					Description: "Default TTL of the leases",
+				},
+				configRoleMaxTTL: {
+					Type:        framework.TypeDurationSecond,
+					Description: "Maximum TTL of the leases",
+				},
+				configRoleAccount: {
+					Type:        framework.TypeString,
+					Description: "Name of the account configured with config/account/<name> owning the service (optional)",
+				},
+				configDBaaSKafkaACLs: {
+					Type:        framework.TypeCommaStringSlice,
+					Description: "Comma-separated list of <permission>:<topic pattern> granted to the users of a Kafka service",
+				},
+			},
+
+			Operations: map[logical.Operation]framework.OperationHandler{
+				logical.CreateOperation: &framework.PathOperation{Callback: b.writeDBaaSRole},
+				logical.UpdateOperation: &framework.PathOperation{Callback: b.writeDBaaSRole},
+				logical.ReadOperation:   &framework.PathOperation{Callback: b.readDBaaSRole},
+				logical.DeleteOperation: &framework.PathOperation{Callback: b.deleteDBaaSRole},
+			},
+
+			HelpSynopsis:    pathDBaaSRoleHelpSyn,
+			HelpDescription: pathDBaaSRoleHelpDesc,
+		},
+		{
+			Pattern: "dbaas-role/?$",
+
+			Operations: map[logical.Operation]framework.OperationHandler{
+				logical.ListOperation: &framework.PathOperation{Callback: b.listDBaaSRoles},
+			},
+
+			HelpSynopsis:    pathListDBaaSRolesHelpSyn,
+			HelpDescription: pathListDBaaSRolesHelpDesc,
+		},
+	}
+}
+
+func getDBaaSRole(ctx context.Context, storage logical.Storage, name string) (*DBaaSRole, error) {
+	if name == "" {
+		return nil, errors.New("invalid role name")
+	}
+
+	entry, err := storage.Get(ctx, dbaasRoleStoragePathPrefix+name)
+	if err != nil {
+		return nil, fmt.Errorf("unable to retrieve DBaaS role %q: %w", name, err)
+	}
+	if entry == nil {
+		return nil, nil
+	}
+
+	var role DBaaSRole
+	if err := entry.DecodeJSON(&role); err != nil {
+		return nil, err
+	}
+
+	return &role, nil
+}
+
+func (b *exoscaleBackend) listDBaaSRoles(
+	ctx context.Context,
+	req *logical.Request,
+	_ *framework.FieldData,
+) (*logical.Response, error) {
+	roles, err := req.Storage.List(ctx, dbaasRoleStoragePathPrefix)
+	if err != nil {
+		return nil, err
+	}
+
+	return logical.ListResponse(roles), nil
+}
+
+func (b *exoscaleBackend) readDBaaSRole(
+	ctx context.Context,
+	req *logical.Request,
+	data *framework.FieldData,
+) (*logical.Response, error) {
+	role, err := getDBaaSRole(ctx, req.Storage, data.Get(configVaultRoleName).(string))
+	if err != nil {
+		return nil, err
+	}
+	if role == nil {
+		return nil, nil
+	}
+
+	res := &logical.Response{
+		Data: map[string]interface{}{
+			configDBaaSServiceType: role.ServiceType,
+			configDBaaSService:     role.Service,
+			configRoleTTL:          role.TTL.Seconds(),
+			configRoleMaxTTL:       role.MaxTTL.Seconds(),
+		},
+	}
+	if role.Zone != "" {
+		res.Data[configZone] = role.Zone
+	}
+	if role.Account != "" {
+		res.Data[configRoleAccount] = role.Account
+	}
+	if role.ServiceType == dbaasTypeKafka {
+		acls := make([]string, 0, len(role.KafkaACLs))
+		for _, acl := range role.KafkaACLs {
+			acls = append(acls, acl.String())
+		}
+		res.Data[configDBaaSKafkaACLs] = acls
+	}
+
+	return res, nil
+}
+
+func (b *exoscaleBackend) writeDBaaSRole(
+	ctx context.Context,
+	req *logical.Request,
+	data *framework.FieldData,
+) (*logical.Response, error) {
+	b.rolesLock.Lock()
+	defer b.rolesLock.Unlock()
+
+	name := data.Get(configVaultRoleName).(string)
+	role, err := getDBaaSRole(ctx, req.Storage, name)
+	if err != nil {
+		return nil, err
+	}
+	if role == nil {
+		role = &DBaaSRole{}
+	}
+
+	if v, ok := data.GetOk(configDBaaSServiceType); ok {
+		role.ServiceType = v.(string)
+	}
+	if !slices.Contains(dbaasTypes, role.ServiceType) {
+		return logical.ErrorResponse("%s must be one of %s", configDBaaSServiceType, strings.Join(dbaasTypes, ", ")), nil
+	}
+
+	if v, ok := data.GetOk(configDBaaSService); ok {
+		role.Service = v.(string)
+	}
+	if role.Service == "" {
+		return logical.ErrorResponse("%s is required", configDBaaSService), nil
+	}
+
+	if v, ok := data.GetOk(configZone); ok {
+		role.Zone = v.(string)
+	}
+
+	if v, ok := data.GetOk(configRoleTTL); ok {
+		role.TTL = time.Duration(v.(int)) * time.Second
+	}
+	if v, ok := data.GetOk(configRoleMaxTTL); ok {
+		role.MaxTTL = time.Duration(v.(int)) * time.Second
+	}
+	if role.MaxTTL != 0 && role.TTL > role.MaxTTL {
+		return logical.ErrorResponse("%s can't be greater than %s", configRoleTTL, configRoleMaxTTL), nil
+	}
+
+	if v, ok := data.GetOk(configRoleAccount); ok {
+		role.Account = v.(string)
+		if _, err := b.exoscale(ctx, req.Storage, role.Account); err != nil {
+			return logical.ErrorResponse(err.Error()), nil
+		}
+	}
+
+	if v, ok := data.GetOk(configDBaaSKafkaACLs); ok {
+		role.KafkaACLs = nil
+		for _, s := range v.([]string) {
+			acl, err := parseKafkaACL(s)
+			if err != nil {
+				return logical.ErrorResponse(err.Error()), nil
+			}
+			role.KafkaACLs = append(role.KafkaACLs, acl)
+		}
+	}
+	if len(role.KafkaACLs) > 0 && role.ServiceType != dbaasTypeKafka {
+		return logical.ErrorResponse("%s can only be set for %s services", configDBaaSKafkaACLs, dbaasTypeKafka), nil
+	}
+
+	entry, err := logical.StorageEntryJSON(dbaasRoleStoragePathPrefix+name, role)
+	if err != nil {
+		return nil, err
+	}
+	if err := req.Storage.Put(ctx, entry); err != nil {
+		return nil, err
+	}
+
+	if role.ServiceType == dbaasTypeKafka && len(role.KafkaACLs) == 0 {
+		res := &logical.Response{}
+		res.AddWarning(fmt.Sprintf("users created without %s can't access any topic", configDBaaSKafkaACLs))
+		return res, nil
+	}
+
+	return nil, nil
+}
+
+func (b *exoscaleBackend) deleteDBaaSRole(
+	ctx context.Context,
+	req *logical.Request,
+	data *framework.FieldData,
+) (*logical.Response, error) {
+	if err := req.Storage.Delete(ctx, dbaasRoleStoragePathPrefix+data.Get(configVaultRoleName).(string)); err != nil {
+		return nil, err
+	}
+
+	return nil, nil
+}
@@ -0,0 +1,68 @@
+package exoscale
+
+import (
+	"context"
+	"time"
+
+	"github.com/hashicorp/vault/sdk/framework"
+	"github.com/hashicorp/vault/sdk/logical"
+)
+
+const (
+	pathDBaaSStaticCredsHelpSyn  = "Retrieve the current password of a DBaaS static role"
+	pathDBaaSStaticCredsHelpDesc = `
+This endpoint returns the username, current password and connection URI of the
+user of a DBaaS static role. The password is rotated by Vault every rotation_period,
+the ttl field indicates how long until the next rotation.
+`
+)
+
+func (b *exoscaleBackend) pathDBaaSStaticCreds() *framework.Path {
+	return &framework.Path{
+		Pattern: "dbaas-static-creds/" + framework.GenericNameRegex(configVaultRoleName),
+		Fields: map[string]*framework.FieldSchema{
+			configVaultRoleName: {
+				Type:        framework.TypeString,
+				Description: "Name of the DBaaS static role",
+				Required:    true,
+			},
+		},
+
+		Operations: map[logical.Operation]framework.OperationHandler{
+			logical.ReadOperation: &framework.PathOperation{Callback: b.readDBaaSStaticCreds},
+		},
+
+		HelpSynopsis:    pathDBaaSStaticCredsHelpSyn,
+		HelpDescription: pathDBaaSStaticCredsHelpDesc,
+	}
+}
+
+func (b *exoscaleBackend) readDBaaSStaticCreds(
+	ctx context.Context,
+	req *logical.Request,
+	data *framework.FieldData,
+) (*logical.Response, error) {
+	name := data.Get(configVaultRoleName).(string)
+
+	role, err := getDBaaSStaticRole(ctx, req.Storage, name)
+	if err != nil {
+		return nil, err
+	}
+	if role == nil {
+		return logical.ErrorResponse("DBaaS static role %q not found", name), nil
+	}
+	if role.Password == "" {
+		return logical.ErrorResponse("the password of DBaaS static role %q is being rotated, retry later", name), nil
+	}
+
+	return &logical.Response{
+		Data: map[string]interface{}{
+			configDBaaSUsername:            role.Username,
+			"password":                     role.Password,
+			"uri":                          role.URI,
+			"last_rotated":                 role.LastRotated,
+			configStaticRoleRotationPeriod: role.RotationPeriod.Seconds(),
+			"ttl":                          int64(time.Until(role.nextRotation()).Seconds()),
+		},
+	}, nil
+}
@@ -0,0 +1,380 @@
+package exoscale
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/vault/sdk/framework"
+	"github.com/hashicorp/vault/sdk/logical"
+)
+
+// DBaaSStaticRole is a role owning the password of an existing user of a DBaaS service,
+// rotated by the backend
+type DBaaSStaticRole struct {
+	ServiceType    string        `json:"service_type"`
+	Service        string        `json:"service"`
+	Zone           string        `json:"zone,omitempty"`
+	Username       string        `json:"username"`
+	Account        string        `json:"account,omitempty"`
+	RotationPeriod time.Duration `json:"rotation_period"`
+
+	// Current credentials
+	Password    string    `json:"password,omitempty"`
+	URI         string    `json:"uri,omitempty"`
+	LastRotated time.Time `json:"last_rotated,omitempty"`
+}
+
+func (role *DBaaSStaticRole) nextRotation() time.Time {
+	return role.LastRotated.Add(role.RotationPeriod)
+}
+
+const (
+	dbaasStaticRoleStoragePathPrefix = "dbaas-static-role/"
+
+	configDBaaSUsername = "username"
+)
+
+const (
+	pathListDBaaSStaticRolesHelpSyn  = "List the configured DBaaS static roles"
+	pathListDBaaSStaticRolesHelpDesc = `
+This endpoint returns a list of the configured DBaaS static roles.
+`
+
+	pathDBaaSStaticRoleHelpSyn  = "Manage DBaaS static roles"
+	pathDBaaSStaticRoleHelpDesc = `
+Manage DBaaS static roles, each owning the password of an existing user of an
+Exoscale managed database service. The password is reset when the role is created
+and then every rotation_period, through the Exoscale API: Vault doesn't need network
+access to the database. The current password is returned by the
+dbaas-static-creds/<name> endpoint.
+
+Deleting a static role doesn't delete the user, nor reset its password.
+
+Fields:
+	service_type: type of the service, one of pg, mysql, kafka, opensearch
+	service: name of the service
+	username: name of the existing user
+	zone: zone of the service (optional, default: the zone of config/root or of the account)
+	rotation_period: how often the password is rotated (in seconds unless a unit is specified: "s", "m", "h")
+	account: name of the account configured with config/account/<name> owning the service (optional)
+
+Example:
+    vault write exoscale/dbaas-static-role/app-rw \
+	service_type=pg \
+	service=app-db \
+	username=app_rw \
+	rotation_period=24h
+`
+)
+
+func (b *exoscaleBackend) pathDBaaSStaticRole() []*framework.Path {
+	return []*framework.Path{
+		{
+			Pattern: "dbaas-static-role/" + framework.GenericNameRegex(configVaultRoleName),
+			Fields: map[string]*framework.FieldSchema{
+				configVaultRoleName: {
+					Type:        framework.TypeString,
+					Description: "Name of the DBaaS static role",
+					Required:    true,
+				},
+				configDBaaSServiceType: {
+					Type:          framework.TypeString,
+					Description:   "Type of the service",
+					AllowedValues: []interface{}{dbaasTypePg, dbaasTypeMysql, dbaasTypeKafka, dbaasTypeOpensearch},
+				},
+				configDBaaSService: {
+					Type:        framework.TypeString,
+					Description: "Name of the service",
+				},
+				configDBaaSUsername: {
+					Type:        framework.TypeString,
+					Description: "Name of the existing user",
+				},
+				configZone: {
+					Type:        framework.TypeString,
+					Description: "Zone of the service (optional, default: the zone of config/root or of the account)",
+				},
+				configStaticRoleRotationPeriod: {
+					Type:        framework.TypeDurationSecond,
+					Description: "Period after which the password is rotated",
+				},
+				configRoleAccount: {
+					Type:        framework.TypeString,
+					Description: "Name of the account configured with config/account/<name> owning the service (optional)",
+				},
+			},
+
+			Operations: map[logical.Operation]framework.OperationHandler{
+				logical.CreateOperation: &framework.PathOperation{Callback: b.writeDBaaSStaticRole},
+				logical.UpdateOperation: &framework.PathOperation{Callback: b.writeDBaaSStaticRole},
+				logical.ReadOperation:   &framework.PathOperation{Callback: b.readDBaaSStaticRole},
+				logical.DeleteOperation: &framework.PathOperation{Callback: b.deleteDBaaSStaticRole},
+			},
+
+			HelpSynopsis:    pathDBaaSStaticRoleHelpSyn,
+			HelpDescription: pathDBaaSStaticRoleHelpDesc,
+		},
+		{
+			Pattern: "dbaas-static-role/?$",
+
+			Operations: map[logical.Operation]framework.OperationHandler{
+				logical.ListOperation: &framework.PathOperation{Callback: b.listDBaaSStaticRoles},
+			},
+
+			HelpSynopsis:    pathListDBaaSStaticRolesHelpSyn,
+			HelpDescription: pathListDBaaSStaticRolesHelpDesc,
+		},
+	}
+}
+
+func getDBaaSStaticRole(ctx context.Context, storage logical.Storage, name string) (*DBaaSStaticRole, error) {
+	if name == "" {
+		return nil, errors.New("invalid role name")
+	}
+
+	entry, err := storage.Get(ctx, dbaasStaticRoleStoragePathPrefix+name)
+	if err != nil {
+		return nil, fmt.Errorf("unable to retrieve DBaaS static role %q: %w", name, err)
+	}
+	if entry == nil {
+		return nil, nil
+	}
+
+	var role DBaaSStaticRole
+	if err := entry.DecodeJSON(&role); err != nil {
+		return nil, err
+	}
+
+	return &role, nil
+}
+
+func putDBaaSStaticRole(ctx context.Context, storage logical.Storage, name string, role *DBaaSStaticRole) error {
+	entry, err := logical.StorageEntryJSON(dbaasStaticRoleStoragePathPrefix+name, role)
+	if err != nil {
+		return err
+	}
+
+	return storage.Put(ctx, entry)
+}
+
+func (b *exoscaleBackend) listDBaaSStaticRoles(
+	ctx context.Context,
+	req *logical.Request,
+	_ *framework.FieldData,
+) (*logical.Response, error) {
+	roles, err := req.Storage.List(ctx, dbaasStaticRoleStoragePathPrefix)
+	if err != nil {
+		return nil, err
+	}
+
+	return logical.ListResponse(roles), nil
+}
+
+func (b *exoscaleBackend) readDBaaSStaticRole(
+	ctx context.Context,
+	req *logical.Request,
+	data *framework.FieldData,
+) (*logical.Response, error) {
+	role, err := getDBaaSStaticRole(ctx, req.Storage, data.Get(configVaultRoleName).(string))
+	if err != nil {
+		return nil, err
+	}
+	if role == nil {
+		return nil, nil
+	}
+
+	res := &logical.Response{
+		Data: map[string]interface{}{
+			configDBaaSServiceType:         role.ServiceType,
+			configDBaaSService:             role.Service,
+			configDBaaSUsername:            role.Username,
+			configStaticRoleRotationPeriod: role.RotationPeriod.Seconds(),
+			"last_rotated":                 role.LastRotated,
+		},
+	}
+	if role.Zone != "" {
+		res.Data[configZone] = role.Zone
+	}
+	if role.Account != "" {
+		res.Data[configRoleAccount] = role.Account
+	}
+
+	return res, nil
+}
+
+func (b *exoscaleBackend) writeDBaaSStaticRole(
+	ctx context.Context,
+	req *logical.Request,
+	data *framework.FieldData,
+) (*logical.Response, error) {
+	b.dbaasStaticRolesLock.Lock()
+	defer b.dbaasStaticRolesLock.Unlock()
+
+	name := data.Get(configVaultRoleName).(string)
+	role, err := getDBaaSStaticRole(ctx, req.Storage, name)
+	if err != nil {
+		return nil, err
+	}
+	if role == nil {
+		role = &DBaaSStaticRole{}
+	}
+	previous := *role
+
+	if v, ok := data.GetOk(configDBaaSServiceType); ok {
+		role.ServiceType = v.(string)
+	}
+	if !slices.Contains(dbaasTypes, role.ServiceType) {
+		return logical.ErrorResponse("%s must be one of %s", configDBaaSServiceType, strings.Join(dbaasTypes, ", ")), nil
+	}
+
+	if v, ok := data.GetOk(configDBaaSService); ok {
+		role.Service = v.(string)
+	}
+	if role.Service == "" {
+		return logical.ErrorResponse("%s is required", configDBaaSService), nil
+	}
+
+	if v, ok := data.GetOk(configDBaaSUsername); ok {
+		role.Username = v.(string)
+	}
+	if role.Username == "" {
+		return logical.ErrorResponse("%s is required", configDBaaSUsername), nil
+	}
+
+	if v, ok := data.GetOk(configZone); ok {
+		role.Zone = v.(string)
+	}
+
+	if v, ok := data.GetOk(configRoleAccount); ok {
+		role.Account = v.(string)
+		if _, err := b.exoscale(ctx, req.Storage, role.Account); err != nil {
+			return logical.ErrorResponse(err.Error()), nil
+		}
+	}
+
+	if p, ok := data.GetOk(configStaticRoleRotationPeriod); ok {
+		role.RotationPeriod = time.Duration(p.(int)) * time.Second
+	}
+	if role.RotationPeriod < minStaticRoleRotationPeriod {
+		return logical.ErrorResponse("%s must be at least %s", configStaticRoleRotationPeriod, minStaticRoleRotationPeriod), nil
+	}
+
+	// the password is rotated on creation, or when the role targets another user
+	if role.Password == "" ||
+		role.ServiceType != previous.ServiceType ||
+		role.Service != previous.Service ||
+		role.Username != previous.Username ||
+		role.Zone != previous.Zone ||
+		role.Account != previous.Account {
+		if err := b.rotateDBaaSStaticRole(ctx, req.Storage, name, role); err != nil {
+			if errors.Is(err, ErrNotFound) {
+				return logical.ErrorResponse(err.Error()), nil
+			}
+			return nil, err
+		}
+		return nil, nil
+	}
+
+	if err := putDBaaSStaticRole(ctx, req.Storage, name, role); err != nil {
+		return nil, err
+	}
+
+	return nil, nil
+}
+
+func (b *exoscaleBackend) deleteDBaaSStaticRole(
+	ctx context.Context,
+	req *logical.Request,
+	data *framework.FieldData,
+) (*logical.Response, error) {
+	b.dbaasStaticRolesLock.Lock()
+	defer b.dbaasStaticRolesLock.Unlock()
+
+	if err := req.Storage.Delete(ctx, dbaasStaticRoleStoragePathPrefix+data.Get(configVaultRoleName).(string)); err != nil {
+		return nil, err
+	}
+
+	return nil, nil
+}
+
+// rotateDBaaSStaticRole resets the password of the user of a DBaaS static role.
+// Callers must hold dbaasStaticRolesLock.
+func (b *exoscaleBackend) rotateDBaaSStaticRole(
+	ctx context.Context,
+	storage logical.Storage,
+	name string,
+	role *DBaaSStaticRole,
+) error {
+	exo, err := b.exoscale(ctx, storage, role.Account)
+	if err != nil {
+		return err
+	}
+
+	if err := exo.V3ResetDBaaSUserPassword(ctx, role.Zone, role.ServiceType, role.Service, role.Username); err != nil {
+		return fmt.Errorf("unable to rotate DBaaS static role %q: %w", name, err)
+	}
+
+	// the previous password is no longer valid, the new one must be stored even if
+	// fetching it fails, so that the next periodic run retries the rotation
+	role.Password = ""
+	role.URI = ""
+	user, err := exo.V3GetDBaaSUser(ctx, role.Zone, role.ServiceType, role.Service, role.Username)
+	if err == nil && user.Password == "" {
+		err = fmt.Errorf("no password returned for user %q", role.Username)
+	}
+	if err != nil {
+		role.LastRotated = time.Time{}
+		return errors.Join(
+			fmt.Errorf("unable to rotate DBaaS static role %q: %w", name, err),
+			putDBaaSStaticRole(ctx, storage, name, role))
+	}
+
+	role.Password = user.Password
+	role.URI = user.URI
+	role.LastRotated = time.Now()
+	if err := putDBaaSStaticRole(ctx, storage, name, role); err != nil {
+		return err
+	}
+
+	b.Logger().Info("DBaaS static role rotated", "role", name, "service", role.Service, "username", role.Username)
+
+	return nil
+}
+
+// rotateDBaaSStaticRoles rotates the DBaaS static roles whose rotation period has elapsed
+func (b *exoscaleBackend) rotateDBaaSStaticRoles(ctx context.Context, storage logical.Storage) error {
+	b.dbaasStaticRolesLock.Lock()
+	defer b.dbaasStaticRolesLock.Unlock()
+
+	names, err := storage.List(ctx, dbaasStaticRoleStoragePathPrefix)
+	if err != nil {
+		return err
+	}
+
+	var errs error
+	for _, name := range names {
+		role, err := getDBaaSStaticRole(ctx, storage, name)
+		if err != nil {
+			errs = errors.Join(errs, err)
+			continue
+		}
+		if role == nil || time.Now().Before(role.nextRotation()) {
+			continue
+		}
+		if ok, err := b.accountConfigured(ctx, storage, role.Account); !ok || err != nil {
+			errs = errors.Join(errs, err)
+			continue
+		}
+
+		if err := b.rotateDBaaSStaticRole(ctx, storage, name, role); err != nil {
+			b.Logger().Error("Failed to rotate DBaaS static role", "role", name, "err", err)
+			errs = errors.Join(errs, err)
+		}
+	}
+
+	return errs
+}
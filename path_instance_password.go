@@ -0,0 +1,159 @@
+package exoscale
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+
+	"github.com/hashicorp/vault/sdk/framework"
+	"github.com/hashicorp/vault/sdk/logical"
+)
+
+const (
+	configInstanceID           = "instance_id"
+	configInstancePasswordRole = "role"
+)
+
+const (
+	pathInstancePasswordHelpSyn  = "Reveal the password of a compute instance"
+	pathInstancePasswordHelpDesc = `
+This endpoint reveals the password of an Exoscale compute instance allowed by an
+instance password role, as set at the creation of the instance or by the latest
+password reset. It is only available for instances of templates with passwords
+enabled, and only for a limited time after the creation or the reset.
+
+Each retrieval is logged with the identity of the requester, and is tracked by a
+non-renewable lease expiring after the ttl of the role.
+
+The password isn't changed when the lease expires or is revoked: the Exoscale API
+can only reset it by reinstalling the instance. Change it on the instance once done.
+
+Fields:
+	role: name of the instance password role
+	zone: zone of the instance (optional, default: the zone of the role if it has a
+		single one, else the zone of config/root or of the account)
+
+Example:
+    vault read exoscale/instance-password/2f6a5e2e-57b4-4e2f-9a3d-3f7c1b9d2e10 \
+	role=windows-breakglass zone=ch-gva-2
+`
+)
+
+func (b *exoscaleBackend) pathInstancePassword() *framework.Path {
+	return &framework.Path{
+		Pattern: "instance-password/" + framework.GenericNameRegex(configInstanceID),
+		Fields: map[string]*framework.FieldSchema{
+			configInstanceID: {
+				Type:        framework.TypeString,
+				Description: "ID of the instance",
+				Required:    true,
+			},
+			configInstancePasswordRole: {
+				Type:        framework.TypeString,
+				Description: "Name of the instance password role",
+			},
+			configZone: {
+				Type:        framework.TypeString,
+				Description: "Zone of the instance",
+			},
+		},
+
+		Operations: map[logical.Operation]framework.OperationHandler{
+			logical.ReadOperation:   &framework.PathOperation{Callback: b.readInstancePassword},
+			logical.UpdateOperation: &framework.PathOperation{Callback: b.readInstancePassword},
+		},
+
+		HelpSynopsis:    pathInstancePasswordHelpSyn,
+		HelpDescription: pathInstancePasswordHelpDesc,
+	}
+}
+
+func (b *exoscaleBackend) readInstancePassword(
+	ctx context.Context,
+	req *logical.Request,
+	data *framework.FieldData,
+) (*logical.Response, error) {
+	instanceID := data.Get(configInstanceID).(string)
+	roleName := data.Get(configInstancePasswordRole).(string)
+	if roleName == "" {
+		return logical.ErrorResponse("%s is required", configInstancePasswordRole), nil
+	}
+
+	role, err := getInstancePasswordRole(ctx, req.Storage, roleName)
+	if err != nil {
+		return nil, err
+	}
+	if role == nil {
+		return logical.ErrorResponse("instance password role %q not found", roleName), nil
+	}
+
+	zone := data.Get(configZone).(string)
+	if zone == "" && len(role.Zones) > 0 {
+		if len(role.Zones) > 1 {
+			return logical.ErrorResponse("%s is required, role %q allows several zones", configZone, roleName), nil
+		}
+		zone = role.Zones[0]
+	}
+	if !role.allowsZone(zone) {
+		return logical.ErrorResponse("zone %q isn't allowed by role %q", zone, roleName), nil
+	}
+
+	exo, err := b.exoscale(ctx, req.Storage, role.Account)
+	if err != nil {
+		return logical.ErrorResponse(err.Error()), nil
+	}
+
+	if !slices.Contains(role.InstanceIDs, instanceID) {
+		labels, err := exo.V3GetInstanceLabels(ctx, zone, instanceID)
+		if err != nil {
+			if errors.Is(err, ErrNotFound) {
+				return logical.ErrorResponse("instance %q not found", instanceID), nil
+			}
+			return nil, err
+		}
+		if !role.matchesLabels(labels) {
+			return logical.ErrorResponse("instance %q isn't allowed by role %q", instanceID, roleName), nil
+		}
+	}
+
+	password, err := exo.V3RevealInstancePassword(ctx, zone, instanceID)
+	if err != nil {
+		if errors.Is(err, ErrNotFound) {
+			return logical.ErrorResponse("no password available for instance %q", instanceID), nil
+		}
+		return nil, err
+	}
+
+	b.Logger().Info("Instance password revealed",
+		"role", roleName,
+		"instance_id", instanceID,
+		"zone", zone,
+		"display_name", req.DisplayName,
+		"entity_id", req.EntityID)
+
+	res := b.Secret(SecretTypeInstancePassword).Response(
+		map[string]interface{}{
+			"password":       password,
+			configInstanceID: instanceID,
+		},
+		map[string]interface{}{
+			"role":           roleName,
+			configInstanceID: instanceID,
+			configZone:       zone,
+			"display_name":   req.DisplayName,
+			"entity_id":      req.EntityID,
+		})
+
+	res.Secret.TTL = b.System().DefaultLeaseTTL()
+	if role.TTL != 0 {
+		res.Secret.TTL = role.TTL
+	}
+	res.Secret.MaxTTL = res.Secret.TTL
+	res.Secret.Renewable = false
+	res.AddWarning(fmt.Sprintf(
+		"the password of instance %q isn't changed when the lease expires, change it on the instance once done",
+		instanceID))
+
+	return res, nil
+}
@@ -0,0 +1,275 @@
+package exoscale
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/hashicorp/vault/sdk/framework"
+	"github.com/hashicorp/vault/sdk/logical"
+)
+
+// InstancePasswordRole is a role allowing to reveal the password of compute instances
+type InstancePasswordRole struct {
+	InstanceIDs []string          `json:"instance_ids,omitempty"`
+	Labels      map[string]string `json:"labels,omitempty"`
+	Zones       []string          `json:"zones,omitempty"`
+	TTL         time.Duration     `json:"ttl,omitempty"`
+	MaxTTL      time.Duration     `json:"max_ttl,omitempty"`
+	Account     string            `json:"account,omitempty"`
+}
+
+// allowsZone returns whether the instances of a zone can be accessed with the role
+func (r *InstancePasswordRole) allowsZone(zone string) bool {
+	return len(r.Zones) == 0 || slices.Contains(r.Zones, zone)
+}
+
+// matchesLabels returns whether the labels of an instance include all the labels of the role
+func (r *InstancePasswordRole) matchesLabels(labels map[string]string) bool {
+	if len(r.Labels) == 0 {
+		return false
+	}
+	for k, v := range r.Labels {
+		if l, ok := labels[k]; !ok || l != v {
+			return false
+		}
+	}
+
+	return true
+}
+
+const (
+	instancePasswordRoleStoragePathPrefix = "instance-password-role/"
+
+	configInstancePasswordRoleInstanceIDs = "instance_ids"
+	configInstancePasswordRoleLabels      = "labels"
+	configInstancePasswordRoleZones       = "zones"
+)
+
+const (
+	pathListInstancePasswordRolesHelpSyn  = "List the configured instance password roles"
+	pathListInstancePasswordRolesHelpDesc = `
+This endpoint returns a list of the configured instance password roles.
+`
+
+	pathInstancePasswordRoleHelpSyn  = "Manage instance password roles"
+	pathInstancePasswordRoleHelpDesc = `
+Manage instance password roles, allowing to reveal the password of Exoscale compute
+instances with the instance-password/<instance_id> endpoint.
+
+An instance can be accessed with the role if its ID is listed in instance_ids, or if it
+has all the labels of the role, and if it is located in one of the zones of the role.
+
+Fields:
+	instance_ids: comma-separated list of IDs of the instances (optional)
+	labels: labels the instances must have, as a map or a list of key=value (optional)
+	zones: comma-separated list of zones of the instances (optional, default: any zone)
+	ttl: default TTL of the leases (optional, default: the default lease TTL of the mount)
+	max_ttl: maximum TTL of the leases (optional, default: the maximum lease TTL of the mount)
+	account: name of the account configured with config/account/<name> owning the instances (optional)
+
+At least one of instance_ids or labels is required.
+
+Example:
+    vault write exoscale/instance-password-role/windows-breakglass \
+	labels=os=windows,env=prod \
+	zones=ch-gva-2,de-fra-1 \
+	ttl=1h
+`
+)
+
+func (b *exoscaleBackend) pathInstancePasswordRole() []*framework.Path {
+	return []*framework.Path{
+		{
+			Pattern: "instance-password-role/" + framework.GenericNameRegex(configVaultRoleName),
+			Fields: map[string]*framework.FieldSchema{
+				configVaultRoleName: {
+					Type:        framework.TypeString,
+					Description: "Name of the instance password role",
+					Required:    true,
+				},
+				configInstancePasswordRoleInstanceIDs: {
+					Type:        framework.TypeCommaStringSlice,
+					Description: "Comma-separated list of IDs of the instances",
+				},
+				configInstancePasswordRoleLabels: {
+					Type:        framework.TypeKVPairs,
+					Description: "Labels the instances must have",
+				},
+				configInstancePasswordRoleZones: {
+					Type:        framework.TypeCommaStringSlice,
+					Description: "Comma-separated list of zones of the instances (optional, default: any zone)",
+				},
+				configRoleTTL: {
+					Type:        framework.TypeDurationSecond,
+					Description: "Default TTL of the leases",
+				},
+				configRoleMaxTTL: {
+					Type:        framework.TypeDurationSecond,
+					Description: "Maximum TTL of the leases",
+				},
+				configRoleAccount: {
+					Type:        framework.TypeString,
+					Description: "Name of the account configured with config/account/<name> owning the instances (optional)",
+				},
+			},
+
+			Operations: map[logical.Operation]framework.OperationHandler{
+				logical.CreateOperation: &framework.PathOperation{Callback: b.writeInstancePasswordRole},
+				logical.UpdateOperation: &framework.PathOperation{Callback: b.writeInstancePasswordRole},
+				logical.ReadOperation:   &framework.PathOperation{Callback: b.readInstancePasswordRole},
+				logical.DeleteOperation: &framework.PathOperation{Callback: b.deleteInstancePasswordRole},
+			},
+
+			HelpSynopsis:    pathInstancePasswordRoleHelpSyn,
+			HelpDescription: pathInstancePasswordRoleHelpDesc,
+		},
+		{
+			Pattern: "instance-password-role/?$",
+
+			Operations: map[logical.Operation]framework.OperationHandler{
+				logical.ListOperation: &framework.PathOperation{Callback: b.listInstancePasswordRoles},
+			},
+
+			HelpSynopsis:    pathListInstancePasswordRolesHelpSyn,
+			HelpDescription: pathListInstancePasswordRolesHelpDesc,
+		},
+	}
+}
+
+func getInstancePasswordRole(ctx context.Context, storage logical.Storage, name string) (*InstancePasswordRole, error) {
+	if name == "" {
+		return nil, errors.New("invalid role name")
+	}
+
+	entry, err := storage.Get(ctx, instancePasswordRoleStoragePathPrefix+name)
+	if err != nil {
+		return nil, fmt.Errorf("unable to retrieve instance password role %q: %w", name, err)
+	}
+	if entry == nil {
+		return nil, nil
+	}
+
+	var role InstancePasswordRole
+	if err := entry.DecodeJSON(&role); err != nil {
+		return nil, err
+	}
+
+	return &role, nil
+}
+
+func (b *exoscaleBackend) listInstancePasswordRoles(
+	ctx context.Context,
+	req *logical.Request,
+	_ *framework.FieldData,
+) (*logical.Response, error) {
+	roles, err := req.Storage.List(ctx, instancePasswordRoleStoragePathPrefix)
+	if err != nil {
+		return nil, err
+	}
+
+	return logical.ListResponse(roles), nil
+}
+
+func (b *exoscaleBackend) readInstancePasswordRole(
+	ctx context.Context,
+	req *logical.Request,
+	data *framework.FieldData,
+) (*logical.Response, error) {
+	role, err := getInstancePasswordRole(ctx, req.Storage, data.Get(configVaultRoleName).(string))
+	if err != nil {
+		return nil, err
+	}
+	if role == nil {
+		return nil, nil
+	}
+
+	res := &logical.Response{
+		Data: map[string]interface{}{
+			configInstancePasswordRoleInstanceIDs: role.InstanceIDs,
+			configInstancePasswordRoleLabels:      role.Labels,
+			configInstancePasswordRoleZones:       role.Zones,
+			configRoleTTL:                         role.TTL.Seconds(),
+			configRoleMaxTTL:                      role.MaxTTL.Seconds(),
+		},
+	}
+	if role.Account != "" {
+		res.Data[configRoleAccount] = role.Account
+	}
+
+	return res, nil
+}
+
+func (b *exoscaleBackend) writeInstancePasswordRole(
+	ctx context.Context,
+	req *logical.Request,
+	data *framework.FieldData,
+) (*logical.Response, error) {
+	b.rolesLock.Lock()
+	defer b.rolesLock.Unlock()
+
+	name := data.Get(configVaultRoleName).(string)
+	role, err := getInstancePasswordRole(ctx, req.Storage, name)
+	if err != nil {
+		return nil, err
+	}
+	if role == nil {
+		role = &InstancePasswordRole{}
+	}
+
+	if v, ok := data.GetOk(configInstancePasswordRoleInstanceIDs); ok {
+		role.InstanceIDs = v.([]string)
+	}
+	if v, ok := data.GetOk(configInstancePasswordRoleLabels); ok {
+		role.Labels = v.(map[string]string)
+	}
+	if len(role.InstanceIDs) == 0 && len(role.Labels) == 0 {
+		return logical.ErrorResponse("at least one of %s or %s is required",
+			configInstancePasswordRoleInstanceIDs, configInstancePasswordRoleLabels), nil
+	}
+
+	if v, ok := data.GetOk(configInstancePasswordRoleZones); ok {
+		role.Zones = v.([]string)
+	}
+
+	if v, ok := data.GetOk(configRoleTTL); ok {
+		role.TTL = time.Duration(v.(int)) * time.Second
+	}
+	if v, ok := data.GetOk(configRoleMaxTTL); ok {
+		role.MaxTTL = time.Duration(v.(int)) * time.Second
+	}
+	if role.MaxTTL != 0 && role.TTL > role.MaxTTL {
+		return logical.ErrorResponse("%s can't be greater than %s", configRoleTTL, configRoleMaxTTL), nil
+	}
+
+	if v, ok := data.GetOk(configRoleAccount); ok {
+		role.Account = v.(string)
+		if _, err := b.exoscale(ctx, req.Storage, role.Account); err != nil {
+			return logical.ErrorResponse(err.Error()), nil
+		}
+	}
+
+	entry, err := logical.StorageEntryJSON(instancePasswordRoleStoragePathPrefix+name, role)
+	if err != nil {
+		return nil, err
+	}
+	if err := req.Storage.Put(ctx, entry); err != nil {
+		return nil, err
+	}
+
+	return nil, nil
+}
+
+func (b *exoscaleBackend) deleteInstancePasswordRole(
+	ctx context.Context,
+	req *logical.Request,
+	data *framework.FieldData,
+) (*logical.Response, error) {
+	if err := req.Storage.Delete(ctx, instancePasswordRoleStoragePathPrefix+data.Get(configVaultRoleName).(string)); err != nil {
+		return nil, err
+	}
+
+	return nil, nil
+}
@@ -0,0 +1,95 @@
+package exoscale
+
+import (
+	"context"
+
+	"github.com/hashicorp/vault/sdk/logical"
+	"github.com/stretchr/testify/mock"
+
+	"github.com/exoscale/egoscale/v2/oapi"
+)
+
+func (ts *testSuite) TestPathInstancePassword() {
+	res, err := ts.backend.HandleRequest(context.Background(), &logical.Request{
+		Storage:   ts.storage,
+		Operation: logical.CreateOperation,
+		Path:      instancePasswordRoleStoragePathPrefix + "windows",
+		Data: map[string]interface{}{
+			configInstancePasswordRoleZones: "ch-gva-2",
+		},
+	})
+	ts.Require().NoError(err)
+	ts.Require().ErrorContains(res.Error(), "at least one of")
+
+	res, err = ts.backend.HandleRequest(context.Background(), &logical.Request{
+		Storage:   ts.storage,
+		Operation: logical.CreateOperation,
+		Path:      instancePasswordRoleStoragePathPrefix + "windows",
+		Data: map[string]interface{}{
+			configInstancePasswordRoleLabels: []string{"os=windows"},
+			configInstancePasswordRoleZones:  "ch-gva-2",
+			configRoleTTL:                    "30m",
+		},
+	})
+	ts.Require().NoError(err)
+	ts.Require().Nil(res)
+
+	allowed, denied := ts.randomID(), ts.randomID()
+	mockClient := ts.backend.(*exoscaleBackend).exo.egoscaleClient.(*mockEgoscaleClient)
+	mockClient.
+		On("GetInstanceWithResponse", mock.Anything, allowed).
+		Return(&oapi.GetInstanceResponse{JSON200: &oapi.Instance{
+			Labels: &oapi.Labels{AdditionalProperties: map[string]string{"os": "windows", "env": "prod"}},
+		}}, nil)
+	mockClient.
+		On("GetInstanceWithResponse", mock.Anything, denied).
+		Return(&oapi.GetInstanceResponse{JSON200: &oapi.Instance{
+			Labels: &oapi.Labels{AdditionalProperties: map[string]string{"os": "linux"}},
+		}}, nil)
+	mockClient.
+		On("RevealInstancePasswordWithResponse", mock.Anything, allowed).
+		Return(&oapi.RevealInstancePasswordResponse{JSON200: &oapi.InstancePassword{Password: ptr("s3cr3t")}}, nil).
+		Once()
+
+	res, err = ts.backend.HandleRequest(context.Background(), &logical.Request{
+		Storage:   ts.storage,
+		Operation: logical.UpdateOperation,
+		Path:      "instance-password/" + allowed,
+		Data:      map[string]interface{}{configInstancePasswordRole: "windows", configZone: "de-fra-1"},
+	})
+	ts.Require().NoError(err)
+	ts.Require().ErrorContains(res.Error(), "isn't allowed")
+
+	res, err = ts.backend.HandleRequest(context.Background(), &logical.Request{
+		Storage:   ts.storage,
+		Operation: logical.UpdateOperation,
+		Path:      "instance-password/" + denied,
+		Data:      map[string]interface{}{configInstancePasswordRole: "windows"},
+	})
+	ts.Require().NoError(err)
+	ts.Require().ErrorContains(res.Error(), "isn't allowed")
+
+	secret, err := ts.backend.HandleRequest(context.Background(), &logical.Request{
+		Storage:     ts.storage,
+		Operation:   logical.UpdateOperation,
+		Path:        "instance-password/" + allowed,
+		Data:        map[string]interface{}{configInstancePasswordRole: "windows"},
+		DisplayName: "alice",
+	})
+	ts.Require().NoError(err)
+	ts.Require().Equal("s3cr3t", secret.Data["password"])
+	ts.Require().Equal("ch-gva-2", secret.Secret.InternalData[configZone])
+	ts.Require().Equal("alice", secret.Secret.InternalData["display_name"])
+	ts.Require().Equal(30*60.0, secret.Secret.TTL.Seconds())
+	ts.Require().False(secret.Secret.Renewable)
+	ts.Require().NotEmpty(secret.Warnings)
+
+	_, err = ts.backend.HandleRequest(context.Background(), &logical.Request{
+		Storage:   ts.storage,
+		Operation: logical.RevokeOperation,
+		Path:      secret.Secret.LeaseID,
+		Secret:    secret.Secret,
+	})
+	ts.Require().NoError(err)
+	mockClient.AssertExpectations(ts.T())
+}
@@ -0,0 +1,90 @@
+package exoscale
+
+import (
+	"context"
+
+	"github.com/hashicorp/vault/sdk/framework"
+	"github.com/hashicorp/vault/sdk/logical"
+)
+
+const (
+	pathListIssuedHelpSyn  = "List the API keys issued by the backend"
+	pathListIssuedHelpDesc = `
+This endpoint returns the list of the API keys issued for leases which haven't
+been revoked yet, see lookup/ for revoked keys.
+`
+
+	pathIssuedHelpSyn  = "Read the record of an issued API key"
+	pathIssuedHelpDesc = `
+This endpoint returns the record of an API key issued for a lease: its name, the
+role it was issued from, the display name, entity ID, entity name and aliases of
+the requester, the mount path, the IAM version and when the lease expires. The API
+secret is never recorded.
+
+Keys issued before the backend started recording issued keys aren't listed.
+
+Example:
+    vault read exoscale/issued/EXO...
+`
+)
+
+func (b *exoscaleBackend) pathIssued() []*framework.Path {
+	return []*framework.Path{
+		{
+			Pattern: "issued/" + framework.GenericNameRegex(apiKeySecretDataAPIKey),
+			Fields: map[string]*framework.FieldSchema{
+				apiKeySecretDataAPIKey: {
+					Type:        framework.TypeString,
+					Description: "API key",
+					Required:    true,
+				},
+			},
+
+			Operations: map[logical.Operation]framework.OperationHandler{
+				logical.ReadOperation: &framework.PathOperation{Callback: b.pathIssuedRead},
+			},
+
+			HelpSynopsis:    pathIssuedHelpSyn,
+			HelpDescription: pathIssuedHelpDesc,
+		},
+		{
+			Pattern: "issued/?$",
+
+			Operations: map[logical.Operation]framework.OperationHandler{
+				logical.ListOperation: &framework.PathOperation{Callback: b.pathIssuedList},
+			},
+
+			HelpSynopsis:    pathListIssuedHelpSyn,
+			HelpDescription: pathListIssuedHelpDesc,
+		},
+	}
+}
+
+func (b *exoscaleBackend) pathIssuedList(
+	ctx context.Context,
+	req *logical.Request,
+	_ *framework.FieldData,
+) (*logical.Response, error) {
+	keys, err := req.Storage.List(ctx, issuedKeyStoragePathPrefix)
+	if err != nil {
+		return nil, err
+	}
+
+	return logical.ListResponse(keys), nil
+}
+
+func (b *exoscaleBackend) pathIssuedRead(
+	ctx context.Context,
+	req *logical.Request,
+	data *framework.FieldData,
+) (*logical.Response, error) {
+	issued, err := getIssuedKey(ctx, req.Storage, data.Get(apiKeySecretDataAPIKey).(string))
+	if err != nil {
+		return nil, err
+	}
+	if issued == nil {
+		return nil, nil
+	}
+
+	return &logical.Response{Data: issued.responseData()}, nil
+}
@@ -0,0 +1,87 @@
+package exoscale
+
+import (
+	"context"
+	"time"
+
+	"github.com/hashicorp/vault/sdk/logical"
+	"github.com/stretchr/testify/mock"
+
+	"github.com/exoscale/egoscale/v2/oapi"
+)
+
+func (ts *testSuite) TestPathIssued() {
+	ts.storeEntry(roleStoragePathPrefix+testRoleName, Role{
+		IAMRoleID:   "f7956761-068e-488b-b1b4-29790b58a697",
+		IAMRoleName: "iamrole-blabla",
+		Renewable:   true,
+		TTL:         time.Hour,
+		Version:     "v3",
+	})
+
+	state := oapi.OperationStateSuccess
+	mockClient := ts.backend.(*exoscaleBackend).exo.egoscaleClient.(*mockEgoscaleClient)
+	mockClient.
+		On("CreateApiKeyWithResponse", mock.Anything, mock.Anything).
+		Return(&oapi.CreateApiKeyResponse{
+			JSON200: &oapi.IamApiKeyCreated{
+				Key:    &testIAMAccessKeyKey,
+				Name:   ptr("vault-" + testRoleName),
+				RoleId: ptr("f7956761-068e-488b-b1b4-29790b58a697"),
+				Secret: &testIAMAccessKeySecret,
+			},
+		}, nil)
+	mockClient.
+		On("DeleteApiKeyWithResponse", mock.Anything, testIAMAccessKeyKey).
+		Return(&oapi.DeleteApiKeyResponse{JSON200: &oapi.Operation{State: &state}}, nil)
+
+	secret, err := ts.backend.HandleRequest(context.Background(), &logical.Request{
+		Storage:     ts.storage,
+		Operation:   logical.ReadOperation,
+		Path:        "apikey/" + testRoleName,
+		DisplayName: "test",
+		EntityID:    "3d1c6a4e-9d0b-4a4b-8f3e-1b7c0f5b2a1d",
+	})
+	ts.Require().NoError(err)
+
+	res, err := ts.backend.HandleRequest(context.Background(), &logical.Request{
+		Storage:   ts.storage,
+		Operation: logical.ListOperation,
+		Path:      issuedKeyStoragePathPrefix,
+	})
+	ts.Require().NoError(err)
+	ts.Require().Equal([]string{testIAMAccessKeyKey}, res.Data["keys"])
+
+	res, err = ts.backend.HandleRequest(context.Background(), &logical.Request{
+		Storage:   ts.storage,
+		Operation: logical.ReadOperation,
+		Path:      issuedKeyStoragePathPrefix + testIAMAccessKeyKey,
+	})
+	ts.Require().NoError(err)
+	ts.Require().Equal("vault-"+testRoleName, res.Data["name"])
+	ts.Require().Equal(testRoleName, res.Data["role"])
+	ts.Require().Equal("test", res.Data["display_name"])
+	ts.Require().Equal("3d1c6a4e-9d0b-4a4b-8f3e-1b7c0f5b2a1d", res.Data["entity_id"])
+	ts.Require().Equal("v3", res.Data["version"])
+	ts.Require().NotContains(res.Data, apiKeySecretDataAPISecret)
+
+	expiresAt, err := time.Parse(time.RFC3339, res.Data["expires_at"].(string))
+	ts.Require().NoError(err)
+	ts.Require().WithinDuration(time.Now().Add(time.Hour), expiresAt, time.Minute)
+
+	_, err = ts.backend.HandleRequest(context.Background(), &logical.Request{
+		Storage:   ts.storage,
+		Operation: logical.RevokeOperation,
+		Path:      secret.Secret.LeaseID,
+		Secret:    secret.Secret,
+	})
+	ts.Require().NoError(err)
+
+	res, err = ts.backend.HandleRequest(context.Background(), &logical.Request{
+		Storage:   ts.storage,
+		Operation: logical.ReadOperation,
+		Path:      issuedKeyStoragePathPrefix + testIAMAccessKeyKey,
+	})
+	ts.Require().NoError(err)
+	ts.Require().Nil(res)
+}
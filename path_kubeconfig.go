@@ -0,0 +1,137 @@
+package exoscale
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/hashicorp/vault/sdk/framework"
+	"github.com/hashicorp/vault/sdk/logical"
+)
+
+const (
+	pathKubeconfigHelpSyn  = "Issue a kubeconfig for an SKS cluster"
+	pathKubeconfigHelpDesc = `
+This endpoint generates a kubeconfig for the SKS cluster of a role, whose client
+certificate is valid for as long as the Vault lease, the lease can't be renewed.
+
+SKS certificates can't be revoked individually: revoking the lease doesn't invalidate
+the kubeconfig, it remains valid until expires_at. Use short TTLs.
+
+Example:
+    vault read exoscale/kubeconfig/prod-readonly ttl=30m
+`
+)
+
+func (b *exoscaleBackend) pathKubeconfig() *framework.Path {
+	return &framework.Path{
+		Pattern: "kubeconfig/" + framework.GenericNameRegex(configVaultRoleName),
+		Fields: map[string]*framework.FieldSchema{
+			configVaultRoleName: {
+				Type:        framework.TypeString,
+				Description: "Name of the SKS role",
+				Required:    true,
+			},
+			configRoleTTL: {
+				Type:        framework.TypeDurationSecond,
+				Description: "Validity of the certificate (optional, default: the ttl of the role)",
+			},
+		},
+
+		Operations: map[logical.Operation]framework.OperationHandler{
+			logical.ReadOperation:   &framework.PathOperation{Callback: b.readKubeconfig},
+			logical.UpdateOperation: &framework.PathOperation{Callback: b.readKubeconfig},
+		},
+
+		HelpSynopsis:    pathKubeconfigHelpSyn,
+		HelpDescription: pathKubeconfigHelpDesc,
+	}
+}
+
+func (b *exoscaleBackend) readKubeconfig(
+	ctx context.Context,
+	req *logical.Request,
+	data *framework.FieldData,
+) (*logical.Response, error) {
+	roleName := data.Get(configVaultRoleName).(string)
+	role, err := getSKSRole(ctx, req.Storage, roleName)
+	if err != nil {
+		return nil, err
+	}
+	if role == nil {
+		return logical.ErrorResponse("SKS role %q not found", roleName), nil
+	}
+
+	user := role.User
+	if user == "" {
+		user = req.DisplayName
+	}
+	if user == "" {
+		return logical.ErrorResponse("the role doesn't set a %s and the request has no display name", configSKSRoleUser), nil
+	}
+
+	var warnings []string
+
+	ttl := role.TTL
+	if v, ok := data.GetOk(configRoleTTL); ok {
+		ttl = time.Duration(v.(int)) * time.Second
+	}
+	if ttl == 0 {
+		ttl = b.System().DefaultLeaseTTL()
+	}
+
+	// the certificate must not outlive the lease
+	maxTTL := b.System().MaxLeaseTTL()
+	if role.MaxTTL > 0 && role.MaxTTL < maxTTL {
+		maxTTL = role.MaxTTL
+	}
+	if ttl > maxTTL {
+		warnings = append(warnings, fmt.Sprintf("ttl of %s is capped to the max_ttl of %s", ttl, maxTTL))
+		ttl = maxTTL
+	}
+	if ttl < minKubeconfigTTL {
+		return logical.ErrorResponse("%s must be at least %s", configRoleTTL, minKubeconfigTTL), nil
+	}
+
+	exo, err := b.exoscale(ctx, req.Storage, role.Account)
+	if err != nil {
+		return logical.ErrorResponse(err.Error()), nil
+	}
+
+	kubeconfig, err := exo.V3GenerateSKSKubeconfig(ctx, role.Zone, role.ClusterID, user, role.Groups, ttl)
+	if err != nil {
+		return nil, err
+	}
+	expiresAt := time.Now().Add(ttl)
+
+	res := b.Secret(SecretTypeKubeconfig).Response(
+		map[string]interface{}{
+			"kubeconfig":           kubeconfig,
+			configSKSRoleClusterID: role.ClusterID,
+			configSKSRoleUser:      user,
+			configSKSRoleGroups:    role.Groups,
+			"expires_at":           expiresAt.Format(time.RFC3339),
+		},
+		map[string]interface{}{
+			"role":                 roleName,
+			configSKSRoleClusterID: role.ClusterID,
+			configSKSRoleUser:      user,
+			"expires_at":           expiresAt.Format(time.RFC3339),
+		})
+	res.Secret.TTL = ttl
+	res.Secret.MaxTTL = ttl
+	res.Secret.Renewable = false
+	res.Warnings = append(warnings, fmt.Sprintf(
+		"the certificate of the kubeconfig can't be revoked, it remains valid until %s even if the lease is revoked",
+		expiresAt.Format(time.RFC3339)))
+
+	b.Logger().Info("Kubeconfig issued",
+		"role", roleName,
+		"cluster_id", role.ClusterID,
+		"user", user,
+		"groups", role.Groups,
+		"ttl", fmt.Sprint(ttl),
+		"entity_id", req.EntityID)
+
+	return res, nil
+}
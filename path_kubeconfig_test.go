@@ -0,0 +1,86 @@
+package exoscale
+
+import (
+	"context"
+	"encoding/base64"
+	"time"
+
+	"github.com/hashicorp/vault/sdk/logical"
+	"github.com/stretchr/testify/mock"
+
+	"github.com/exoscale/egoscale/v2/oapi"
+)
+
+func (ts *testSuite) TestPathKubeconfig() {
+	clusterID := ts.randomID()
+
+	res, err := ts.backend.HandleRequest(context.Background(), &logical.Request{
+		Storage:   ts.storage,
+		Operation: logical.CreateOperation,
+		Path:      sksRoleStoragePathPrefix + "prod",
+		Data: map[string]interface{}{
+			configSKSRoleGroups: "view",
+			configRoleTTL:       "1h",
+		},
+	})
+	ts.Require().NoError(err)
+	ts.Require().ErrorContains(res.Error(), configSKSRoleClusterID)
+
+	res, err = ts.backend.HandleRequest(context.Background(), &logical.Request{
+		Storage:   ts.storage,
+		Operation: logical.CreateOperation,
+		Path:      sksRoleStoragePathPrefix + "prod",
+		Data: map[string]interface{}{
+			configSKSRoleClusterID: clusterID,
+			configSKSRoleGroups:    "view,developers",
+			configRoleTTL:          "1h",
+			configRoleMaxTTL:       "2h",
+		},
+	})
+	ts.Require().NoError(err)
+	ts.Require().Nil(res)
+
+	var body oapi.GenerateSksClusterKubeconfigJSONRequestBody
+	mockClient := ts.backend.(*exoscaleBackend).exo.egoscaleClient.(*mockEgoscaleClient)
+	mockClient.
+		On("GenerateSksClusterKubeconfigWithResponse", mock.Anything, clusterID, mock.Anything).
+		Run(func(args mock.Arguments) {
+			body = args.Get(2).(oapi.GenerateSksClusterKubeconfigJSONRequestBody)
+		}).
+		Return(&oapi.GenerateSksClusterKubeconfigResponse{
+			JSON200: &struct {
+				Kubeconfig *string `json:"kubeconfig,omitempty"`
+			}{
+				Kubeconfig: ptr(base64.StdEncoding.EncodeToString([]byte("apiVersion: v1\nkind: Config\n"))),
+			},
+		}, nil)
+
+	secret, err := ts.backend.HandleRequest(context.Background(), &logical.Request{
+		Storage:     ts.storage,
+		Operation:   logical.ReadOperation,
+		Path:        "kubeconfig/prod",
+		DisplayName: "oidc-alice",
+		Data:        map[string]interface{}{configRoleTTL: "3h"},
+	})
+	ts.Require().NoError(err)
+	ts.Require().Equal("apiVersion: v1\nkind: Config\n", secret.Data["kubeconfig"])
+	ts.Require().Equal("oidc-alice", *body.User)
+	ts.Require().Equal([]string{"view", "developers"}, *body.Groups)
+	ts.Require().Equal(int64(2*time.Hour/time.Second), *body.Ttl)
+
+	// the lease matches the validity of the certificate
+	ts.Require().Equal(2*time.Hour, secret.Secret.TTL)
+	ts.Require().False(secret.Secret.Renewable)
+	ts.Require().Len(secret.Warnings, 2)
+	expiresAt, err := time.Parse(time.RFC3339, secret.Data["expires_at"].(string))
+	ts.Require().NoError(err)
+	ts.Require().WithinDuration(time.Now().Add(2*time.Hour), expiresAt, time.Minute)
+
+	_, err = ts.backend.HandleRequest(context.Background(), &logical.Request{
+		Storage:   ts.storage,
+		Operation: logical.RevokeOperation,
+		Path:      secret.Secret.LeaseID,
+		Secret:    secret.Secret,
+	})
+	ts.Require().NoError(err)
+}
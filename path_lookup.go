@@ -0,0 +1,163 @@
+package exoscale
+
+import (
+	"context"
+	"time"
+
+	"github.com/hashicorp/vault/sdk/framework"
+	"github.com/hashicorp/vault/sdk/logical"
+)
+
+const (
+	lookupStatusActive     = "active"
+	lookupStatusRevoked    = "revoked"
+	lookupStatusPooled     = "pooled"
+	lookupStatusStaticRole = "static-role"
+	lookupStatusRoot       = "root"
+)
+
+const (
+	pathLookupHelpSyn  = "Find which Vault identity requested an Exoscale API key"
+	pathLookupHelpDesc = `
+The IAM API doesn't support labels on API keys, this endpoint maps the ID of an
+API key found in the Exoscale audit logs to the Vault identity which requested it.
+
+For API keys issued for leases, it returns the role, the display name, entity ID,
+entity name and aliases of the requester, the mount path and the lease ID (known once
+the lease is renewed or revoked). Records of revoked keys are kept for 30 days.
+
+The status of the key is one of: active, revoked, pooled (created in advance and not
+handed out yet), static-role or root (root API key of config/root or of an account).
+
+Example:
+    vault read exoscale/lookup/EXO...
+`
+)
+
+func (b *exoscaleBackend) pathLookup() *framework.Path {
+	return &framework.Path{
+		Pattern: "lookup/" + framework.GenericNameRegex(apiKeySecretDataAPIKey),
+		Fields: map[string]*framework.FieldSchema{
+			apiKeySecretDataAPIKey: {
+				Type:        framework.TypeString,
+				Description: "ID of the Exoscale API key",
+				Required:    true,
+			},
+		},
+
+		Operations: map[logical.Operation]framework.OperationHandler{
+			logical.ReadOperation: &framework.PathOperation{Callback: b.pathLookupRead},
+		},
+
+		HelpSynopsis:    pathLookupHelpSyn,
+		HelpDescription: pathLookupHelpDesc,
+	}
+}
+
+func (b *exoscaleBackend) pathLookupRead(
+	ctx context.Context,
+	req *logical.Request,
+	data *framework.FieldData,
+) (*logical.Response, error) {
+	key := data.Get(apiKeySecretDataAPIKey).(string)
+
+	issued, err := getIssuedKey(ctx, req.Storage, key)
+	if err != nil {
+		return nil, err
+	}
+	if issued != nil {
+		return lookupResponse(lookupStatusActive, issued.responseData()), nil
+	}
+
+	revoked, err := getRevokedKey(ctx, req.Storage, key)
+	if err != nil {
+		return nil, err
+	}
+	if revoked != nil {
+		return lookupResponse(lookupStatusRevoked, revoked.responseData()), nil
+	}
+
+	pooledRoles, err := b.pooledRoles(ctx, req.Storage)
+	if err != nil {
+		return nil, err
+	}
+	for roleName := range pooledRoles {
+		pooled, err := listPooledKeys(ctx, req.Storage, roleName)
+		if err != nil {
+			return nil, err
+		}
+		for _, k := range pooled {
+			if k.Key == key {
+				return lookupResponse(lookupStatusPooled, map[string]interface{}{
+					"api_key": k.Key,
+					"name":    k.Name,
+					"role":    roleName,
+					"account": k.Account,
+				}), nil
+			}
+		}
+	}
+
+	staticRoles, err := req.Storage.List(ctx, staticRoleStoragePathPrefix)
+	if err != nil {
+		return nil, err
+	}
+	for _, name := range staticRoles {
+		role, err := getStaticRole(ctx, req.Storage, name)
+		if err != nil {
+			return nil, err
+		}
+		if role != nil && (role.APIKey == key || role.PreviousAPIKey == key) {
+			return lookupResponse(lookupStatusStaticRole, map[string]interface{}{
+				"api_key":     key,
+				"static_role": name,
+			}), nil
+		}
+	}
+
+	rootConfig, err := getRootConfig(ctx, req.Storage)
+	if err != nil {
+		return nil, err
+	}
+	if rootConfig != nil && rootConfig.RootAPIKey == key {
+		return lookupResponse(lookupStatusRoot, map[string]interface{}{"api_key": key}), nil
+	}
+
+	accounts, err := req.Storage.List(ctx, configAccountStoragePathPrefix)
+	if err != nil {
+		return nil, err
+	}
+	for _, name := range accounts {
+		config, err := getAccountConfig(ctx, req.Storage, name)
+		if err != nil {
+			return nil, err
+		}
+		if config != nil && config.RootAPIKey == key {
+			return lookupResponse(lookupStatusRoot, map[string]interface{}{
+				"api_key": key,
+				"account": name,
+			}), nil
+		}
+	}
+
+	return logical.ErrorResponse("API key %q is unknown to this backend", key), nil
+}
+
+func lookupResponse(status string, data map[string]interface{}) *logical.Response {
+	data["status"] = status
+	return &logical.Response{Data: data}
+}
+
+// purgeRevokedKeysIfDue deletes the expired records of revoked keys every revokedKeyPurgeInterval
+func (b *exoscaleBackend) purgeRevokedKeysIfDue(ctx context.Context, storage logical.Storage) error {
+	if time.Since(b.lastRevokedPurge) < revokedKeyPurgeInterval {
+		return nil
+	}
+
+	if err := purgeRevokedKeys(ctx, storage); err != nil {
+		return err
+	}
+	b.lastRevokedPurge = time.Now()
+
+	return nil
+}
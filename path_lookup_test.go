@@ -0,0 +1,89 @@
+package exoscale
+
+import (
+	"context"
+	"time"
+
+	"github.com/hashicorp/vault/sdk/logical"
+)
+
+func (ts *testSuite) lookup(key string) *logical.Response {
+	res, err := ts.backend.HandleRequest(context.Background(), &logical.Request{
+		Storage:   ts.storage,
+		Operation: logical.ReadOperation,
+		Path:      "lookup/" + key,
+	})
+	ts.Require().NoError(err)
+
+	return res
+}
+
+func (ts *testSuite) TestPathLookup() {
+	ts.storeEntry(roleStoragePathPrefix+testRoleName, Role{
+		IAMRoleID: ts.randomID(),
+		Version:   "v3",
+		Renewable: true,
+		TTL:       time.Hour,
+	})
+	ts.mockCreateAPIKey()
+
+	secret, err := ts.backend.HandleRequest(context.Background(), &logical.Request{
+		Storage:     ts.storage,
+		Operation:   logical.ReadOperation,
+		Path:        "apikey/" + testRoleName,
+		DisplayName: "oidc-alice",
+		EntityID:    "3d1c6a4e-9d0b-4a4b-8f3e-1b7c0f5b2a1d",
+		MountPoint:  "exoscale/",
+	})
+	ts.Require().NoError(err)
+
+	res := ts.lookup(testIAMAccessKeyKey)
+	ts.Require().Equal(lookupStatusActive, res.Data["status"])
+	ts.Require().Equal(testRoleName, res.Data["role"])
+	ts.Require().Equal("oidc-alice", res.Data["display_name"])
+	ts.Require().Equal("3d1c6a4e-9d0b-4a4b-8f3e-1b7c0f5b2a1d", res.Data["entity_id"])
+	ts.Require().Equal("exoscale/", res.Data["mount_point"])
+	ts.Require().NotContains(res.Data, "revoked_at")
+
+	_, err = ts.backend.HandleRequest(context.Background(), &logical.Request{
+		Storage:   ts.storage,
+		Operation: logical.RevokeOperation,
+		Path:      secret.Secret.LeaseID,
+		Secret:    secret.Secret,
+	})
+	ts.Require().NoError(err)
+
+	res = ts.lookup(testIAMAccessKeyKey)
+	ts.Require().Equal(lookupStatusRevoked, res.Data["status"])
+	ts.Require().Equal("3d1c6a4e-9d0b-4a4b-8f3e-1b7c0f5b2a1d", res.Data["entity_id"])
+	ts.Require().Equal(secret.Secret.LeaseID, res.Data["lease_id"])
+	revokedAt, err := time.Parse(time.RFC3339, res.Data["revoked_at"].(string))
+	ts.Require().NoError(err)
+	ts.Require().WithinDuration(time.Now(), revokedAt, time.Minute)
+
+	// expired records of revoked keys are purged
+	ts.Require().NoError(ts.storage.Put(context.Background(), &logical.StorageEntry{
+		Key: revokedKeyStoragePathPrefix + "EXOexpired",
+		Value: []byte(`{"key":"EXOexpired","revoked_at":"` +
+			time.Now().Add(-revokedKeyRetention-time.Hour).Format(time.RFC3339) + `"}`),
+	}))
+	ts.Require().NoError(purgeRevokedKeys(context.Background(), ts.storage))
+	ts.Require().True(ts.lookup("EXOexpired").IsError())
+	ts.Require().Equal(lookupStatusRevoked, ts.lookup(testIAMAccessKeyKey).Data["status"])
+}
+
+func (ts *testSuite) TestPathLookupNotIssued() {
+	ts.storeEntry(staticRoleStoragePathPrefix+testRoleName, StaticRole{
+		IAMRoleID: ts.randomID(),
+		APIKey:    "EXOstatic",
+	})
+
+	res := ts.lookup("EXOstatic")
+	ts.Require().Equal(lookupStatusStaticRole, res.Data["status"])
+	ts.Require().Equal(testRoleName, res.Data["static_role"])
+
+	res = ts.lookup("EXO0000")
+	ts.Require().Equal(lookupStatusRoot, res.Data["status"])
+
+	ts.Require().True(ts.lookup("EXOunknown").IsError())
+}
@@ -0,0 +1,158 @@
+package exoscale
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/vault/sdk/framework"
+	"github.com/hashicorp/vault/sdk/logical"
+
+	"github.com/exoscale/egoscale/v2/oapi"
+)
+
+const (
+	// quotaResourceIAMKey is the quota resource of the API keys of an organization
+	quotaResourceIAMKey = "iam-key"
+
+	// iamQuotaPrefix is the prefix of the IAM quota resources
+	iamQuotaPrefix = "iam"
+)
+
+const (
+	pathQuotaHelpSyn  = "Report the IAM quotas of the Exoscale organization"
+	pathQuotaHelpDesc = `
+This endpoint returns the usage and the limit (-1 for unlimited) of the IAM quotas
+of the organization of config/root, or of an account with account=<name>.
+
+A warning is returned for each quota whose usage reaches quota_warning_threshold
+(see config/root).
+
+Example:
+    vault read exoscale/quota
+    vault read exoscale/quota account=staging
+`
+)
+
+func (b *exoscaleBackend) pathQuota() *framework.Path {
+	return &framework.Path{
+		Pattern: "quota",
+		Fields: map[string]*framework.FieldSchema{
+			configRoleAccount: {
+				Type:        framework.TypeString,
+				Description: "Name of the account configured with config/account/<name> (optional, default: config/root)",
+			},
+		},
+
+		Operations: map[logical.Operation]framework.OperationHandler{
+			logical.ReadOperation: &framework.PathOperation{Callback: b.pathQuotaRead},
+		},
+
+		HelpSynopsis:    pathQuotaHelpSyn,
+		HelpDescription: pathQuotaHelpDesc,
+	}
+}
+
+func (b *exoscaleBackend) pathQuotaRead(
+	ctx context.Context,
+	req *logical.Request,
+	data *framework.FieldData,
+) (*logical.Response, error) {
+	exo, err := b.exoscale(ctx, req.Storage, data.Get(configRoleAccount).(string))
+	if err != nil {
+		return logical.ErrorResponse(err.Error()), nil
+	}
+
+	quotas, err := exo.V3ListQuotas(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	res := &logical.Response{}
+	iamQuotas := make(map[string]interface{})
+	for _, q := range quotas {
+		if q.Resource == nil || !strings.HasPrefix(*q.Resource, iamQuotaPrefix) {
+			continue
+		}
+
+		iamQuotas[*q.Resource] = map[string]interface{}{
+			"usage": valueOrZero(q.Usage),
+			"limit": valueOrZero(q.Limit),
+		}
+		if w := exo.quotaWarning(&q); w != "" {
+			res.AddWarning(w)
+		}
+	}
+	res.Data = map[string]interface{}{"quotas": iamQuotas}
+
+	return res, nil
+}
+
+func valueOrZero[T any](v *T) T {
+	var zero T
+	if v == nil {
+		return zero
+	}
+	return *v
+}
+
+// quotaExhausted returns whether no more resources can be created within a quota
+func quotaExhausted(q *oapi.Quota) bool {
+	if q == nil || q.Limit == nil || q.Usage == nil || *q.Limit < 0 {
+		return false
+	}
+	return *q.Usage >= *q.Limit
+}
+
+// quotaWarning returns a warning if the usage of a quota reaches quota_warning_threshold
+func (e *Exoscale) quotaWarning(q *oapi.Quota) string {
+	e.RLock()
+	threshold := int64(e.quotaWarningThreshold)
+	e.RUnlock()
+
+	if threshold == 0 || q == nil || q.Limit == nil || q.Usage == nil || *q.Limit <= 0 {
+		return ""
+	}
+	if *q.Usage*100 < threshold**q.Limit {
+		return ""
+	}
+
+	return fmt.Sprintf("usage of quota %q of the organization is %d/%d, above %d%%",
+		valueOrZero(q.Resource), *q.Usage, *q.Limit, threshold)
+}
+
+// keyQuotaWarning returns a warning if the usage of the API key quota reaches
+// quota_warning_threshold, the quota is only fetched if the threshold is set
+func (e *Exoscale) keyQuotaWarning(ctx context.Context) string {
+	e.RLock()
+	threshold := e.quotaWarningThreshold
+	e.RUnlock()
+	if threshold == 0 {
+		return ""
+	}
+
+	q, err := e.V3GetQuota(ctx, quotaResourceIAMKey)
+	if err != nil {
+		return fmt.Sprintf("unable to check the API key quota of the organization: %s", err)
+	}
+
+	return e.quotaWarning(q)
+}
+
+// keyQuotaErrorResponse returns an error response if an API key couldn't be created because
+// the API key quota of the organization is exhausted, nil otherwise
+func (e *Exoscale) keyQuotaErrorResponse(ctx context.Context, createErr error) *logical.Response {
+	// exhausted quotas are reported as invalid or forbidden requests
+	if !errors.Is(createErr, ErrInvalid) && !errors.Is(createErr, ErrForbidden) {
+		return nil
+	}
+
+	q, err := e.V3GetQuota(ctx, quotaResourceIAMKey)
+	if err != nil || !quotaExhausted(q) {
+		return nil
+	}
+
+	return logical.ErrorResponse("the API key quota of the organization is exhausted (%d/%d), "+
+		"delete unused API keys or request a quota increase from Exoscale support", *q.Usage, *q.Limit)
+}
@@ -0,0 +1,86 @@
+package exoscale
+
+import (
+	"context"
+
+	"github.com/hashicorp/vault/sdk/logical"
+	"github.com/stretchr/testify/mock"
+
+	"github.com/exoscale/egoscale/v2/oapi"
+)
+
+func (ts *testSuite) TestPathQuota() {
+	mockClient := ts.backend.(*exoscaleBackend).exo.egoscaleClient.(*mockEgoscaleClient)
+	mockClient.
+		On("ListQuotasWithResponse", mock.Anything).
+		Return(&oapi.ListQuotasResponse{
+			JSON200: &struct {
+				Quotas *[]oapi.Quota `json:"quotas,omitempty"`
+			}{Quotas: &[]oapi.Quota{
+				{Resource: ptr("iam-key"), Usage: ptr(int64(90)), Limit: ptr(int64(100))},
+				{Resource: ptr("iam-role"), Usage: ptr(int64(3)), Limit: ptr(int64(-1))},
+				{Resource: ptr("instance"), Usage: ptr(int64(12)), Limit: ptr(int64(20))},
+			}},
+		}, nil)
+
+	ts.backend.(*exoscaleBackend).exo.quotaWarningThreshold = 80
+
+	res, err := ts.backend.HandleRequest(context.Background(), &logical.Request{
+		Storage:   ts.storage,
+		Operation: logical.ReadOperation,
+		Path:      "quota",
+	})
+	ts.Require().NoError(err)
+	ts.Require().Equal(map[string]interface{}{
+		"iam-key":  map[string]interface{}{"usage": int64(90), "limit": int64(100)},
+		"iam-role": map[string]interface{}{"usage": int64(3), "limit": int64(-1)},
+	}, res.Data["quotas"])
+	ts.Require().Equal([]string{`usage of quota "iam-key" of the organization is 90/100, above 80%`}, res.Warnings)
+}
+
+func (ts *testSuite) TestPathAPIKeyQuotaExhausted() {
+	ts.storeEntry(roleStoragePathPrefix+testRoleName, Role{
+		IAMRoleID: ts.randomID(),
+		Version:   "v3",
+		Renewable: true,
+	})
+
+	mockClient := ts.backend.(*exoscaleBackend).exo.egoscaleClient.(*mockEgoscaleClient)
+	mockClient.
+		On("CreateApiKeyWithResponse", mock.Anything, mock.Anything).
+		Return(nil, &APIError{StatusCode: 400, Message: "limit reached"})
+	mockClient.
+		On("GetQuotaWithResponse", mock.Anything, quotaResourceIAMKey).
+		Return(&oapi.GetQuotaResponse{
+			JSON200: &oapi.Quota{Resource: ptr(quotaResourceIAMKey), Usage: ptr(int64(100)), Limit: ptr(int64(100))},
+		}, nil)
+
+	res := ts.issueAPIKey()
+	ts.Require().EqualError(res.Error(), "the API key quota of the organization is exhausted (100/100), "+
+		"delete unused API keys or request a quota increase from Exoscale support")
+}
+
+func (ts *testSuite) TestPathAPIKeyQuotaWarning() {
+	ts.storeEntry(roleStoragePathPrefix+testRoleName, Role{
+		IAMRoleID: ts.randomID(),
+		Version:   "v3",
+		Renewable: true,
+	})
+	ts.mockCreateAPIKey()
+
+	mockClient := ts.backend.(*exoscaleBackend).exo.egoscaleClient.(*mockEgoscaleClient)
+	mockClient.
+		On("GetQuotaWithResponse", mock.Anything, quotaResourceIAMKey).
+		Return(&oapi.GetQuotaResponse{
+			JSON200: &oapi.Quota{Resource: ptr(quotaResourceIAMKey), Usage: ptr(int64(85)), Limit: ptr(int64(100))},
+		}, nil)
+
+	// the quota isn't checked without threshold
+	res := ts.issueAPIKey()
+	ts.Require().Empty(res.Warnings)
+	mockClient.AssertNotCalled(ts.T(), "GetQuotaWithResponse", mock.Anything, mock.Anything)
+
+	ts.backend.(*exoscaleBackend).exo.quotaWarningThreshold = 80
+	res = ts.issueAPIKey()
+	ts.Require().Equal([]string{`usage of quota "iam-key" of the organization is 85/100, above 80%`}, res.Warnings)
+}
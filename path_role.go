@@ -2,12 +2,15 @@ package exoscale
 
 import (
 	"context"
+	"encoding/json"
 	"errors"
 	"fmt"
 	"time"
 
 	"github.com/hashicorp/vault/sdk/framework"
 	"github.com/hashicorp/vault/sdk/logical"
+
+	"github.com/exoscale/egoscale/v2/oapi"
 )
 
 type Role struct {
@@ -19,6 +22,23 @@ type Role struct {
 	// IAM V3
 	IAMRoleID   string `json:"iam_role_id,omitempty"`
 	IAMRoleName string `json:"iam_role_name,omitempty"`
+	// IAMPolicy is set for roles backed by a dynamic IAM role, created and
+	// deleted along with each API key
+	IAMPolicy *oapi.IamPolicy `json:"iam_policy,omitempty"`
+	// IAMRolePolicyHash is the digest of the IAM role policy when the role was written
+	IAMRolePolicyHash string     `json:"iam_role_policy_hash,omitempty"`
+	Drift             *RoleDrift `json:"drift,omitempty"`
+	// Strict refuses to issue API keys while the role is drifted
+	Strict bool `json:"strict,omitempty"`
+	// NameTemplate overrides the name template of the configuration
+	NameTemplate string `json:"name_template,omitempty"`
+
+	// PoolSize is the number of API keys created in advance
+	PoolSize int `json:"pool_size,omitempty"`
+
+	// Quotas, 0 means unlimited
+	MaxActiveKeys int `json:"max_active_keys,omitempty"`
+	MaxIssueRate  int `json:"max_issue_rate,omitempty"`
 
 	// Lease
 	Renewable   bool          `json:"renewable"`
@@ -27,6 +47,9 @@ type Role struct {
 	LeaseConfig *leaseConfig  `json:"lease_config,omitempty"` // deprecated
 
 	Version string `json:"version,omitempty"`
+
+	// Account is the name of the account the API keys are created in, config/root is used if empty
+	Account string `json:"account,omitempty"`
 }
 
 func (role *Role) fromFieldData(data *framework.FieldData) error {
@@ -55,6 +78,51 @@ func (role *Role) fromFieldData(data *framework.FieldData) error {
 	// v3
 	role.IAMRoleID = data.Get(configIAMRole).(string)
 
+	if p, ok := data.GetOk(configIAMPolicy); ok {
+		if p.(string) == "" {
+			role.IAMPolicy = nil
+		} else {
+			policy, err := parseIAMPolicy(p.(string))
+			if err != nil {
+				return err
+			}
+			role.IAMPolicy = policy
+		}
+	}
+
+	if s, ok := data.GetOk(configRoleStrict); ok {
+		role.Strict = s.(bool)
+	}
+
+	if a, ok := data.GetOk(configRoleAccount); ok {
+		role.Account = a.(string)
+	}
+
+	if t, ok := data.GetOk(configNameTemplate); ok {
+		role.NameTemplate = t.(string)
+		if err := validateNameTemplate(role.NameTemplate); err != nil {
+			return err
+		}
+	}
+
+	if p, ok := data.GetOk(configRolePoolSize); ok {
+		role.PoolSize = p.(int)
+	}
+	if role.PoolSize < 0 || role.PoolSize > maxPoolSize {
+		return fmt.Errorf("%s must be between 0 and %d", configRolePoolSize, maxPoolSize)
+	}
+
+	// quotas
+	if m, ok := data.GetOk(configRoleMaxActiveKeys); ok {
+		role.MaxActiveKeys = m.(int)
+	}
+	if m, ok := data.GetOk(configRoleMaxIssueRate); ok {
+		role.MaxIssueRate = m.(int)
+	}
+	if role.MaxActiveKeys < 0 || role.MaxIssueRate < 0 {
+		return fmt.Errorf("%s and %s must be positive", configRoleMaxActiveKeys, configRoleMaxIssueRate)
+	}
+
 	// lease
 	if r, ok := data.GetOk(configRoleRenewable); ok {
 		role.Renewable = r.(bool)
@@ -74,10 +142,13 @@ func (role *Role) fromFieldData(data *framework.FieldData) error {
 
 	// version
 	v2FieldSet := (role.Operations != nil || role.Resources != nil || role.Tags != nil)
-	v3FieldSet := role.IAMRoleID != ""
+	v3FieldSet := role.IAMRoleID != "" || role.IAMPolicy != nil
 	if v2FieldSet && v3FieldSet {
 		return errors.New("iam-role cannot be used in conjunction with the deprecated fields: operations, resources or tags")
 	}
+	if role.IAMRoleID != "" && role.IAMPolicy != nil {
+		return errors.New("iam-role cannot be used in conjunction with policy")
+	}
 
 	if v3FieldSet {
 		role.Version = "v3"
@@ -85,9 +156,31 @@ func (role *Role) fromFieldData(data *framework.FieldData) error {
 		role.Version = "v2" // nothing set means v2 unrestricted
 	}
 
+	if role.PoolSize > 0 && !role.poolable() {
+		return fmt.Errorf("%s can only be used in conjunction with iam-role", configRolePoolSize)
+	}
+
 	return nil
 }
 
+// parseIAMPolicy decodes a JSON-encoded IAM policy document
+func parseIAMPolicy(v string) (*oapi.IamPolicy, error) {
+	var policy oapi.IamPolicy
+
+	if err := json.Unmarshal([]byte(v), &policy); err != nil {
+		return nil, fmt.Errorf("invalid IAM policy: %w", err)
+	}
+
+	switch policy.DefaultServiceStrategy {
+	case oapi.IamPolicyDefaultServiceStrategyAllow, oapi.IamPolicyDefaultServiceStrategyDeny:
+	default:
+		return nil, fmt.Errorf("invalid IAM policy: default-service-strategy must be %q or %q",
+			oapi.IamPolicyDefaultServiceStrategyAllow, oapi.IamPolicyDefaultServiceStrategyDeny)
+	}
+
+	return &policy, nil
+}
+
 const (
 	roleStoragePathPrefix = "role/"
 
@@ -95,6 +188,10 @@ const (
 	configRoleTTL       = "ttl"
 	configRoleMaxTTL    = "max_ttl"
 	configRoleRenewable = "renewable"
+	configRoleAccount   = "account"
+
+	configRoleMaxActiveKeys = "max_active_keys"
+	configRoleMaxIssueRate  = "max_issue_rate"
 
 	// IAM v2
 	configRoleOperations = "operations"
@@ -102,7 +199,10 @@ const (
 	configRoleTags       = "tags"
 
 	// IAM v3
-	configIAMRole = "iam-role"
+	configIAMRole      = "iam-role"
+	configIAMPolicy    = "policy"
+	configRoleStrict   = "strict"
+	configRolePoolSize = "pool_size"
 )
 
 const (
@@ -127,11 +227,30 @@ it defines what the key is able to do.
 Warning: Vault Roles and Exoscale IAM roles are different things, they are not
 not related with one another at all!
 
+Instead of referencing an existing IAM Role, a role can carry its own IAM policy:
+a dedicated IAM Role is then created along with each API key, and deleted when
+the key is revoked.
+
 Fields:
 	iam-role: name or id of the IAM Role
+	policy: IAM policy document (JSON), cannot be used in conjunction with iam-role
+	strict (optional): refuse to issue API keys while the IAM role drifted (see role/<name>/check)
+	pool_size (optional): number of API keys created in advance, see below
 	ttl (optional): How long should this key be valid if not renewed (in seconds unless and unit is specified: "s", "m", "h")
 	max_ttl (optional): Hard limit on the lifetime of the key, even if renewed (in seconds unless and unit is specified: "s", "m", "h")
 	renewable (optional): allow this secret to be renewed past its ttl up to its max_ttl (default: true)
+	account (optional): name of the account (config/account/<name>) the API keys are created in
+	max_active_keys (optional): maximum number of API keys whose lease isn't revoked (default: 0, unlimited)
+	max_issue_rate (optional): maximum number of API keys issued per minute (default: 0, unlimited)
+	name_template (optional): Go template generating the names of the API keys, see the help of config/root
+
+API keys of roles referencing an IAM role can be created in advance to reduce the
+latency of apikey/<role>: up to pool_size keys are kept in storage, along with their
+secret, and handed out instantly. The pool is refilled in the background. Pooled keys
+are named after the "pool" display name instead of the one of the requester, and are
+replaced after 24h. Writing the role deletes the pooled keys which don't match its IAM
+role or account anymore, deleting it deletes all of them. The pooled keys are kept when
+the backend is unloaded (seal, plugin reload), the ones left behind are deleted by tidy.
 
 Example:
     vault write exoscale/role/example \
@@ -139,6 +258,10 @@ Example:
 	renewable=false \
 	iam-role=vault-role-example
 
+    vault write exoscale/role/sos-read-only \
+	ttl=1h \
+	policy=@sos-read-only-policy.json
+
 
 Legacy IAM Access Keys (deprecated)
 ===================================
@@ -186,6 +309,23 @@ func (b *exoscaleBackend) pathRole() []*framework.Path {
 					Description: `Is the secret renewable?`,
 					Default:     true,
 				},
+				configRoleMaxActiveKeys: {
+					Type:        framework.TypeInt,
+					Description: "Maximum number of API keys whose lease isn't revoked (default: 0, unlimited)",
+				},
+				configRoleMaxIssueRate: {
+					Type:        framework.TypeInt,
+					Description: "Maximum number of API keys issued per minute (default: 0, unlimited)",
+				},
+				configNameTemplate: {
+					Type:        framework.TypeString,
+					Description: "Go template generating the names of the API keys, overrides the one of config/root (optional)",
+				},
+				configRoleAccount: {
+					Type: framework.TypeString,
+					Description: `Name of the account configured with config/account/<name> in which API keys are created.
+				If not set, the credentials of config/root are used.`,
+				},
 
 				// IAM v2
 				configRoleOperations: {
@@ -211,6 +351,21 @@ func (b *exoscaleBackend) pathRole() []*framework.Path {
 					Description: `Name or ID of an Exoscale IAM role created externally (e.g. with terraform).
 				Cannot be used in conjunction with the deprecated fields: operations, resources or tags.`,
 				},
+				configIAMPolicy: {
+					Type: framework.TypeString,
+					Description: `JSON-encoded IAM policy, an Exoscale IAM role with this policy will be created
+				for each API key and deleted along with it. Cannot be used in conjunction with iam-role.`,
+				},
+				configRoleStrict: {
+					Type: framework.TypeBool,
+					Description: `Refuse to issue API keys while the IAM role is deleted, renamed or has its policy
+				changed since the role was written (default: false)`,
+				},
+				configRolePoolSize: {
+					Type: framework.TypeInt,
+					Description: `Number of API keys created in advance and handed out instantly, requires iam-role
+				(default: 0, maximum: 100)`,
+				},
 			},
 
 			Operations: map[logical.Operation]framework.OperationHandler{
@@ -306,13 +461,26 @@ func (b *exoscaleBackend) readRole(
 		}
 		res.AddWarning("Legacy IAM Access Keys are deprecated, plase switch to the new IAM API Keys and Roles")
 
+	} else if role.IAMPolicy != nil {
+		res = &logical.Response{
+			Data: map[string]interface{}{
+				configIAMPolicy: role.IAMPolicy,
+			},
+		}
 	} else {
 		res = &logical.Response{
 			Data: map[string]interface{}{
-				"iam-role-id":   role.IAMRoleID,
-				"iam-role-name": role.IAMRoleName,
+				"iam-role-id":      role.IAMRoleID,
+				"iam-role-name":    role.IAMRoleName,
+				configRoleStrict:   role.Strict,
+				configRolePoolSize: role.PoolSize,
+				"drift_status":     role.driftStatus(),
 			},
 		}
+		if role.Drift != nil {
+			res.Data["drift"] = role.Drift.Issues
+			res.Data["drift_checked_at"] = role.Drift.CheckedAt.Format(time.RFC3339)
+		}
 	}
 
 	if role.TTL != 0 {
@@ -323,6 +491,21 @@ func (b *exoscaleBackend) readRole(
 	}
 	res.Data[configRoleRenewable] = role.Renewable
 
+	usage, err := getRoleUsage(ctx, req.Storage, name)
+	if err != nil {
+		return nil, err
+	}
+	res.Data[configRoleMaxActiveKeys] = role.MaxActiveKeys
+	res.Data[configRoleMaxIssueRate] = role.MaxIssueRate
+	res.Data["active_keys"] = usage.Active
+	res.Data["issued_last_minute"] = len(usage.Issued)
+	if role.Account != "" {
+		res.Data[configRoleAccount] = role.Account
+	}
+	if role.NameTemplate != "" {
+		res.Data[configNameTemplate] = role.NameTemplate
+	}
+
 	return res, nil
 }
 
@@ -331,6 +514,9 @@ func (b *exoscaleBackend) writeRole(
 	req *logical.Request,
 	data *framework.FieldData,
 ) (*logical.Response, error) {
+	b.rolesLock.Lock()
+	defer b.rolesLock.Unlock()
+
 	name := data.Get(configVaultRoleName).(string)
 	role, err := getRole(ctx, req.Storage, name)
 	if err != nil {
@@ -346,6 +532,11 @@ func (b *exoscaleBackend) writeRole(
 
 	res := &logical.Response{}
 
+	exo, err := b.exoscale(ctx, req.Storage, role.Account)
+	if err != nil {
+		return logical.ErrorResponse(err.Error()), nil
+	}
+
 	if role.Version == "v3" {
 		mountMaxTTL := b.System().MaxLeaseTTL()
 		if role.MaxTTL > mountMaxTTL {
@@ -355,12 +546,25 @@ func (b *exoscaleBackend) writeRole(
 			res.AddWarning(fmt.Sprintf("TTL %q is higher than the effective MaxTTL for this mount", role.TTL))
 		}
 
-		iamrole, err := b.exo.V3GetRole(ctx, role.IAMRoleID)
-		if err != nil {
-			return nil, err
+		// the IAM role resolved now is the reference for drift detection
+		role.IAMRolePolicyHash = ""
+		role.Drift = nil
+
+		if role.IAMPolicy != nil {
+			role.IAMRoleName = ""
+		} else {
+			iamrole, err := exo.V3GetRole(ctx, role.IAMRoleID)
+			if errors.Is(err, ErrNotFound) {
+				return logical.ErrorResponse("IAM role %q not found", role.IAMRoleID), nil
+			} else if err != nil {
+				return nil, err
+			}
+			role.IAMRoleID = *iamrole.Id
+			role.IAMRoleName = *iamrole.Name
+			if role.IAMRolePolicyHash, err = iamPolicyHash(iamrole.Policy); err != nil {
+				return nil, err
+			}
 		}
-		role.IAMRoleID = *iamrole.Id
-		role.IAMRoleName = *iamrole.Name
 	}
 
 	entry, err := logical.StorageEntryJSON(roleStoragePathPrefix+name, role)
@@ -372,15 +576,37 @@ func (b *exoscaleBackend) writeRole(
 		return nil, err
 	}
 
+	// pooled keys may have been created for a previous version of the role, the ones which
+	// can still be handed out for the new version are kept
+	poolRole := role
+	if role.PoolSize == 0 || !role.poolable() {
+		poolRole = nil
+	}
+	if err := b.drainPool(ctx, req.Storage, name, poolRole); err != nil {
+		res.AddWarning(fmt.Sprintf("failed to delete pooled API keys: %s", err))
+	}
+	if role.PoolSize > 0 {
+		b.refillPoolAsync(name)
+	}
+
 	return res, nil
 }
 
 func (b *exoscaleBackend) deleteRole(ctx context.Context, req *logical.Request,
 	data *framework.FieldData) (*logical.Response, error) {
+	b.rolesLock.Lock()
+	defer b.rolesLock.Unlock()
+
 	name := data.Get(configVaultRoleName).(string)
 	if err := req.Storage.Delete(ctx, roleStoragePathPrefix+name); err != nil {
 		return nil, err
 	}
 
+	if err := b.drainPool(ctx, req.Storage, name, nil); err != nil {
+		res := &logical.Response{}
+		res.AddWarning(fmt.Sprintf("failed to delete pooled API keys: %s", err))
+		return res, nil
+	}
+
 	return nil, nil
 }
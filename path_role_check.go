@@ -0,0 +1,218 @@
+package exoscale
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/hashicorp/vault/sdk/framework"
+	"github.com/hashicorp/vault/sdk/logical"
+
+	"github.com/exoscale/egoscale/v2/oapi"
+)
+
+// roleDriftCheckInterval is the minimum interval between two periodic drift checks of a role
+const roleDriftCheckInterval = time.Hour
+
+const (
+	roleDriftStatusUnchecked = "unchecked"
+	roleDriftStatusOK        = "ok"
+	roleDriftStatusDrifted   = "drifted"
+)
+
+const (
+	pathRoleCheckHelpSyn  = "Check a role against its Exoscale IAM role"
+	pathRoleCheckHelpDesc = `
+Fetch the Exoscale IAM role referenced by a role and compare it with the IAM role
+resolved when the role was written: the IAM role is reported as drifted if it
+doesn't exist anymore, was renamed, or if its policy changed.
+
+Roles are also checked periodically, the result of the last check is returned
+when reading the role. Writing the role again resolves its IAM role and clears
+the drift. Roles with strict=true refuse to issue API keys while drifted.
+
+Roles carrying their own IAM policy and legacy roles aren't checked.
+
+Example:
+    vault write -f exoscale/role/example/check
+`
+)
+
+// RoleDrift is the result of the last check of a role against its Exoscale IAM role
+type RoleDrift struct {
+	CheckedAt time.Time `json:"checked_at"`
+	Issues    []string  `json:"issues,omitempty"`
+}
+
+func (role *Role) driftStatus() string {
+	switch {
+	case role.Drift == nil:
+		return roleDriftStatusUnchecked
+	case len(role.Drift.Issues) > 0:
+		return roleDriftStatusDrifted
+	default:
+		return roleDriftStatusOK
+	}
+}
+
+// checkable returns whether the role references an IAM role that can drift
+func (role *Role) checkable() bool {
+	return role.Version == "v3" && role.IAMPolicy == nil && role.IAMRoleID != ""
+}
+
+// iamPolicyHash returns a digest of an IAM policy, used to detect policy changes
+func iamPolicyHash(policy *oapi.IamPolicy) (string, error) {
+	if policy == nil {
+		return "", nil
+	}
+
+	data, err := json.Marshal(policy)
+	if err != nil {
+		return "", err
+	}
+	sum := sha256.Sum256(data)
+
+	return hex.EncodeToString(sum[:]), nil
+}
+
+func (b *exoscaleBackend) pathRoleCheck() *framework.Path {
+	return &framework.Path{
+		Pattern: "role/" + framework.GenericNameRegex(configVaultRoleName) + "/check",
+		Fields: map[string]*framework.FieldSchema{
+			configVaultRoleName: {
+				Type:        framework.TypeString,
+				Description: "Name of the vault role",
+				Required:    true,
+			},
+		},
+
+		Operations: map[logical.Operation]framework.OperationHandler{
+			logical.UpdateOperation: &framework.PathOperation{Callback: b.pathRoleCheckWrite},
+		},
+
+		HelpSynopsis:    pathRoleCheckHelpSyn,
+		HelpDescription: pathRoleCheckHelpDesc,
+	}
+}
+
+func (b *exoscaleBackend) pathRoleCheckWrite(
+	ctx context.Context,
+	req *logical.Request,
+	data *framework.FieldData,
+) (*logical.Response, error) {
+	b.rolesLock.Lock()
+	defer b.rolesLock.Unlock()
+
+	name := data.Get(configVaultRoleName).(string)
+	role, err := getRole(ctx, req.Storage, name)
+	if err != nil {
+		return nil, err
+	}
+	if role == nil {
+		return logical.ErrorResponse("role %q not found", name), nil
+	}
+	if !role.checkable() {
+		return logical.ErrorResponse("role %q doesn't reference an IAM role", name), nil
+	}
+
+	if err := b.checkRoleDrift(ctx, req.Storage, name, role); err != nil {
+		return nil, err
+	}
+
+	return &logical.Response{
+		Data: map[string]interface{}{
+			"drift_status": role.driftStatus(),
+			"drift":        role.Drift.Issues,
+			"checked_at":   role.Drift.CheckedAt.Format(time.RFC3339),
+		},
+	}, nil
+}
+
+// checkRoleDrift compares a role with its Exoscale IAM role and stores the result.
+// Callers must hold rolesLock.
+func (b *exoscaleBackend) checkRoleDrift(ctx context.Context, storage logical.Storage, name string, role *Role) error {
+	exo, err := b.exoscale(ctx, storage, role.Account)
+	if err != nil {
+		return err
+	}
+
+	drift := &RoleDrift{CheckedAt: time.Now()}
+
+	iamrole, err := exo.V3GetRole(ctx, role.IAMRoleID)
+	switch {
+	case errors.Is(err, ErrNotFound):
+		drift.Issues = append(drift.Issues, fmt.Sprintf("IAM role %q (%s) doesn't exist anymore", role.IAMRoleName, role.IAMRoleID))
+
+	case err != nil:
+		return fmt.Errorf("unable to check role %q: %w", name, err)
+
+	default:
+		if *iamrole.Name != role.IAMRoleName {
+			drift.Issues = append(drift.Issues, fmt.Sprintf("IAM role renamed from %q to %q", role.IAMRoleName, *iamrole.Name))
+		}
+
+		hash, err := iamPolicyHash(iamrole.Policy)
+		if err != nil {
+			return err
+		}
+		// roles written before drift detection have no policy hash, it is recorded on the first check
+		if role.IAMRolePolicyHash == "" {
+			role.IAMRolePolicyHash = hash
+		} else if hash != role.IAMRolePolicyHash {
+			drift.Issues = append(drift.Issues, fmt.Sprintf("policy of IAM role %q changed", *iamrole.Name))
+		}
+	}
+
+	if len(drift.Issues) > 0 {
+		b.Logger().Warn("Role drifted from its IAM role", "role", name, "iam_role_id", role.IAMRoleID, "issues", drift.Issues)
+	}
+
+	role.Drift = drift
+	entry, err := logical.StorageEntryJSON(roleStoragePathPrefix+name, role)
+	if err != nil {
+		return err
+	}
+
+	return storage.Put(ctx, entry)
+}
+
+// checkRolesDrift checks the roles that haven't been checked for roleDriftCheckInterval
+func (b *exoscaleBackend) checkRolesDrift(ctx context.Context, storage logical.Storage) error {
+	b.rolesLock.Lock()
+	defer b.rolesLock.Unlock()
+
+	names, err := storage.List(ctx, roleStoragePathPrefix)
+	if err != nil {
+		return err
+	}
+
+	var errs error
+	for _, name := range names {
+		role, err := getRole(ctx, storage, name)
+		if err != nil {
+			errs = errors.Join(errs, err)
+			continue
+		}
+		if role == nil || !role.checkable() {
+			continue
+		}
+		if role.Drift != nil && time.Since(role.Drift.CheckedAt) < roleDriftCheckInterval {
+			continue
+		}
+		if ok, err := b.accountConfigured(ctx, storage, role.Account); !ok || err != nil {
+			errs = errors.Join(errs, err)
+			continue
+		}
+
+		if err := b.checkRoleDrift(ctx, storage, name, role); err != nil {
+			b.Logger().Error("Failed to check role", "role", name, "err", err)
+			errs = errors.Join(errs, err)
+		}
+	}
+
+	return errs
+}
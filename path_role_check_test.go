@@ -0,0 +1,186 @@
+package exoscale
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/hashicorp/vault/sdk/logical"
+	"github.com/stretchr/testify/mock"
+
+	"github.com/exoscale/egoscale/v2/oapi"
+)
+
+// mockIAMRole sets up the mock to return an IAM role, resetting previous expectations and calls
+func (ts *testSuite) mockIAMRole(id, name string, policy *oapi.IamPolicy, err error) {
+	mockClient := ts.backend.(*exoscaleBackend).exo.egoscaleClient.(*mockEgoscaleClient)
+	mockClient.ExpectedCalls = nil
+	mockClient.Calls = nil
+
+	if err != nil {
+		mockClient.On("GetIamRoleWithResponse", mock.Anything, id).Return(nil, err)
+		return
+	}
+	mockClient.
+		On("GetIamRoleWithResponse", mock.Anything, id).
+		Return(&oapi.GetIamRoleResponse{
+			JSON200: &oapi.IamRole{Id: &id, Name: &name, Policy: policy},
+		}, nil)
+}
+
+func (ts *testSuite) TestPathRoleCheck() {
+	iamRoleID := ts.randomID()
+	ts.mockIAMRole(iamRoleID, "ci", testRootIAMPolicy("true"), nil)
+
+	_, err := ts.backend.HandleRequest(context.Background(), &logical.Request{
+		Storage:   ts.storage,
+		Operation: logical.CreateOperation,
+		Path:      roleStoragePathPrefix + testRoleName,
+		Data: map[string]interface{}{
+			configIAMRole:    iamRoleID,
+			configRoleStrict: true,
+		},
+	})
+	ts.Require().NoError(err)
+
+	tests := []struct {
+		name       string
+		iamName    string
+		policy     *oapi.IamPolicy
+		err        error
+		wantStatus string
+		wantIssues int
+	}{
+		{
+			name:       "unchanged",
+			iamName:    "ci",
+			policy:     testRootIAMPolicy("true"),
+			wantStatus: roleDriftStatusOK,
+		},
+		{
+			name:       "renamed with policy changed",
+			iamName:    "ci-renamed",
+			policy:     testRootIAMPolicy("operation == 'get-api-key'"),
+			wantStatus: roleDriftStatusDrifted,
+			wantIssues: 2,
+		},
+		{
+			name:       "deleted",
+			err:        &APIError{StatusCode: http.StatusNotFound},
+			wantStatus: roleDriftStatusDrifted,
+			wantIssues: 1,
+		},
+	}
+
+	for _, tt := range tests {
+		ts.Run(tt.name, func() {
+			ts.mockIAMRole(iamRoleID, tt.iamName, tt.policy, tt.err)
+
+			res, err := ts.backend.HandleRequest(context.Background(), &logical.Request{
+				Storage:   ts.storage,
+				Operation: logical.UpdateOperation,
+				Path:      roleStoragePathPrefix + testRoleName + "/check",
+			})
+			ts.Require().NoError(err)
+			ts.Require().Equal(tt.wantStatus, res.Data["drift_status"])
+			ts.Require().Len(res.Data["drift"], tt.wantIssues)
+
+			res, err = ts.backend.HandleRequest(context.Background(), &logical.Request{
+				Storage:   ts.storage,
+				Operation: logical.ReadOperation,
+				Path:      roleStoragePathPrefix + testRoleName,
+			})
+			ts.Require().NoError(err)
+			ts.Require().Equal(tt.wantStatus, res.Data["drift_status"])
+
+			// strict roles don't issue keys while drifted
+			if tt.wantStatus == roleDriftStatusDrifted {
+				res, err = ts.backend.HandleRequest(context.Background(), &logical.Request{
+					Storage:   ts.storage,
+					Operation: logical.ReadOperation,
+					Path:      "apikey/" + testRoleName,
+				})
+				ts.Require().NoError(err)
+				ts.Require().True(res.IsError())
+				ts.Require().Contains(res.Error().Error(), "drifted from its IAM role")
+			}
+		})
+	}
+}
+
+func (ts *testSuite) TestPeriodicRoleCheck() {
+	iamRoleID := ts.randomID()
+	ts.storeEntry(roleStoragePathPrefix+testRoleName, Role{
+		IAMRoleID:   iamRoleID,
+		IAMRoleName: "ci",
+		Version:     "v3",
+		Renewable:   true,
+	})
+	ts.mockIAMRole(iamRoleID, "ci-renamed", nil, nil)
+
+	_, err := ts.backend.HandleRequest(context.Background(), &logical.Request{
+		Storage:   ts.storage,
+		Operation: logical.RollbackOperation,
+	})
+	ts.Require().NoError(err)
+
+	role, err := getRole(context.Background(), ts.storage, testRoleName)
+	ts.Require().NoError(err)
+	ts.Require().Equal(roleDriftStatusDrifted, role.driftStatus())
+	ts.Require().Equal([]string{`IAM role renamed from "ci" to "ci-renamed"`}, role.Drift.Issues)
+
+	// the role was checked recently, it isn't checked again
+	ts.mockIAMRole(iamRoleID, "ci", nil, nil)
+	_, err = ts.backend.HandleRequest(context.Background(), &logical.Request{
+		Storage:   ts.storage,
+		Operation: logical.RollbackOperation,
+	})
+	ts.Require().NoError(err)
+	ts.backend.(*exoscaleBackend).exo.egoscaleClient.(*mockEgoscaleClient).
+		AssertNotCalled(ts.T(), "GetIamRoleWithResponse", mock.Anything, iamRoleID)
+}
+
+func (ts *testSuite) TestPeriodicRoleCheckAccountOnly() {
+	clients := ts.mockAccountClients()
+	clients["EXOstaging"] = new(mockEgoscaleClient)
+	ts.writeTestAccount("EXOstaging")
+
+	// the mount only has an account, config/root isn't configured
+	exo := ts.backend.(*exoscaleBackend).exo
+	exo.Lock()
+	exo.configured = false
+	exo.Unlock()
+
+	iamRoleID := ts.randomID()
+	ts.storeEntry(roleStoragePathPrefix+testRoleName, Role{
+		IAMRoleID:   iamRoleID,
+		IAMRoleName: "ci",
+		Version:     "v3",
+		Account:     testAccountName,
+	})
+	ts.storeEntry(roleStoragePathPrefix+"root", Role{
+		IAMRoleID:   ts.randomID(),
+		IAMRoleName: "root",
+		Version:     "v3",
+	})
+	clients["EXOstaging"].
+		On("GetIamRoleWithResponse", mock.Anything, iamRoleID).
+		Return(&oapi.GetIamRoleResponse{
+			JSON200: &oapi.IamRole{Id: &iamRoleID, Name: ptr("ci-renamed")},
+		}, nil)
+
+	_, err := ts.backend.HandleRequest(context.Background(), &logical.Request{
+		Storage:   ts.storage,
+		Operation: logical.RollbackOperation,
+	})
+	ts.Require().NoError(err)
+
+	role, err := getRole(context.Background(), ts.storage, testRoleName)
+	ts.Require().NoError(err)
+	ts.Require().Equal(roleDriftStatusDrifted, role.driftStatus())
+
+	// the roles of config/root are left unchecked
+	role, err = getRole(context.Background(), ts.storage, "root")
+	ts.Require().NoError(err)
+	ts.Require().Nil(role.Drift)
+	exo.egoscaleClient.(*mockEgoscaleClient).AssertNotCalled(ts.T(), "GetIamRoleWithResponse", mock.Anything, mock.Anything)
+}
@@ -176,12 +176,16 @@ func (ts *testSuite) TestPathRoleV2LegacyRead() {
 	ts.Require().Equal(testRoleResources, res.Data[configRoleResources].([]string))
 	ts.Require().Equal(testRoleTags, res.Data[configRoleTags].([]string))
 	ts.Require().Equal(map[string]interface{}{
-		"max_ttl":    float64(3000),
-		"operations": testRoleOperations,
-		"renewable":  false,
-		"resources":  testRoleResources,
-		"tags":       testRoleTags,
-		"ttl":        float64(600),
+		"max_ttl":            float64(3000),
+		"operations":         testRoleOperations,
+		"renewable":          false,
+		"resources":          testRoleResources,
+		"tags":               testRoleTags,
+		"ttl":                float64(600),
+		"max_active_keys":    0,
+		"max_issue_rate":     0,
+		"active_keys":        0,
+		"issued_last_minute": 0,
 	}, res.Data)
 }
 
@@ -261,3 +265,91 @@ func (ts *testSuite) TestPathRoleV3Write() {
 		Version:     "v3",
 	}, actualRoleConfig)
 }
+
+func (ts *testSuite) TestPathRoleV3PolicyWrite() {
+	name := "superv3policyrole"
+
+	_, err := ts.backend.HandleRequest(context.Background(), &logical.Request{
+		Storage:   ts.storage,
+		Operation: logical.CreateOperation,
+		Path:      roleStoragePathPrefix + name,
+		Data: map[string]interface{}{
+			configVaultRoleName: name,
+			configIAMPolicy:     `{"default-service-strategy":"deny","services":{"sos":{"type":"allow"}}}`,
+			configRoleTTL:       42,
+		},
+	})
+	if err != nil {
+		ts.FailNow("request failed", err)
+	}
+
+	role, err := getRole(context.Background(), ts.storage, name)
+	if err != nil {
+		ts.FailNow("unable to retrieve role from storage", err)
+	}
+
+	allow := oapi.IamServicePolicyTypeAllow
+	ts.Require().Equal("v3", role.Version)
+	ts.Require().Empty(role.IAMRoleID)
+	ts.Require().Equal(&oapi.IamPolicy{
+		DefaultServiceStrategy: oapi.IamPolicyDefaultServiceStrategyDeny,
+		Services: oapi.IamPolicy_Services{
+			AdditionalProperties: map[string]oapi.IamServicePolicy{
+				"sos": {Type: &allow},
+			},
+		},
+	}, role.IAMPolicy)
+
+	res, err := ts.backend.HandleRequest(context.Background(), &logical.Request{
+		Storage:   ts.storage,
+		Operation: logical.ReadOperation,
+		Path:      roleStoragePathPrefix + name,
+	})
+	if err != nil {
+		ts.FailNow("request failed", err)
+	}
+	ts.Require().Equal(role.IAMPolicy, res.Data[configIAMPolicy])
+}
+
+func (ts *testSuite) TestPathRoleWriteV3PolicyInvalid() {
+	tests := []struct {
+		name    string
+		data    map[string]interface{}
+		wantErr string
+	}{
+		{
+			name: "with iam-role",
+			data: map[string]interface{}{
+				configIAMRole:   "tititoto",
+				configIAMPolicy: `{"default-service-strategy":"allow","services":{}}`,
+			},
+			wantErr: "iam-role cannot be used in conjunction with policy",
+		},
+		{
+			name: "malformed",
+			data: map[string]interface{}{
+				configIAMPolicy: `{"default-service-strategy":`,
+			},
+			wantErr: "invalid IAM policy",
+		},
+		{
+			name: "missing default strategy",
+			data: map[string]interface{}{
+				configIAMPolicy: `{"services":{}}`,
+			},
+			wantErr: "default-service-strategy must be",
+		},
+	}
+
+	for _, tt := range tests {
+		ts.Run(tt.name, func() {
+			_, err := ts.backend.HandleRequest(context.Background(), &logical.Request{
+				Storage:   ts.storage,
+				Operation: logical.CreateOperation,
+				Path:      roleStoragePathPrefix + testRoleName,
+				Data:      tt.data,
+			})
+			ts.ErrorContains(err, tt.wantErr)
+		})
+	}
+}
@@ -0,0 +1,268 @@
+package exoscale
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/hashicorp/vault/sdk/framework"
+	"github.com/hashicorp/vault/sdk/logical"
+)
+
+// SKSRole is a role issuing kubeconfigs for a user of an SKS cluster
+type SKSRole struct {
+	ClusterID string        `json:"cluster_id"`
+	Zone      string        `json:"zone,omitempty"`
+	User      string        `json:"user,omitempty"`
+	Groups    []string      `json:"groups,omitempty"`
+	TTL       time.Duration `json:"ttl,omitempty"`
+	MaxTTL    time.Duration `json:"max_ttl,omitempty"`
+	Account   string        `json:"account,omitempty"`
+}
+
+const (
+	sksRoleStoragePathPrefix = "sks-role/"
+
+	configSKSRoleClusterID = "cluster_id"
+	configSKSRoleUser      = "user"
+	configSKSRoleGroups    = "groups"
+
+	// minKubeconfigTTL is the minimum validity of the kubeconfig certificates
+	minKubeconfigTTL = time.Minute
+)
+
+const (
+	pathListSKSRolesHelpSyn  = "List the configured SKS roles"
+	pathListSKSRolesHelpDesc = `
+This endpoint returns a list of the configured SKS roles.
+`
+
+	pathSKSRoleHelpSyn  = "Manage SKS roles"
+	pathSKSRoleHelpDesc = `
+Manage SKS roles, issuing short-lived kubeconfigs for an Exoscale SKS cluster
+with the kubeconfig/<name> endpoint.
+
+Fields:
+	cluster_id: ID of the SKS cluster
+	zone: zone of the cluster (optional, default: the zone of config/root or of the account)
+	user: Kubernetes user, set as CN of the certificate (optional, default: the display name of the requester)
+	groups: comma-separated list of Kubernetes groups, set as O of the certificate
+	ttl: default validity of the certificates (optional, default: the default lease TTL of the mount)
+	max_ttl: maximum validity of the certificates (optional, default: the maximum lease TTL of the mount)
+	account: name of the account configured with config/account/<name> owning the cluster (optional)
+
+Example:
+    vault write exoscale/sks-role/prod-readonly \
+	cluster_id=6d4b0e0c-5c6f-4b5e-9a4f-0f3c4d2e1a7b \
+	zone=ch-gva-2 \
+	groups=view \
+	ttl=1h max_ttl=8h
+`
+)
+
+func (b *exoscaleBackend) pathSKSRole() []*framework.Path {
+	return []*framework.Path{
+		{
+			Pattern: "sks-role/" + framework.GenericNameRegex(configVaultRoleName),
+			Fields: map[string]*framework.FieldSchema{
+				configVaultRoleName: {
+					Type:        framework.TypeString,
+					Description: "Name of the SKS role",
+					Required:    true,
+				},
+				configSKSRoleClusterID: {
+					Type:        framework.TypeString,
+					Description: "ID of the SKS cluster",
+				},
+				configZone: {
+					Type:        framework.TypeString,
+					Description: "Zone of the cluster (optional, default: the zone of config/root or of the account)",
+				},
+				configSKSRoleUser: {
+					Type:        framework.TypeString,
+					Description: "Kubernetes user (optional, default: the display name of the requester)",
+				},
+				configSKSRoleGroups: {
+					Type:        framework.TypeCommaStringSlice,
+					Description: "Comma-separated list of Kubernetes groups",
+				},
+				configRoleTTL: {
+					Type:        framework.TypeDurationSecond,
+					Description: "Default validity of the certificates",
+				},
+				configRoleMaxTTL: {
+					Type:        framework.TypeDurationSecond,
+					Description: "Maximum validity of the certificates",
+				},
+				configRoleAccount: {
+					Type:        framework.TypeString,
+					Description: "Name of the account configured with config/account/<name> owning the cluster (optional)",
+				},
+			},
+
+			Operations: map[logical.Operation]framework.OperationHandler{
+				logical.CreateOperation: &framework.PathOperation{Callback: b.writeSKSRole},
+				logical.UpdateOperation: &framework.PathOperation{Callback: b.writeSKSRole},
+				logical.ReadOperation:   &framework.PathOperation{Callback: b.readSKSRole},
+				logical.DeleteOperation: &framework.PathOperation{Callback: b.deleteSKSRole},
+			},
+
+			HelpSynopsis:    pathSKSRoleHelpSyn,
+			HelpDescription: pathSKSRoleHelpDesc,
+		},
+		{
+			Pattern: "sks-role/?$",
+
+			Operations: map[logical.Operation]framework.OperationHandler{
+				logical.ListOperation: &framework.PathOperation{Callback: b.listSKSRoles},
+			},
+
+			HelpSynopsis:    pathListSKSRolesHelpSyn,
+			HelpDescription: pathListSKSRolesHelpDesc,
+		},
+	}
+}
+
+func getSKSRole(ctx context.Context, storage logical.Storage, name string) (*SKSRole, error) {
+	if name == "" {
+		return nil, errors.New("invalid role name")
+	}
+
+	entry, err := storage.Get(ctx, sksRoleStoragePathPrefix+name)
+	if err != nil {
+		return nil, fmt.Errorf("unable to retrieve SKS role %q: %w", name, err)
+	}
+	if entry == nil {
+		return nil, nil
+	}
+
+	var role SKSRole
+	if err := entry.DecodeJSON(&role); err != nil {
+		return nil, err
+	}
+
+	return &role, nil
+}
+
+func (b *exoscaleBackend) listSKSRoles(
+	ctx context.Context,
+	req *logical.Request,
+	_ *framework.FieldData,
+) (*logical.Response, error) {
+	roles, err := req.Storage.List(ctx, sksRoleStoragePathPrefix)
+	if err != nil {
+		return nil, err
+	}
+
+	return logical.ListResponse(roles), nil
+}
+
+func (b *exoscaleBackend) readSKSRole(
+	ctx context.Context,
+	req *logical.Request,
+	data *framework.FieldData,
+) (*logical.Response, error) {
+	role, err := getSKSRole(ctx, req.Storage, data.Get(configVaultRoleName).(string))
+	if err != nil {
+		return nil, err
+	}
+	if role == nil {
+		return nil, nil
+	}
+
+	res := &logical.Response{
+		Data: map[string]interface{}{
+			configSKSRoleClusterID: role.ClusterID,
+			configSKSRoleUser:      role.User,
+			configSKSRoleGroups:    role.Groups,
+			configRoleTTL:          role.TTL.Seconds(),
+			configRoleMaxTTL:       role.MaxTTL.Seconds(),
+		},
+	}
+	if role.Zone != "" {
+		res.Data[configZone] = role.Zone
+	}
+	if role.Account != "" {
+		res.Data[configRoleAccount] = role.Account
+	}
+
+	return res, nil
+}
+
+func (b *exoscaleBackend) writeSKSRole(
+	ctx context.Context,
+	req *logical.Request,
+	data *framework.FieldData,
+) (*logical.Response, error) {
+	b.rolesLock.Lock()
+	defer b.rolesLock.Unlock()
+
+	name := data.Get(configVaultRoleName).(string)
+	role, err := getSKSRole(ctx, req.Storage, name)
+	if err != nil {
+		return nil, err
+	}
+	if role == nil {
+		role = &SKSRole{}
+	}
+
+	if v, ok := data.GetOk(configSKSRoleClusterID); ok {
+		role.ClusterID = v.(string)
+	}
+	if role.ClusterID == "" {
+		return logical.ErrorResponse("%s is required", configSKSRoleClusterID), nil
+	}
+
+	if v, ok := data.GetOk(configZone); ok {
+		role.Zone = v.(string)
+	}
+	if v, ok := data.GetOk(configSKSRoleUser); ok {
+		role.User = v.(string)
+	}
+	if v, ok := data.GetOk(configSKSRoleGroups); ok {
+		role.Groups = v.([]string)
+	}
+
+	if v, ok := data.GetOk(configRoleTTL); ok {
+		role.TTL = time.Duration(v.(int)) * time.Second
+	}
+	if v, ok := data.GetOk(configRoleMaxTTL); ok {
+		role.MaxTTL = time.Duration(v.(int)) * time.Second
+	}
+	if role.TTL != 0 && role.TTL < minKubeconfigTTL {
+		return logical.ErrorResponse("%s must be at least %s", configRoleTTL, minKubeconfigTTL), nil
+	}
+	if role.MaxTTL != 0 && role.TTL > role.MaxTTL {
+		return logical.ErrorResponse("%s can't be greater than %s", configRoleTTL, configRoleMaxTTL), nil
+	}
+
+	if v, ok := data.GetOk(configRoleAccount); ok {
+		role.Account = v.(string)
+		if _, err := b.exoscale(ctx, req.Storage, role.Account); err != nil {
+			return logical.ErrorResponse(err.Error()), nil
+		}
+	}
+
+	entry, err := logical.StorageEntryJSON(sksRoleStoragePathPrefix+name, role)
+	if err != nil {
+		return nil, err
+	}
+	if err := req.Storage.Put(ctx, entry); err != nil {
+		return nil, err
+	}
+
+	return nil, nil
+}
+
+func (b *exoscaleBackend) deleteSKSRole(
+	ctx context.Context,
+	req *logical.Request,
+	data *framework.FieldData,
+) (*logical.Response, error) {
+	if err := req.Storage.Delete(ctx, sksRoleStoragePathPrefix+data.Get(configVaultRoleName).(string)); err != nil {
+		return nil, err
+	}
+
+	return nil, nil
+}
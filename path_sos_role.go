@@ -0,0 +1,250 @@
+package exoscale
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/hashicorp/vault/sdk/framework"
+	"github.com/hashicorp/vault/sdk/logical"
+)
+
+// SOSRole is a role allowing to presign URLs of objects of SOS buckets
+type SOSRole struct {
+	Buckets     []string `json:"buckets"`
+	KeyPrefixes []string `json:"key_prefixes,omitempty"`
+	Zone        string   `json:"zone,omitempty"`
+	Account     string   `json:"account,omitempty"`
+}
+
+// allows returns an error if the role doesn't allow to presign a URL for an object
+func (role *SOSRole) allows(bucket, key string) error {
+	if !slices.Contains(role.Buckets, bucket) {
+		return fmt.Errorf("bucket %q is not allowed by the role", bucket)
+	}
+
+	if len(role.KeyPrefixes) > 0 {
+		allowed := false
+		for _, p := range role.KeyPrefixes {
+			if strings.HasPrefix(key, p) {
+				allowed = true
+				break
+			}
+		}
+		if !allowed {
+			return fmt.Errorf("key %q doesn't match any of the key prefixes allowed by the role", key)
+		}
+	}
+
+	return nil
+}
+
+const (
+	sosRoleStoragePathPrefix = "sos-role/"
+
+	configSOSRoleBuckets     = "buckets"
+	configSOSRoleKeyPrefixes = "key_prefixes"
+)
+
+const (
+	pathListSOSRolesHelpSyn  = "List the configured SOS roles"
+	pathListSOSRolesHelpDesc = `
+This endpoint returns a list of the configured SOS roles.
+`
+
+	pathSOSRoleHelpSyn  = "Manage SOS roles"
+	pathSOSRoleHelpDesc = `
+Manage SOS roles, allowing to presign URLs of objects of SOS (object storage)
+buckets with the sos-url/<name> endpoint, without creating any API key. The
+Exoscale API only presigns download (GET) URLs, and decides of their validity.
+
+Fields:
+	buckets: comma-separated list of the buckets whose objects can be presigned
+	key_prefixes: comma-separated list of prefixes the object keys must start with (optional, default: any key)
+	zone: zone of the buckets (optional, default: the zone of config/root or of the account)
+	account: name of the account configured with config/account/<name> (optional, default: config/root)
+
+Example:
+    vault write exoscale/sos-role/reports \
+	buckets=reports \
+	key_prefixes=monthly/,yearly/
+`
+)
+
+func (b *exoscaleBackend) pathSOSRole() []*framework.Path {
+	return []*framework.Path{
+		{
+			Pattern: "sos-role/" + framework.GenericNameRegex(configVaultRoleName),
+			Fields: map[string]*framework.FieldSchema{
+				configVaultRoleName: {
+					Type:        framework.TypeString,
+					Description: "Name of the SOS role",
+					Required:    true,
+				},
+				configSOSRoleBuckets: {
+					Type:        framework.TypeCommaStringSlice,
+					Description: "Comma-separated list of the buckets whose objects can be presigned",
+				},
+				configSOSRoleKeyPrefixes: {
+					Type:        framework.TypeCommaStringSlice,
+					Description: "Comma-separated list of prefixes the object keys must start with (optional, default: any key)",
+				},
+				configZone: {
+					Type:        framework.TypeString,
+					Description: "Zone of the buckets (optional, default: the zone of config/root or of the account)",
+				},
+				configRoleAccount: {
+					Type:        framework.TypeString,
+					Description: "Name of the account configured with config/account/<name> owning the buckets (optional)",
+				},
+			},
+
+			Operations: map[logical.Operation]framework.OperationHandler{
+				logical.CreateOperation: &framework.PathOperation{Callback: b.writeSOSRole},
+				logical.UpdateOperation: &framework.PathOperation{Callback: b.writeSOSRole},
+				logical.ReadOperation:   &framework.PathOperation{Callback: b.readSOSRole},
+				logical.DeleteOperation: &framework.PathOperation{Callback: b.deleteSOSRole},
+			},
+
+			HelpSynopsis:    pathSOSRoleHelpSyn,
+			HelpDescription: pathSOSRoleHelpDesc,
+		},
+		{
+			Pattern: "sos-role/?$",
+
+			Operations: map[logical.Operation]framework.OperationHandler{
+				logical.ListOperation: &framework.PathOperation{Callback: b.listSOSRoles},
+			},
+
+			HelpSynopsis:    pathListSOSRolesHelpSyn,
+			HelpDescription: pathListSOSRolesHelpDesc,
+		},
+	}
+}
+
+func getSOSRole(ctx context.Context, storage logical.Storage, name string) (*SOSRole, error) {
+	if name == "" {
+		return nil, errors.New("invalid role name")
+	}
+
+	entry, err := storage.Get(ctx, sosRoleStoragePathPrefix+name)
+	if err != nil {
+		return nil, fmt.Errorf("unable to retrieve SOS role %q: %w", name, err)
+	}
+	if entry == nil {
+		return nil, nil
+	}
+
+	var role SOSRole
+	if err := entry.DecodeJSON(&role); err != nil {
+		return nil, err
+	}
+
+	return &role, nil
+}
+
+func (b *exoscaleBackend) listSOSRoles(
+	ctx context.Context,
+	req *logical.Request,
+	_ *framework.FieldData,
+) (*logical.Response, error) {
+	roles, err := req.Storage.List(ctx, sosRoleStoragePathPrefix)
+	if err != nil {
+		return nil, err
+	}
+
+	return logical.ListResponse(roles), nil
+}
+
+func (b *exoscaleBackend) readSOSRole(
+	ctx context.Context,
+	req *logical.Request,
+	data *framework.FieldData,
+) (*logical.Response, error) {
+	role, err := getSOSRole(ctx, req.Storage, data.Get(configVaultRoleName).(string))
+	if err != nil {
+		return nil, err
+	}
+	if role == nil {
+		return nil, nil
+	}
+
+	res := &logical.Response{
+		Data: map[string]interface{}{
+			configSOSRoleBuckets:     role.Buckets,
+			configSOSRoleKeyPrefixes: role.KeyPrefixes,
+		},
+	}
+	if role.Zone != "" {
+		res.Data[configZone] = role.Zone
+	}
+	if role.Account != "" {
+		res.Data[configRoleAccount] = role.Account
+	}
+
+	return res, nil
+}
+
+func (b *exoscaleBackend) writeSOSRole(
+	ctx context.Context,
+	req *logical.Request,
+	data *framework.FieldData,
+) (*logical.Response, error) {
+	b.rolesLock.Lock()
+	defer b.rolesLock.Unlock()
+
+	name := data.Get(configVaultRoleName).(string)
+	role, err := getSOSRole(ctx, req.Storage, name)
+	if err != nil {
+		return nil, err
+	}
+	if role == nil {
+		role = &SOSRole{}
+	}
+
+	if v, ok := data.GetOk(configSOSRoleBuckets); ok {
+		role.Buckets = v.([]string)
+	}
+	if len(role.Buckets) == 0 {
+		return logical.ErrorResponse("%s is required", configSOSRoleBuckets), nil
+	}
+
+	if v, ok := data.GetOk(configSOSRoleKeyPrefixes); ok {
+		role.KeyPrefixes = v.([]string)
+	}
+
+	if v, ok := data.GetOk(configZone); ok {
+		role.Zone = v.(string)
+	}
+
+	if v, ok := data.GetOk(configRoleAccount); ok {
+		role.Account = v.(string)
+		if _, err := b.exoscale(ctx, req.Storage, role.Account); err != nil {
+			return logical.ErrorResponse(err.Error()), nil
+		}
+	}
+
+	entry, err := logical.StorageEntryJSON(sosRoleStoragePathPrefix+name, role)
+	if err != nil {
+		return nil, err
+	}
+	if err := req.Storage.Put(ctx, entry); err != nil {
+		return nil, err
+	}
+
+	return nil, nil
+}
+
+func (b *exoscaleBackend) deleteSOSRole(
+	ctx context.Context,
+	req *logical.Request,
+	data *framework.FieldData,
+) (*logical.Response, error) {
+	if err := req.Storage.Delete(ctx, sosRoleStoragePathPrefix+data.Get(configVaultRoleName).(string)); err != nil {
+		return nil, err
+	}
+
+	return nil, nil
+}
@@ -0,0 +1,144 @@
+package exoscale
+
+import (
+	"context"
+	"net/url"
+	"strconv"
+	"time"
+
+	"github.com/hashicorp/vault/sdk/framework"
+	"github.com/hashicorp/vault/sdk/logical"
+)
+
+const (
+	sosURLBucket = "bucket"
+	sosURLKey    = "key"
+)
+
+const (
+	pathSOSURLHelpSyn  = "Presign the URL of an object of an SOS bucket"
+	pathSOSURLHelpDesc = `
+This endpoint returns a presigned URL giving time-bounded read (GET) access to
+one object of an SOS bucket allowed by the role, without creating any API key.
+
+The validity of the URL is decided by the Exoscale API and returned in expires_at.
+Presigned URLs can't be revoked, they aren't leased.
+
+Example:
+    vault read exoscale/sos-url/reports bucket=reports key=monthly/2024-01.pdf
+`
+)
+
+func (b *exoscaleBackend) pathSOSURL() *framework.Path {
+	return &framework.Path{
+		Pattern: "sos-url/" + framework.GenericNameRegex(configVaultRoleName),
+		Fields: map[string]*framework.FieldSchema{
+			configVaultRoleName: {
+				Type:        framework.TypeString,
+				Description: "Name of the SOS role",
+				Required:    true,
+			},
+			sosURLBucket: {
+				Type:        framework.TypeString,
+				Description: "Name of the bucket",
+				Required:    true,
+			},
+			sosURLKey: {
+				Type:        framework.TypeString,
+				Description: "Key of the object",
+				Required:    true,
+			},
+		},
+
+		Operations: map[logical.Operation]framework.OperationHandler{
+			logical.ReadOperation:   &framework.PathOperation{Callback: b.readSOSURL},
+			logical.UpdateOperation: &framework.PathOperation{Callback: b.readSOSURL},
+		},
+
+		HelpSynopsis:    pathSOSURLHelpSyn,
+		HelpDescription: pathSOSURLHelpDesc,
+	}
+}
+
+func (b *exoscaleBackend) readSOSURL(
+	ctx context.Context,
+	req *logical.Request,
+	data *framework.FieldData,
+) (*logical.Response, error) {
+	roleName := data.Get(configVaultRoleName).(string)
+	role, err := getSOSRole(ctx, req.Storage, roleName)
+	if err != nil {
+		return nil, err
+	}
+	if role == nil {
+		return logical.ErrorResponse("SOS role %q not found", roleName), nil
+	}
+
+	bucket := data.Get(sosURLBucket).(string)
+	key := data.Get(sosURLKey).(string)
+	if bucket == "" || key == "" {
+		return logical.ErrorResponse("%s and %s are required", sosURLBucket, sosURLKey), nil
+	}
+	if err := role.allows(bucket, key); err != nil {
+		return logical.ErrorResponse(err.Error()), nil
+	}
+
+	exo, err := b.exoscale(ctx, req.Storage, role.Account)
+	if err != nil {
+		return logical.ErrorResponse(err.Error()), nil
+	}
+
+	presignedURL, err := exo.V3GetSOSPresignedURL(ctx, role.Zone, bucket, key)
+	if err != nil {
+		return nil, err
+	}
+
+	b.Logger().Info("SOS URL presigned",
+		"role", roleName, "bucket", bucket, "key", key, "entity_id", req.EntityID, "display_name", req.DisplayName)
+
+	res := &logical.Response{
+		Data: map[string]interface{}{
+			"url":        presignedURL,
+			sosURLBucket: bucket,
+			sosURLKey:    key,
+		},
+	}
+	if expiresAt, ok := presignedURLExpiry(presignedURL); ok {
+		res.Data["expires_at"] = expiresAt.Format(time.RFC3339)
+		res.Data["ttl"] = int64(time.Until(expiresAt).Seconds())
+	}
+
+	return res, nil
+}
+
+// presignedURLExpiry returns when a presigned URL expires, from its AWS signature v4
+// or v2 query parameters
+func presignedURLExpiry(rawURL string) (time.Time, bool) {
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return time.Time{}, false
+	}
+	q := u.Query()
+
+	if date, expires := q.Get("X-Amz-Date"), q.Get("X-Amz-Expires"); date != "" && expires != "" {
+		signedAt, err := time.Parse("20060102T150405Z", date)
+		if err != nil {
+			return time.Time{}, false
+		}
+		seconds, err := strconv.ParseInt(expires, 10, 64)
+		if err != nil {
+			return time.Time{}, false
+		}
+		return signedAt.Add(time.Duration(seconds) * time.Second), true
+	}
+
+	if expires := q.Get("Expires"); expires != "" {
+		epoch, err := strconv.ParseInt(expires, 10, 64)
+		if err != nil {
+			return time.Time{}, false
+		}
+		return time.Unix(epoch, 0), true
+	}
+
+	return time.Time{}, false
+}
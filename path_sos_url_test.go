@@ -0,0 +1,78 @@
+package exoscale
+
+import (
+	"context"
+	"time"
+
+	"github.com/hashicorp/vault/sdk/logical"
+	"github.com/stretchr/testify/mock"
+
+	"github.com/exoscale/egoscale/v2/oapi"
+)
+
+func (ts *testSuite) TestPathSOSURL() {
+	res, err := ts.backend.HandleRequest(context.Background(), &logical.Request{
+		Storage:   ts.storage,
+		Operation: logical.CreateOperation,
+		Path:      sosRoleStoragePathPrefix + "reports",
+		Data: map[string]interface{}{
+			configSOSRoleKeyPrefixes: "monthly/",
+		},
+	})
+	ts.Require().NoError(err)
+	ts.Require().True(res.IsError())
+
+	res, err = ts.backend.HandleRequest(context.Background(), &logical.Request{
+		Storage:   ts.storage,
+		Operation: logical.CreateOperation,
+		Path:      sosRoleStoragePathPrefix + "reports",
+		Data: map[string]interface{}{
+			configSOSRoleBuckets:     "reports",
+			configSOSRoleKeyPrefixes: "monthly/",
+			configZone:               "de-fra-1",
+		},
+	})
+	ts.Require().NoError(err)
+	ts.Require().Nil(res)
+
+	signedAt := time.Now().UTC().Format("20060102T150405Z")
+	mockClient := ts.backend.(*exoscaleBackend).exo.egoscaleClient.(*mockEgoscaleClient)
+	mockClient.
+		On("GetSosPresignedUrlWithResponse", mock.Anything, "reports", &oapi.GetSosPresignedUrlParams{Key: ptr("monthly/jan.pdf")}).
+		Return(&oapi.GetSosPresignedUrlResponse{
+			JSON200: &struct {
+				Url *string `json:"url,omitempty"`
+			}{
+				Url: ptr("https://sos-de-fra-1.exo.io/reports/monthly/jan.pdf?X-Amz-Date=" + signedAt + "&X-Amz-Expires=600"),
+			},
+		}, nil).
+		Once()
+
+	tests := []struct {
+		name string
+		data map[string]interface{}
+		err  string
+	}{
+		{"bucket not allowed", map[string]interface{}{sosURLBucket: "other", sosURLKey: "monthly/jan.pdf"}, "bucket"},
+		{"key not allowed", map[string]interface{}{sosURLBucket: "reports", sosURLKey: "yearly/2024.pdf"}, "key prefixes"},
+		{"allowed", map[string]interface{}{sosURLBucket: "reports", sosURLKey: "monthly/jan.pdf"}, ""},
+	}
+	for _, tt := range tests {
+		ts.Run(tt.name, func() {
+			res, err := ts.backend.HandleRequest(context.Background(), &logical.Request{
+				Storage:   ts.storage,
+				Operation: logical.ReadOperation,
+				Path:      "sos-url/reports",
+				Data:      tt.data,
+			})
+			ts.Require().NoError(err)
+			if tt.err != "" {
+				ts.Require().ErrorContains(res.Error(), tt.err)
+				return
+			}
+			ts.Require().Contains(res.Data["url"], "X-Amz-Expires=600")
+			ts.Require().InDelta(600, res.Data["ttl"], 5)
+			ts.Require().Nil(res.Secret)
+		})
+	}
+}
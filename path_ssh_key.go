@@ -0,0 +1,165 @@
+package exoscale
+
+import (
+	"context"
+	"crypto/ed25519"
+	"crypto/rand"
+	"encoding/pem"
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/vault/sdk/framework"
+	"github.com/hashicorp/vault/sdk/logical"
+	"golang.org/x/crypto/ssh"
+)
+
+const configSSHKeyPublicKey = "public_key"
+
+const (
+	pathSSHKeyHelpSyn  = "Register an SSH key in Exoscale"
+	pathSSHKeyHelpDesc = `
+This endpoint registers a uniquely named SSH key in the Exoscale organization, which
+can be set on new compute instances, and deletes it when the lease is revoked.
+
+If public_key is set, the given public key is registered. Otherwise, an ed25519 key
+pair is generated and its private key returned in private_key, in the OpenSSH format.
+
+Deleting the SSH key doesn't remove it from the instances it has been set on.
+
+Fields:
+	public_key: SSH public key to register, as in authorized_keys files (optional)
+
+Example:
+    vault read exoscale/ssh-key/ops
+    vault write exoscale/ssh-key/ops public_key=@$HOME/.ssh/id_ed25519.pub
+`
+)
+
+func (b *exoscaleBackend) pathSSHKey() *framework.Path {
+	return &framework.Path{
+		Pattern: "ssh-key/" + framework.GenericNameRegex(configVaultRoleName),
+		Fields: map[string]*framework.FieldSchema{
+			configVaultRoleName: {
+				Type:        framework.TypeString,
+				Description: "Name of the SSH key role",
+				Required:    true,
+			},
+			configSSHKeyPublicKey: {
+				Type:        framework.TypeString,
+				Description: "SSH public key to register (optional, default: a generated ed25519 key)",
+			},
+		},
+
+		Operations: map[logical.Operation]framework.OperationHandler{
+			logical.ReadOperation:   &framework.PathOperation{Callback: b.readSSHKey},
+			logical.UpdateOperation: &framework.PathOperation{Callback: b.readSSHKey},
+		},
+
+		HelpSynopsis:    pathSSHKeyHelpSyn,
+		HelpDescription: pathSSHKeyHelpDesc,
+	}
+}
+
+// generateSSHKey generates an ed25519 key pair, returning the public key and the
+// private key in the OpenSSH format
+func generateSSHKey(comment string) (ssh.PublicKey, string, error) {
+	pub, priv, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		return nil, "", fmt.Errorf("unable to generate SSH key: %w", err)
+	}
+
+	publicKey, err := ssh.NewPublicKey(pub)
+	if err != nil {
+		return nil, "", fmt.Errorf("unable to generate SSH key: %w", err)
+	}
+
+	block, err := ssh.MarshalPrivateKey(priv, comment)
+	if err != nil {
+		return nil, "", fmt.Errorf("unable to encode SSH private key: %w", err)
+	}
+
+	return publicKey, string(pem.EncodeToMemory(block)), nil
+}
+
+func (b *exoscaleBackend) readSSHKey(
+	ctx context.Context,
+	req *logical.Request,
+	data *framework.FieldData,
+) (*logical.Response, error) {
+	roleName := data.Get(configVaultRoleName).(string)
+	role, err := getSSHKeyRole(ctx, req.Storage, roleName)
+	if err != nil {
+		return nil, err
+	}
+	if role == nil {
+		return logical.ErrorResponse("SSH key role %q not found", roleName), nil
+	}
+
+	exo, err := b.exoscale(ctx, req.Storage, role.Account)
+	if err != nil {
+		return logical.ErrorResponse(err.Error()), nil
+	}
+
+	name, err := exo.resourceName(nameData{
+		RoleName:      roleName,
+		DisplayName:   req.DisplayName,
+		EntityID:      req.EntityID,
+		MountAccessor: req.MountAccessor,
+	}, "")
+	if err != nil {
+		return nil, err
+	}
+
+	var publicKey ssh.PublicKey
+	var privateKey string
+	if v := data.Get(configSSHKeyPublicKey).(string); v != "" {
+		if publicKey, _, _, _, err = ssh.ParseAuthorizedKey([]byte(v)); err != nil {
+			return logical.ErrorResponse("invalid %s: %s", configSSHKeyPublicKey, err), nil
+		}
+	} else {
+		if publicKey, privateKey, err = generateSSHKey(name); err != nil {
+			return nil, err
+		}
+	}
+
+	authorizedKey := strings.TrimSpace(string(ssh.MarshalAuthorizedKey(publicKey)))
+	if err := exo.V3RegisterSSHKey(ctx, name, authorizedKey); err != nil {
+		return nil, err
+	}
+
+	if err := putAccountLease(ctx, req.Storage, role.Account, SecretTypeSSHKey, name); err != nil {
+		if err := exo.V3DeleteSSHKey(ctx, name); err != nil {
+			b.Logger().Warn("Failed to clean up SSH key", "name", name, "err", err)
+		}
+		return nil, err
+	}
+
+	resData := map[string]interface{}{
+		"name":                name,
+		configSSHKeyPublicKey: authorizedKey,
+		"fingerprint":         ssh.FingerprintSHA256(publicKey),
+	}
+	if privateKey != "" {
+		resData["private_key"] = privateKey
+	}
+
+	res := b.Secret(SecretTypeSSHKey).Response(
+		resData,
+		map[string]interface{}{
+			"role":            roleName,
+			"name":            name,
+			configRoleAccount: role.Account,
+		})
+	res.Secret.TTL = role.TTL
+	res.Secret.MaxTTL = role.MaxTTL
+	res.Secret.Renewable = true
+
+	b.Logger().Info("SSH key registered",
+		"role", roleName,
+		"name", name,
+		"fingerprint", resData["fingerprint"],
+		"generated", privateKey != "",
+		"entity_id", req.EntityID)
+
+	return res, nil
+}
@@ -0,0 +1,205 @@
+package exoscale
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/hashicorp/vault/sdk/framework"
+	"github.com/hashicorp/vault/sdk/logical"
+)
+
+// SSHKeyRole is a role registering SSH keys in Exoscale for leases
+type SSHKeyRole struct {
+	TTL     time.Duration `json:"ttl,omitempty"`
+	MaxTTL  time.Duration `json:"max_ttl,omitempty"`
+	Account string        `json:"account,omitempty"`
+}
+
+const sshKeyRoleStoragePathPrefix = "ssh-key-role/"
+
+const (
+	pathListSSHKeyRolesHelpSyn  = "List the configured SSH key roles"
+	pathListSSHKeyRolesHelpDesc = `
+This endpoint returns a list of the configured SSH key roles.
+`
+
+	pathSSHKeyRoleHelpSyn  = "Manage SSH key roles"
+	pathSSHKeyRoleHelpDesc = `
+Manage SSH key roles, registering an SSH key in the Exoscale organization for each
+lease of the ssh-key/<name> endpoint. The SSH key is deleted when the lease is revoked.
+
+Fields:
+	ttl: default TTL of the leases (optional, default: the default lease TTL of the mount)
+	max_ttl: maximum TTL of the leases (optional, default: the maximum lease TTL of the mount)
+	account: name of the account configured with config/account/<name> to register the keys in (optional)
+
+Example:
+    vault write exoscale/ssh-key-role/ops ttl=8h max_ttl=24h
+`
+)
+
+func (b *exoscaleBackend) pathSSHKeyRole() []*framework.Path {
+	return []*framework.Path{
+		{
+			Pattern: "ssh-key-role/" + framework.GenericNameRegex(configVaultRoleName),
+			Fields: map[string]*framework.FieldSchema{
+				configVaultRoleName: {
+					Type:        framework.TypeString,
+					Description: "Name of the SSH key role",
+					Required:    true,
+				},
+				configRoleTTL: {
+					Type:        framework.TypeDurationSecond,
+					Description: "Default TTL of the leases",
+				},
+				configRoleMaxTTL: {
+					Type:        framework.TypeDurationSecond,
+					Description: "Maximum TTL of the leases",
+				},
+				configRoleAccount: {
+					Type:        framework.TypeString,
+					Description: "Name of the account configured with config/account/<name> to register the keys in (optional)",
+				},
+			},
+
+			Operations: map[logical.Operation]framework.OperationHandler{
+				logical.CreateOperation: &framework.PathOperation{Callback: b.writeSSHKeyRole},
+				logical.UpdateOperation: &framework.PathOperation{Callback: b.writeSSHKeyRole},
+				logical.ReadOperation:   &framework.PathOperation{Callback: b.readSSHKeyRole},
+				logical.DeleteOperation: &framework.PathOperation{Callback: b.deleteSSHKeyRole},
+			},
+
+			HelpSynopsis:    pathSSHKeyRoleHelpSyn,
+			HelpDescription: pathSSHKeyRoleHelpDesc,
+		},
+		{
+			Pattern: "ssh-key-role/?$",
+
+			Operations: map[logical.Operation]framework.OperationHandler{
+				logical.ListOperation: &framework.PathOperation{Callback: b.listSSHKeyRoles},
+			},
+
+			HelpSynopsis:    pathListSSHKeyRolesHelpSyn,
+			HelpDescription: pathListSSHKeyRolesHelpDesc,
+		},
+	}
+}
+
+func getSSHKeyRole(ctx context.Context, storage logical.Storage, name string) (*SSHKeyRole, error) {
+	if name == "" {
+		return nil, errors.New("invalid role name")
+	}
+
+	entry, err := storage.Get(ctx, sshKeyRoleStoragePathPrefix+name)
+	if err != nil {
+		return nil, fmt.Errorf("unable to retrieve SSH key role %q: %w", name, err)
+	}
+	if entry == nil {
+		return nil, nil
+	}
+
+	var role SSHKeyRole
+	if err := entry.DecodeJSON(&role); err != nil {
+		return nil, err
+	}
+
+	return &role, nil
+}
+
+func (b *exoscaleBackend) listSSHKeyRoles(
+	ctx context.Context,
+	req *logical.Request,
+	_ *framework.FieldData,
+) (*logical.Response, error) {
+	roles, err := req.Storage.List(ctx, sshKeyRoleStoragePathPrefix)
+	if err != nil {
+		return nil, err
+	}
+
+	return logical.ListResponse(roles), nil
+}
+
+func (b *exoscaleBackend) readSSHKeyRole(
+	ctx context.Context,
+	req *logical.Request,
+	data *framework.FieldData,
+) (*logical.Response, error) {
+	role, err := getSSHKeyRole(ctx, req.Storage, data.Get(configVaultRoleName).(string))
+	if err != nil {
+		return nil, err
+	}
+	if role == nil {
+		return nil, nil
+	}
+
+	res := &logical.Response{
+		Data: map[string]interface{}{
+			configRoleTTL:    role.TTL.Seconds(),
+			configRoleMaxTTL: role.MaxTTL.Seconds(),
+		},
+	}
+	if role.Account != "" {
+		res.Data[configRoleAccount] = role.Account
+	}
+
+	return res, nil
+}
+
+func (b *exoscaleBackend) writeSSHKeyRole(
+	ctx context.Context,
+	req *logical.Request,
+	data *framework.FieldData,
+) (*logical.Response, error) {
+	b.rolesLock.Lock()
+	defer b.rolesLock.Unlock()
+
+	name := data.Get(configVaultRoleName).(string)
+	role, err := getSSHKeyRole(ctx, req.Storage, name)
+	if err != nil {
+		return nil, err
+	}
+	if role == nil {
+		role = &SSHKeyRole{}
+	}
+
+	if v, ok := data.GetOk(configRoleTTL); ok {
+		role.TTL = time.Duration(v.(int)) * time.Second
+	}
+	if v, ok := data.GetOk(configRoleMaxTTL); ok {
+		role.MaxTTL = time.Duration(v.(int)) * time.Second
+	}
+	if role.MaxTTL != 0 && role.TTL > role.MaxTTL {
+		return logical.ErrorResponse("%s can't be greater than %s", configRoleTTL, configRoleMaxTTL), nil
+	}
+
+	if v, ok := data.GetOk(configRoleAccount); ok {
+		role.Account = v.(string)
+		if _, err := b.exoscale(ctx, req.Storage, role.Account); err != nil {
+			return logical.ErrorResponse(err.Error()), nil
+		}
+	}
+
+	entry, err := logical.StorageEntryJSON(sshKeyRoleStoragePathPrefix+name, role)
+	if err != nil {
+		return nil, err
+	}
+	if err := req.Storage.Put(ctx, entry); err != nil {
+		return nil, err
+	}
+
+	return nil, nil
+}
+
+func (b *exoscaleBackend) deleteSSHKeyRole(
+	ctx context.Context,
+	req *logical.Request,
+	data *framework.FieldData,
+) (*logical.Response, error) {
+	if err := req.Storage.Delete(ctx, sshKeyRoleStoragePathPrefix+data.Get(configVaultRoleName).(string)); err != nil {
+		return nil, err
+	}
+
+	return nil, nil
+}
@@ -0,0 +1,83 @@
+package exoscale
+
+import (
+	"context"
+	"strings"
+
+	"github.com/hashicorp/vault/sdk/logical"
+	"github.com/stretchr/testify/mock"
+	"golang.org/x/crypto/ssh"
+
+	"github.com/exoscale/egoscale/v2/oapi"
+)
+
+func (ts *testSuite) TestPathSSHKey() {
+	res, err := ts.backend.HandleRequest(context.Background(), &logical.Request{
+		Storage:   ts.storage,
+		Operation: logical.CreateOperation,
+		Path:      sshKeyRoleStoragePathPrefix + "ops",
+		Data:      map[string]interface{}{configRoleTTL: "8h"},
+	})
+	ts.Require().NoError(err)
+	ts.Require().Nil(res)
+
+	var registered oapi.RegisterSshKeyJSONRequestBody
+	state := oapi.OperationStateSuccess
+	mockClient := ts.backend.(*exoscaleBackend).exo.egoscaleClient.(*mockEgoscaleClient)
+	mockClient.
+		On("RegisterSshKeyWithResponse", mock.Anything, mock.Anything).
+		Run(func(args mock.Arguments) {
+			registered = args.Get(1).(oapi.RegisterSshKeyJSONRequestBody)
+		}).
+		Return(&oapi.RegisterSshKeyResponse{JSON200: &oapi.Operation{State: &state}}, nil)
+
+	// a key pair is generated without public_key
+	secret, err := ts.backend.HandleRequest(context.Background(), &logical.Request{
+		Storage:     ts.storage,
+		Operation:   logical.ReadOperation,
+		Path:        "ssh-key/ops",
+		DisplayName: "alice",
+	})
+	ts.Require().NoError(err)
+	ts.Require().True(strings.HasPrefix(registered.Name, "vault-ops-alice-"), registered.Name)
+	ts.Require().Equal(registered.Name, secret.Data["name"])
+	ts.Require().Equal(registered.PublicKey, secret.Data[configSSHKeyPublicKey])
+	signer, err := ssh.ParsePrivateKey([]byte(secret.Data["private_key"].(string)))
+	ts.Require().NoError(err)
+	ts.Require().Equal(registered.PublicKey, strings.TrimSpace(string(ssh.MarshalAuthorizedKey(signer.PublicKey()))))
+
+	var deleted string
+	mockClient.
+		On("DeleteSshKeyWithResponse", mock.Anything, mock.Anything).
+		Run(func(args mock.Arguments) { deleted = args.Get(1).(string) }).
+		Return(&oapi.DeleteSshKeyResponse{JSON200: &oapi.Operation{State: &state}}, nil)
+
+	_, err = ts.backend.HandleRequest(context.Background(), &logical.Request{
+		Storage:   ts.storage,
+		Operation: logical.RevokeOperation,
+		Path:      secret.Secret.LeaseID,
+		Secret:    secret.Secret,
+	})
+	ts.Require().NoError(err)
+	ts.Require().Equal(registered.Name, deleted)
+
+	// a provided public key is registered as is
+	res, err = ts.backend.HandleRequest(context.Background(), &logical.Request{
+		Storage:   ts.storage,
+		Operation: logical.UpdateOperation,
+		Path:      "ssh-key/ops",
+		Data:      map[string]interface{}{configSSHKeyPublicKey: "ssh-ed25519 not-a-key"},
+	})
+	ts.Require().NoError(err)
+	ts.Require().ErrorContains(res.Error(), "invalid "+configSSHKeyPublicKey)
+
+	res, err = ts.backend.HandleRequest(context.Background(), &logical.Request{
+		Storage:   ts.storage,
+		Operation: logical.UpdateOperation,
+		Path:      "ssh-key/ops",
+		Data:      map[string]interface{}{configSSHKeyPublicKey: registered.PublicKey + " alice@laptop"},
+	})
+	ts.Require().NoError(err)
+	ts.Require().Equal(secret.Data[configSSHKeyPublicKey], registered.PublicKey)
+	ts.Require().NotContains(res.Data, "private_key")
+}
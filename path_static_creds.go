@@ -0,0 +1,65 @@
+package exoscale
+
+import (
+	"context"
+	"time"
+
+	"github.com/hashicorp/vault/sdk/framework"
+	"github.com/hashicorp/vault/sdk/logical"
+)
+
+const (
+	pathStaticCredsHelpSyn  = "Retrieve the current credentials of a static role"
+	pathStaticCredsHelpDesc = `
+This endpoint returns the current Exoscale API key/secret credentials of a
+static role. The credentials are rotated by Vault every rotation_period, the
+ttl field indicates how long until the next rotation.
+`
+)
+
+func (b *exoscaleBackend) pathStaticCreds() *framework.Path {
+	return &framework.Path{
+		Pattern: "static-creds/" + framework.GenericNameRegex(configVaultRoleName),
+		Fields: map[string]*framework.FieldSchema{
+			configVaultRoleName: {
+				Type:        framework.TypeString,
+				Description: "Name of the static role",
+				Required:    true,
+			},
+		},
+
+		Operations: map[logical.Operation]framework.OperationHandler{
+			logical.ReadOperation: &framework.PathOperation{Callback: b.readStaticCreds},
+		},
+
+		HelpSynopsis:    pathStaticCredsHelpSyn,
+		HelpDescription: pathStaticCredsHelpDesc,
+	}
+}
+
+func (b *exoscaleBackend) readStaticCreds(
+	ctx context.Context,
+	req *logical.Request,
+	data *framework.FieldData,
+) (*logical.Response, error) {
+	name := data.Get(configVaultRoleName).(string)
+
+	role, err := getStaticRole(ctx, req.Storage, name)
+	if err != nil {
+		return nil, err
+	}
+	if role == nil {
+		return logical.ErrorResponse("static role %q not found", name), nil
+	}
+
+	return &logical.Response{
+		Data: map[string]interface{}{
+			apiKeySecretDataName:           role.APIKeyName,
+			apiKeySecretDataAPIKey:         role.APIKey,
+			apiKeySecretDataAPISecret:      role.APISecret,
+			"last_rotated":                 role.LastRotated,
+			configStaticRoleRotationPeriod: role.RotationPeriod.Seconds(),
+			"ttl":                          int64(time.Until(role.nextRotation()).Seconds()),
+		},
+	}, nil
+}
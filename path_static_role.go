@@ -0,0 +1,361 @@
+package exoscale
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/hashicorp/vault/sdk/framework"
+	"github.com/hashicorp/vault/sdk/logical"
+)
+
+// StaticRole is a role owning a single long-lived API key, rotated by the backend
+type StaticRole struct {
+	IAMRoleID      string        `json:"iam_role_id"`
+	IAMRoleName    string        `json:"iam_role_name"`
+	RotationPeriod time.Duration `json:"rotation_period"`
+
+	// Current credentials
+	APIKey      string    `json:"api_key,omitempty"`
+	APISecret   string    `json:"api_secret,omitempty"`
+	APIKeyName  string    `json:"api_key_name,omitempty"`
+	LastRotated time.Time `json:"last_rotated,omitempty"`
+
+	// Previous key, kept until its deletion succeeds
+	PreviousAPIKey string `json:"previous_api_key,omitempty"`
+}
+
+func (role *StaticRole) nextRotation() time.Time {
+	return role.LastRotated.Add(role.RotationPeriod)
+}
+
+const (
+	staticRoleStoragePathPrefix = "static-role/"
+
+	configStaticRoleRotationPeriod = "rotation_period"
+
+	minStaticRoleRotationPeriod = time.Minute
+)
+
+const (
+	pathListStaticRolesHelpSyn  = "List the configured static roles"
+	pathListStaticRolesHelpDesc = `
+This endpoint returns a list of the configured static roles.
+`
+
+	pathStaticRoleHelpSyn  = "Manage static roles"
+	pathStaticRoleHelpDesc = `
+Manage static roles, each owning a single long-lived Exoscale API key that
+is rotated by Vault every rotation_period.
+
+Static roles are meant for workloads that can't handle dynamic leases, the
+current credentials are returned by the static-creds/<name> endpoint.
+Only IAM API Keys (v3) are supported, the keys are always created with the
+credentials of config/root.
+
+Fields:
+	iam-role: name or id of the IAM Role
+	rotation_period: how often the API key is rotated (in seconds unless a unit is specified: "s", "m", "h")
+
+Example:
+    vault write exoscale/static-role/backup \
+	iam-role=vault-role-backup \
+	rotation_period=24h
+`
+)
+
+func (b *exoscaleBackend) pathStaticRole() []*framework.Path {
+	return []*framework.Path{
+		{
+			Pattern: "static-role/" + framework.GenericNameRegex(configVaultRoleName),
+			Fields: map[string]*framework.FieldSchema{
+				configVaultRoleName: {
+					Type:        framework.TypeString,
+					Description: "Name of the static role",
+					Required:    true,
+				},
+				configIAMRole: {
+					Type:        framework.TypeString,
+					Description: "Name or ID of an Exoscale IAM role created externally (e.g. with terraform)",
+				},
+				configStaticRoleRotationPeriod: {
+					Type:        framework.TypeDurationSecond,
+					Description: "Period after which the API key is rotated",
+				},
+			},
+
+			Operations: map[logical.Operation]framework.OperationHandler{
+				logical.CreateOperation: &framework.PathOperation{Callback: b.writeStaticRole},
+				logical.UpdateOperation: &framework.PathOperation{Callback: b.writeStaticRole},
+				logical.ReadOperation:   &framework.PathOperation{Callback: b.readStaticRole},
+				logical.DeleteOperation: &framework.PathOperation{Callback: b.deleteStaticRole},
+			},
+
+			HelpSynopsis:    pathStaticRoleHelpSyn,
+			HelpDescription: pathStaticRoleHelpDesc,
+		},
+		{
+			Pattern: "static-role/?$",
+
+			Operations: map[logical.Operation]framework.OperationHandler{
+				logical.ListOperation: &framework.PathOperation{Callback: b.listStaticRoles},
+			},
+
+			HelpSynopsis:    pathListStaticRolesHelpSyn,
+			HelpDescription: pathListStaticRolesHelpDesc,
+		},
+	}
+}
+
+func getStaticRole(ctx context.Context, storage logical.Storage, name string) (*StaticRole, error) {
+	if name == "" {
+		return nil, errors.New("invalid role name")
+	}
+
+	entry, err := storage.Get(ctx, staticRoleStoragePathPrefix+name)
+	if err != nil {
+		return nil, fmt.Errorf("unable to retrieve static role %q: %w", name, err)
+	}
+	if entry == nil {
+		return nil, nil
+	}
+
+	var role StaticRole
+	if err := entry.DecodeJSON(&role); err != nil {
+		return nil, err
+	}
+
+	return &role, nil
+}
+
+func putStaticRole(ctx context.Context, storage logical.Storage, name string, role *StaticRole) error {
+	entry, err := logical.StorageEntryJSON(staticRoleStoragePathPrefix+name, role)
+	if err != nil {
+		return err
+	}
+
+	return storage.Put(ctx, entry)
+}
+
+func (b *exoscaleBackend) listStaticRoles(
+	ctx context.Context,
+	req *logical.Request,
+	_ *framework.FieldData,
+) (*logical.Response, error) {
+	roles, err := req.Storage.List(ctx, staticRoleStoragePathPrefix)
+	if err != nil {
+		return nil, err
+	}
+
+	return logical.ListResponse(roles), nil
+}
+
+func (b *exoscaleBackend) readStaticRole(
+	ctx context.Context,
+	req *logical.Request,
+	data *framework.FieldData,
+) (*logical.Response, error) {
+	role, err := getStaticRole(ctx, req.Storage, data.Get(configVaultRoleName).(string))
+	if err != nil {
+		return nil, err
+	}
+	if role == nil {
+		return nil, nil
+	}
+
+	return &logical.Response{
+		Data: map[string]interface{}{
+			"iam-role-id":                  role.IAMRoleID,
+			"iam-role-name":                role.IAMRoleName,
+			configStaticRoleRotationPeriod: role.RotationPeriod.Seconds(),
+			apiKeySecretDataAPIKey:         role.APIKey,
+			"last_rotated":                 role.LastRotated,
+		},
+	}, nil
+}
+
+func (b *exoscaleBackend) writeStaticRole(
+	ctx context.Context,
+	req *logical.Request,
+	data *framework.FieldData,
+) (*logical.Response, error) {
+	b.staticRolesLock.Lock()
+	defer b.staticRolesLock.Unlock()
+
+	name := data.Get(configVaultRoleName).(string)
+	role, err := getStaticRole(ctx, req.Storage, name)
+	if err != nil {
+		return nil, err
+	}
+	if role == nil {
+		role = &StaticRole{}
+	}
+
+	previousIAMRoleID := role.IAMRoleID
+
+	if r, ok := data.GetOk(configIAMRole); ok {
+		iamrole, err := b.exo.V3GetRole(ctx, r.(string))
+		if errors.Is(err, ErrNotFound) {
+			return logical.ErrorResponse("IAM role %q not found", r.(string)), nil
+		} else if err != nil {
+			return nil, err
+		}
+		role.IAMRoleID = *iamrole.Id
+		role.IAMRoleName = *iamrole.Name
+	}
+	if role.IAMRoleID == "" {
+		return nil, fmt.Errorf("%s is required", configIAMRole)
+	}
+
+	if p, ok := data.GetOk(configStaticRoleRotationPeriod); ok {
+		role.RotationPeriod = time.Duration(p.(int)) * time.Second
+	}
+	if role.RotationPeriod < minStaticRoleRotationPeriod {
+		return nil, fmt.Errorf("%s must be at least %s", configStaticRoleRotationPeriod, minStaticRoleRotationPeriod)
+	}
+
+	// a new key is required on creation, or when the key would otherwise
+	// keep the permissions of the previous IAM role
+	if role.APIKey == "" || role.IAMRoleID != previousIAMRoleID {
+		if err := b.rotateStaticRole(ctx, req.Storage, name, role); err != nil {
+			return nil, err
+		}
+		return nil, nil
+	}
+
+	if err := putStaticRole(ctx, req.Storage, name, role); err != nil {
+		return nil, err
+	}
+
+	return nil, nil
+}
+
+func (b *exoscaleBackend) deleteStaticRole(
+	ctx context.Context,
+	req *logical.Request,
+	data *framework.FieldData,
+) (*logical.Response, error) {
+	b.staticRolesLock.Lock()
+	defer b.staticRolesLock.Unlock()
+
+	name := data.Get(configVaultRoleName).(string)
+	role, err := getStaticRole(ctx, req.Storage, name)
+	if err != nil {
+		return nil, err
+	}
+	if role == nil {
+		return nil, nil
+	}
+
+	for _, key := range []string{role.APIKey, role.PreviousAPIKey} {
+		if key == "" {
+			continue
+		}
+		if err := b.exo.V3DeleteAPIKey(ctx, key); err != nil && !errors.Is(err, ErrNotFound) {
+			return nil, fmt.Errorf("unable to delete the API key of static role %q: %w", name, err)
+		}
+	}
+
+	if err := req.Storage.Delete(ctx, staticRoleStoragePathPrefix+name); err != nil {
+		return nil, err
+	}
+
+	return nil, nil
+}
+
+// rotateStaticRole replaces the API key of a static role, the new key is
+// persisted before the previous one is deleted so that credentials are never lost.
+// Callers must hold staticRolesLock.
+func (b *exoscaleBackend) rotateStaticRole(ctx context.Context, storage logical.Storage, name string, role *StaticRole) error {
+	if err := b.deletePreviousStaticRoleKey(ctx, storage, name, role); err != nil {
+		return err
+	}
+	if role.PreviousAPIKey != "" {
+		return fmt.Errorf("unable to rotate static role %q: previous API key %q is still pending deletion",
+			name, role.PreviousAPIKey)
+	}
+
+	keyName, err := b.exo.resourceName(nameData{RoleName: name, DisplayName: "static"}, "")
+	if err != nil {
+		return err
+	}
+
+	apikey, err := b.exo.V3CreateAPIKey(ctx, keyName, Role{IAMRoleID: role.IAMRoleID})
+	if err != nil {
+		return fmt.Errorf("unable to rotate static role %q: %w", name, err)
+	}
+
+	role.PreviousAPIKey = role.APIKey
+	role.APIKey = *apikey.Key
+	role.APISecret = *apikey.Secret
+	role.APIKeyName = *apikey.Name
+	role.LastRotated = time.Now()
+
+	if err := putStaticRole(ctx, storage, name, role); err != nil {
+		// the new key can't be used if it isn't persisted
+		if err := b.exo.V3DeleteAPIKey(ctx, *apikey.Key); err != nil {
+			b.Logger().Warn("Failed to clean up static role API key", "role", name, "iam_key", *apikey.Key, "err", err)
+		}
+		return err
+	}
+
+	b.Logger().Info("Static role rotated", "role", name, "iam_key", role.APIKey, "iam_name", role.APIKeyName)
+
+	return b.deletePreviousStaticRoleKey(ctx, storage, name, role)
+}
+
+func (b *exoscaleBackend) deletePreviousStaticRoleKey(ctx context.Context, storage logical.Storage, name string, role *StaticRole) error {
+	if role.PreviousAPIKey == "" {
+		return nil
+	}
+
+	if err := b.exo.V3DeleteAPIKey(ctx, role.PreviousAPIKey); err != nil && !errors.Is(err, ErrNotFound) {
+		// deletion will be retried on the next periodic run
+		b.Logger().Warn("Failed to delete previous static role API key", "role", name, "iam_key", role.PreviousAPIKey, "err", err)
+		return nil
+	}
+
+	role.PreviousAPIKey = ""
+	return putStaticRole(ctx, storage, name, role)
+}
+
+// rotateStaticRoles rotates the static roles whose rotation period has elapsed
+func (b *exoscaleBackend) rotateStaticRoles(ctx context.Context, storage logical.Storage) error {
+	// static roles always use config/root
+	if !b.exo.isConfigured() {
+		return nil
+	}
+
+	b.staticRolesLock.Lock()
+	defer b.staticRolesLock.Unlock()
+
+	names, err := storage.List(ctx, staticRoleStoragePathPrefix)
+	if err != nil {
+		return err
+	}
+
+	var errs error
+	for _, name := range names {
+		role, err := getStaticRole(ctx, storage, name)
+		if err != nil {
+			errs = errors.Join(errs, err)
+			continue
+		}
+		if role == nil {
+			continue
+		}
+
+		if time.Now().Before(role.nextRotation()) {
+			errs = errors.Join(errs, b.deletePreviousStaticRoleKey(ctx, storage, name, role))
+			continue
+		}
+
+		if err := b.rotateStaticRole(ctx, storage, name, role); err != nil {
+			b.Logger().Error("Failed to rotate static role", "role", name, "err", err)
+			errs = errors.Join(errs, err)
+		}
+	}
+
+	return errs
+}
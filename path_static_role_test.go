@@ -0,0 +1,158 @@
+package exoscale
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/hashicorp/vault/sdk/logical"
+	"github.com/stretchr/testify/mock"
+
+	"github.com/exoscale/egoscale/v2/oapi"
+)
+
+const testStaticRoleName = "backup"
+
+// mockStaticRoleAPIKeys sets up the mock to create API keys named key-1, key-2...
+// and returns the list of deleted keys
+func (ts *testSuite) mockStaticRoleAPIKeys(iamRoleID string) *[]string {
+	var created int
+	deleted := []string{}
+	state := oapi.OperationStateSuccess
+
+	mockClient := ts.backend.(*exoscaleBackend).exo.egoscaleClient.(*mockEgoscaleClient)
+	mockClient.
+		On("CreateApiKeyWithResponse", mock.Anything, mock.Anything).
+		Return(func(_ context.Context, body oapi.CreateApiKeyJSONRequestBody, _ ...oapi.RequestEditorFn) (*oapi.CreateApiKeyResponse, error) {
+			ts.Require().Equal(iamRoleID, body.RoleId)
+			ts.Require().Regexp("^vault-"+testStaticRoleName+"-static-[0-9]{19}$", body.Name)
+			created++
+			key := fmt.Sprintf("key-%d", created)
+			secret := fmt.Sprintf("secret-%d", created)
+			return &oapi.CreateApiKeyResponse{
+				JSON200: &oapi.IamApiKeyCreated{Key: &key, Name: &body.Name, RoleId: &body.RoleId, Secret: &secret},
+			}, nil
+		})
+	mockClient.
+		On("DeleteApiKeyWithResponse", mock.Anything, mock.Anything).
+		Run(func(args mock.Arguments) {
+			deleted = append(deleted, args.Get(1).(string))
+		}).
+		Return(&oapi.DeleteApiKeyResponse{JSON200: &oapi.Operation{State: &state}}, nil)
+
+	return &deleted
+}
+
+func (ts *testSuite) TestPathStaticRoleLifecycle() {
+	iamRoleID := ts.randomID()
+	iamRoleName := "backup-iam-role"
+
+	ts.backend.(*exoscaleBackend).exo.egoscaleClient.(*mockEgoscaleClient).
+		On("GetIamRoleWithResponse", mock.Anything, iamRoleID).
+		Return(&oapi.GetIamRoleResponse{
+			JSON200: &oapi.IamRole{Id: &iamRoleID, Name: &iamRoleName},
+		}, nil)
+	deleted := ts.mockStaticRoleAPIKeys(iamRoleID)
+
+	_, err := ts.backend.HandleRequest(context.Background(), &logical.Request{
+		Storage:   ts.storage,
+		Operation: logical.CreateOperation,
+		Path:      staticRoleStoragePathPrefix + testStaticRoleName,
+		Data: map[string]interface{}{
+			configIAMRole:                  iamRoleID,
+			configStaticRoleRotationPeriod: "1h",
+		},
+	})
+	ts.Require().NoError(err)
+
+	res, err := ts.backend.HandleRequest(context.Background(), &logical.Request{
+		Storage:   ts.storage,
+		Operation: logical.ReadOperation,
+		Path:      "static-creds/" + testStaticRoleName,
+	})
+	ts.Require().NoError(err)
+	ts.Require().Equal("key-1", res.Data[apiKeySecretDataAPIKey])
+	ts.Require().Equal("secret-1", res.Data[apiKeySecretDataAPISecret])
+	ts.Require().Equal(float64(3600), res.Data[configStaticRoleRotationPeriod])
+	ts.Require().InDelta(3600, res.Data["ttl"], 5)
+
+	// the rotation period hasn't elapsed, nothing to do
+	_, err = ts.backend.HandleRequest(context.Background(), &logical.Request{
+		Storage:   ts.storage,
+		Operation: logical.RollbackOperation,
+	})
+	ts.Require().NoError(err)
+	ts.Require().Empty(*deleted)
+
+	role, err := getStaticRole(context.Background(), ts.storage, testStaticRoleName)
+	ts.Require().NoError(err)
+	role.LastRotated = time.Now().Add(-2 * time.Hour)
+	ts.storeEntry(staticRoleStoragePathPrefix+testStaticRoleName, role)
+
+	_, err = ts.backend.HandleRequest(context.Background(), &logical.Request{
+		Storage:   ts.storage,
+		Operation: logical.RollbackOperation,
+	})
+	ts.Require().NoError(err)
+	ts.Require().Equal([]string{"key-1"}, *deleted)
+
+	role, err = getStaticRole(context.Background(), ts.storage, testStaticRoleName)
+	ts.Require().NoError(err)
+	ts.Require().Equal("key-2", role.APIKey)
+	ts.Require().Equal("secret-2", role.APISecret)
+	ts.Require().Empty(role.PreviousAPIKey)
+
+	_, err = ts.backend.HandleRequest(context.Background(), &logical.Request{
+		Storage:   ts.storage,
+		Operation: logical.DeleteOperation,
+		Path:      staticRoleStoragePathPrefix + testStaticRoleName,
+	})
+	ts.Require().NoError(err)
+	ts.Require().Equal([]string{"key-1", "key-2"}, *deleted)
+
+	role, err = getStaticRole(context.Background(), ts.storage, testStaticRoleName)
+	ts.Require().NoError(err)
+	ts.Require().Nil(role)
+}
+
+func (ts *testSuite) TestPathStaticRoleWriteInvalid() {
+	iamRoleID := ts.randomID()
+	iamRoleName := "backup-iam-role"
+	ts.backend.(*exoscaleBackend).exo.egoscaleClient.(*mockEgoscaleClient).
+		On("GetIamRoleWithResponse", mock.Anything, iamRoleID).
+		Return(&oapi.GetIamRoleResponse{
+			JSON200: &oapi.IamRole{Id: &iamRoleID, Name: &iamRoleName},
+		}, nil)
+
+	tests := []struct {
+		name    string
+		data    map[string]interface{}
+		wantErr string
+	}{
+		{
+			name:    "missing iam-role",
+			data:    map[string]interface{}{configStaticRoleRotationPeriod: "1h"},
+			wantErr: "iam-role is required",
+		},
+		{
+			name: "rotation period too short",
+			data: map[string]interface{}{
+				configIAMRole:                  iamRoleID,
+				configStaticRoleRotationPeriod: "10s",
+			},
+			wantErr: "rotation_period must be at least 1m0s",
+		},
+	}
+
+	for _, tt := range tests {
+		ts.Run(tt.name, func() {
+			_, err := ts.backend.HandleRequest(context.Background(), &logical.Request{
+				Storage:   ts.storage,
+				Operation: logical.CreateOperation,
+				Path:      staticRoleStoragePathPrefix + testStaticRoleName,
+				Data:      tt.data,
+			})
+			ts.ErrorContains(err, tt.wantErr)
+		})
+	}
+}
@@ -0,0 +1,512 @@
+package exoscale
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/vault/sdk/framework"
+	"github.com/hashicorp/vault/sdk/logical"
+)
+
+const (
+	configTidyStoragePath = "config/tidy"
+
+	configTidyDryRun       = "dry_run"
+	configTidySafetyBuffer = "safety_buffer"
+	configTidyInterval     = "interval"
+
+	defaultTidySafetyBuffer = time.Hour
+	minTidySafetyBuffer     = 5 * time.Minute
+	minTidyInterval         = time.Hour
+)
+
+const (
+	pathTidyHelpSyn  = "Find and delete the API keys created by Vault that aren't used anymore"
+	pathTidyHelpDesc = `
+List the API keys of the organization(s) named according to the naming scheme of
+this backend (vault-<api_key_name_prefix>-<role>-<display name>-<timestamp>) and
+report the keys which aren't used by a lease, a static role or as root API key.
+Such keys can be left behind when Vault storage is restored from a backup or
+when the revocation of a lease permanently failed.
+
+By default the orphaned keys are only reported (dry_run=true), they are deleted
+when dry_run=false. Keys are only deleted from the organizations whose configuration
+(config/root or config/account/<name>) sets api_key_name_prefix, as the keys issued
+by other Vault mounts in the same organization can't be told apart otherwise: when
+several Vault mounts share an organization, each of them must use a distinct
+api_key_name_prefix.
+
+Only keys created after the backend started recording issued keys and older than
+safety_buffer are considered. The key of a lease is considered orphaned once the
+lease expired for more than safety_buffer, as its revocation failed.
+
+Example:
+    vault write exoscale/tidy dry_run=false
+`
+
+	pathConfigTidyHelpSyn  = "Configure the periodic tidy of orphaned API keys"
+	pathConfigTidyHelpDesc = `
+Run the tidy operation periodically, every interval (0 disables the periodic tidy).
+With dry_run=true, or while api_key_name_prefix isn't set, orphaned keys are only logged.
+
+Example:
+    vault write exoscale/config/tidy interval=24h dry_run=false
+`
+)
+
+// tidyConfig configures the periodic tidy
+type tidyConfig struct {
+	Interval     time.Duration `json:"interval"`
+	SafetyBuffer time.Duration `json:"safety_buffer"`
+	DryRun       bool          `json:"dry_run"`
+	LastRun      time.Time     `json:"last_run,omitempty"`
+}
+
+// orphanedKey is an API key created by Vault which isn't used anymore
+type orphanedKey struct {
+	Key     string
+	Name    string
+	Version string
+	Account string
+	Created time.Time
+}
+
+// errTidyNoKeyNamePrefix is returned when deleting orphaned keys without an api_key_name_prefix
+var errTidyNoKeyNamePrefix = fmt.Errorf(
+	"orphaned keys can't be deleted while %s is not set, keys issued by other Vault mounts in the same organization can't be told apart",
+	configAPIKeyNamePrefix)
+
+func (k *orphanedKey) responseData() map[string]interface{} {
+	return map[string]interface{}{
+		"api_key": k.Key,
+		"name":    k.Name,
+		"version": k.Version,
+		"account": k.Account,
+		"created": k.Created.Format(time.RFC3339),
+	}
+}
+
+func (b *exoscaleBackend) pathTidy() []*framework.Path {
+	safetyBuffer := &framework.FieldSchema{
+		Type:        framework.TypeDurationSecond,
+		Description: "Minimum age of the keys to consider, to leave out keys being issued (default: 1h)",
+		Default:     int(defaultTidySafetyBuffer.Seconds()),
+	}
+
+	return []*framework.Path{
+		{
+			Pattern: "tidy$",
+			Fields: map[string]*framework.FieldSchema{
+				configTidyDryRun: {
+					Type:        framework.TypeBool,
+					Description: "Only report the orphaned keys (default: true)",
+					Default:     true,
+				},
+				configTidySafetyBuffer: safetyBuffer,
+			},
+
+			Operations: map[logical.Operation]framework.OperationHandler{
+				logical.UpdateOperation: &framework.PathOperation{Callback: b.pathTidyWrite},
+			},
+
+			HelpSynopsis:    pathTidyHelpSyn,
+			HelpDescription: pathTidyHelpDesc,
+		},
+		{
+			Pattern: "config/tidy",
+			Fields: map[string]*framework.FieldSchema{
+				configTidyInterval: {
+					Type:        framework.TypeDurationSecond,
+					Description: "Interval between two runs of the periodic tidy, 0 disables it (default: 0)",
+				},
+				configTidyDryRun: {
+					Type:        framework.TypeBool,
+					Description: "Only log the orphaned keys (default: true)",
+					Default:     true,
+				},
+				configTidySafetyBuffer: safetyBuffer,
+			},
+
+			Operations: map[logical.Operation]framework.OperationHandler{
+				logical.ReadOperation:   &framework.PathOperation{Callback: b.pathConfigTidyRead},
+				logical.UpdateOperation: &framework.PathOperation{Callback: b.pathConfigTidyWrite},
+			},
+
+			HelpSynopsis:    pathConfigTidyHelpSyn,
+			HelpDescription: pathConfigTidyHelpDesc,
+		},
+	}
+}
+
+func getTidyConfig(ctx context.Context, storage logical.Storage) (*tidyConfig, error) {
+	entry, err := storage.Get(ctx, configTidyStoragePath)
+	if err != nil {
+		return nil, err
+	}
+
+	config := tidyConfig{SafetyBuffer: defaultTidySafetyBuffer, DryRun: true}
+	if entry == nil {
+		return &config, nil
+	}
+
+	if err := entry.DecodeJSON(&config); err != nil {
+		return nil, err
+	}
+
+	return &config, nil
+}
+
+func putTidyConfig(ctx context.Context, storage logical.Storage, config *tidyConfig) error {
+	entry, err := logical.StorageEntryJSON(configTidyStoragePath, config)
+	if err != nil {
+		return err
+	}
+
+	return storage.Put(ctx, entry)
+}
+
+func (b *exoscaleBackend) pathConfigTidyRead(
+	ctx context.Context,
+	req *logical.Request,
+	_ *framework.FieldData,
+) (*logical.Response, error) {
+	config, err := getTidyConfig(ctx, req.Storage)
+	if err != nil {
+		return nil, err
+	}
+
+	res := &logical.Response{
+		Data: map[string]interface{}{
+			configTidyInterval:     int64(config.Interval.Seconds()),
+			configTidySafetyBuffer: int64(config.SafetyBuffer.Seconds()),
+			configTidyDryRun:       config.DryRun,
+		},
+	}
+	if !config.LastRun.IsZero() {
+		res.Data["last_run"] = config.LastRun.Format(time.RFC3339)
+	}
+
+	return res, nil
+}
+
+func (b *exoscaleBackend) pathConfigTidyWrite(
+	ctx context.Context,
+	req *logical.Request,
+	data *framework.FieldData,
+) (*logical.Response, error) {
+	config, err := getTidyConfig(ctx, req.Storage)
+	if err != nil {
+		return nil, err
+	}
+
+	if v, ok := data.GetOk(configTidyInterval); ok {
+		config.Interval = time.Duration(v.(int)) * time.Second
+	}
+	if v, ok := data.GetOk(configTidySafetyBuffer); ok {
+		config.SafetyBuffer = time.Duration(v.(int)) * time.Second
+	}
+	if v, ok := data.GetOk(configTidyDryRun); ok {
+		config.DryRun = v.(bool)
+	}
+
+	if config.Interval != 0 && config.Interval < minTidyInterval {
+		return logical.ErrorResponse("%s must be at least %s", configTidyInterval, minTidyInterval), nil
+	}
+	if config.SafetyBuffer < minTidySafetyBuffer {
+		return logical.ErrorResponse("%s must be at least %s", configTidySafetyBuffer, minTidySafetyBuffer), nil
+	}
+
+	if err := putTidyConfig(ctx, req.Storage, config); err != nil {
+		return nil, err
+	}
+
+	if !config.DryRun && b.exo.keyNamePrefix() == "" {
+		res := &logical.Response{}
+		res.AddWarning(fmt.Sprintf("%s: %s, the periodic tidy only logs them", configRootStoragePath, errTidyNoKeyNamePrefix))
+		return res, nil
+	}
+
+	return nil, nil
+}
+
+func (b *exoscaleBackend) pathTidyWrite(
+	ctx context.Context,
+	req *logical.Request,
+	data *framework.FieldData,
+) (*logical.Response, error) {
+	dryRun := data.Get(configTidyDryRun).(bool)
+	safetyBuffer := time.Duration(data.Get(configTidySafetyBuffer).(int)) * time.Second
+	if safetyBuffer < minTidySafetyBuffer {
+		return logical.ErrorResponse("%s must be at least %s", configTidySafetyBuffer, minTidySafetyBuffer), nil
+	}
+	orphans, deleted, unprefixed, err := b.tidy(ctx, req.Storage, dryRun, safetyBuffer)
+	if err != nil && orphans == nil {
+		return nil, err
+	}
+
+	res := &logical.Response{
+		Data: map[string]interface{}{
+			configTidyDryRun: dryRun,
+		},
+	}
+
+	orphansData := make([]map[string]interface{}, 0, len(orphans))
+	for _, k := range orphans {
+		orphansData = append(orphansData, k.responseData())
+	}
+	res.Data["orphans"] = orphansData
+	if !dryRun {
+		res.Data["deleted"] = deleted
+	}
+
+	if err != nil {
+		res.AddWarning(err.Error())
+	}
+
+	for _, config := range unprefixed {
+		if dryRun {
+			res.AddWarning(fmt.Sprintf("%s: %s is not set, keys issued by other Vault mounts in the same organization are reported as orphans",
+				config, configAPIKeyNamePrefix))
+		} else {
+			res.AddWarning(fmt.Sprintf("%s: %s, they are only reported", config, errTidyNoKeyNamePrefix))
+		}
+	}
+
+	return res, nil
+}
+
+// tidy finds the orphaned API keys of all the accounts and deletes them unless dryRun is set.
+// The orphaned keys of the accounts without api_key_name_prefix, whose configuration paths are
+// returned in unprefixed, are never deleted. Errors affecting a single account or key are
+// returned along with the orphans found.
+func (b *exoscaleBackend) tidy(
+	ctx context.Context,
+	storage logical.Storage,
+	dryRun bool,
+	safetyBuffer time.Duration,
+) (orphans []*orphanedKey, deleted, unprefixed []string, err error) {
+	if !b.tidyLock.TryLock() {
+		return nil, nil, nil, errors.New("tidy is already running")
+	}
+	defer b.tidyLock.Unlock()
+
+	inventoryStart, err := getInventoryStart(ctx, storage)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	if inventoryStart.IsZero() {
+		return nil, nil, nil, errors.New("issued keys aren't recorded yet")
+	}
+
+	live, err := b.liveKeys(ctx, storage, safetyBuffer)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+
+	accounts, err := storage.List(ctx, configAccountStoragePathPrefix)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+
+	orphans = []*orphanedKey{}
+	var errs error
+	for _, account := range append([]string{""}, accounts...) {
+		exo, err := b.exoscale(ctx, storage, account)
+		if err != nil {
+			errs = errors.Join(errs, err)
+			continue
+		}
+		if !exo.isConfigured() {
+			continue
+		}
+
+		// keys issued by other Vault mounts can't be told apart without a prefix
+		keep := dryRun
+		if exo.keyNamePrefix() == "" {
+			config := configRootStoragePath
+			if account != "" {
+				config = configAccountStoragePathPrefix + account
+			}
+			unprefixed = append(unprefixed, config)
+			keep = true
+		}
+
+		found, err := exo.orphanedKeys(ctx, live)
+		errs = errors.Join(errs, err)
+
+		for _, k := range found {
+			if k.Created.Before(inventoryStart) || time.Since(k.Created) < safetyBuffer {
+				continue
+			}
+			k.Account = account
+			orphans = append(orphans, k)
+
+			if keep {
+				b.Logger().Warn("Orphaned API key found", "iam_key", k.Key, "iam_name", k.Name, "account", account)
+				continue
+			}
+
+			if k.Version == "v2" {
+				err = exo.V2RevokeAccessKey(ctx, k.Key)
+			} else {
+				err = exo.V3DeleteAPIKey(ctx, k.Key)
+			}
+			if err != nil && !errors.Is(err, ErrNotFound) {
+				b.Logger().Warn("Failed to delete orphaned API key", "iam_key", k.Key, "account", account, "err", err)
+				errs = errors.Join(errs, err)
+				continue
+			}
+			b.Logger().Info("Orphaned API key deleted", "iam_key", k.Key, "iam_name", k.Name, "account", account)
+			deleted = append(deleted, k.Key)
+		}
+	}
+
+	return orphans, deleted, unprefixed, errs
+}
+
+// liveKeys returns the API keys used by leases, static roles, pools and as root API keys.
+// The key of a lease which expired for more than safetyBuffer isn't live: its revocation failed.
+func (b *exoscaleBackend) liveKeys(
+	ctx context.Context,
+	storage logical.Storage,
+	safetyBuffer time.Duration,
+) (map[string]bool, error) {
+	live := make(map[string]bool)
+
+	keys, err := storage.List(ctx, issuedKeyStoragePathPrefix)
+	if err != nil {
+		return nil, err
+	}
+	for _, key := range keys {
+		issued, err := getIssuedKey(ctx, storage, key)
+		if err != nil {
+			return nil, err
+		}
+		if issued == nil {
+			continue
+		}
+		if !issued.ExpiresAt.IsZero() && time.Since(issued.ExpiresAt) > safetyBuffer {
+			b.Logger().Warn("API key of an expired lease found", "iam_key", key, "expires_at", issued.ExpiresAt)
+			continue
+		}
+		live[key] = true
+	}
+
+	staticRoles, err := storage.List(ctx, staticRoleStoragePathPrefix)
+	if err != nil {
+		return nil, err
+	}
+	for _, name := range staticRoles {
+		role, err := getStaticRole(ctx, storage, name)
+		if err != nil {
+			return nil, err
+		}
+		if role != nil {
+			live[role.APIKey] = true
+			live[role.PreviousAPIKey] = true
+		}
+	}
+
+	pooledRoles, err := b.pooledRoles(ctx, storage)
+	if err != nil {
+		return nil, err
+	}
+	for name := range pooledRoles {
+		pooled, err := listPooledKeys(ctx, storage, name)
+		if err != nil {
+			return nil, err
+		}
+		for _, k := range pooled {
+			live[k.Key] = true
+		}
+	}
+
+	rootConfig, err := getRootConfig(ctx, storage)
+	if err != nil {
+		return nil, err
+	}
+	if rootConfig != nil {
+		live[rootConfig.RootAPIKey] = true
+	}
+
+	accounts, err := storage.List(ctx, configAccountStoragePathPrefix)
+	if err != nil {
+		return nil, err
+	}
+	for _, name := range accounts {
+		config, err := getAccountConfig(ctx, storage, name)
+		if err != nil {
+			return nil, err
+		}
+		if config != nil {
+			live[config.RootAPIKey] = true
+		}
+	}
+
+	return live, nil
+}
+
+// orphanedKeys returns the keys named after the naming scheme of the backend which aren't live
+func (e *Exoscale) orphanedKeys(ctx context.Context, live map[string]bool) ([]*orphanedKey, error) {
+	var orphans []*orphanedKey
+	var errs error
+
+	apikeys, err := e.V3ListAPIKeys(ctx)
+	errs = errors.Join(errs, err)
+	for _, k := range apikeys {
+		if k.Key == nil || k.Name == nil || live[*k.Key] || strings.HasSuffix(*k.Name, v2KeyNameSuffix) {
+			continue
+		}
+		if created, ok := e.parseResourceName(*k.Name); ok {
+			orphans = append(orphans, &orphanedKey{Key: *k.Key, Name: *k.Name, Version: "v3", Created: created})
+		}
+	}
+
+	accesskeys, err := e.V2ListAccessKeys(ctx)
+	errs = errors.Join(errs, err)
+	for _, k := range accesskeys {
+		if k.Key == nil || k.Name == nil || live[*k.Key] || !strings.HasSuffix(*k.Name, v2KeyNameSuffix) {
+			continue
+		}
+		if created, ok := e.parseResourceName(strings.TrimSuffix(*k.Name, v2KeyNameSuffix)); ok {
+			orphans = append(orphans, &orphanedKey{Key: *k.Key, Name: *k.Name, Version: "v2", Created: created})
+		}
+	}
+
+	return orphans, errs
+}
+
+// tidyIfDue runs the periodic tidy if its interval has elapsed
+func (b *exoscaleBackend) tidyIfDue(ctx context.Context, storage logical.Storage) error {
+	config, err := getTidyConfig(ctx, storage)
+	if err != nil {
+		return err
+	}
+	if config.Interval == 0 || time.Since(config.LastRun) < config.Interval {
+		return nil
+	}
+
+	orphans, deleted, unprefixed, tidyErr := b.tidy(ctx, storage, config.DryRun, config.SafetyBuffer)
+	if tidyErr != nil {
+		b.Logger().Error("Periodic tidy failed", "err", tidyErr)
+	}
+	if !config.DryRun {
+		for _, c := range unprefixed {
+			b.Logger().Warn("Periodic tidy only logs orphaned keys", "config", c, "err", errTidyNoKeyNamePrefix)
+		}
+	}
+	b.Logger().Info("Periodic tidy done", "orphans", len(orphans), "deleted", len(deleted), "dry_run", config.DryRun)
+
+	config.LastRun = time.Now()
+	if err := putTidyConfig(ctx, storage, config); err != nil {
+		return errors.Join(tidyErr, err)
+	}
+
+	if tidyErr != nil {
+		return fmt.Errorf("periodic tidy: %w", tidyErr)
+	}
+	return nil
+}
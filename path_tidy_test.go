@@ -0,0 +1,217 @@
+package exoscale
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/hashicorp/vault/sdk/logical"
+	"github.com/stretchr/testify/mock"
+
+	"github.com/exoscale/egoscale/v2/oapi"
+)
+
+func (ts *testSuite) mockTidyKeys() {
+	created := time.Now().Add(-2 * time.Hour).UnixNano()
+	name := func(role string) *string { return ptr(fmt.Sprintf("vault-tidy-%s-test-%d", role, created)) }
+
+	ts.storeEntry(inventoryStoragePath, inventory{Start: time.Now().Add(-24 * time.Hour)})
+	ts.storeEntry(issuedKeyStoragePathPrefix+"EXOlive", issuedKey{Key: "EXOlive", ExpiresAt: time.Now().Add(time.Hour)})
+	ts.storeEntry(issuedKeyStoragePathPrefix+"EXOexpired", issuedKey{Key: "EXOexpired", ExpiresAt: time.Now().Add(-2 * time.Hour)})
+	ts.storeEntry(staticRoleStoragePathPrefix+"static", StaticRole{APIKey: "EXOstatic"})
+
+	mockClient := ts.backend.(*exoscaleBackend).exo.egoscaleClient.(*mockEgoscaleClient)
+	mockClient.ExpectedCalls = nil
+	mockClient.
+		On("ListApiKeysWithResponse", mock.Anything).
+		Return(&oapi.ListApiKeysResponse{
+			JSON200: &struct {
+				ApiKeys *[]oapi.IamApiKey `json:"api-keys,omitempty"`
+			}{ApiKeys: &[]oapi.IamApiKey{
+				{Key: ptr("EXOorphan"), Name: name("ci")},
+				{Key: ptr("EXOlive"), Name: name("ci")},
+				{Key: ptr("EXOexpired"), Name: name("ci")},
+				{Key: ptr("EXOstatic"), Name: name("ci")},
+				{Key: ptr("EXOrecent"), Name: ptr(fmt.Sprintf("vault-tidy-ci-test-%d", time.Now().UnixNano()))},
+				{Key: ptr("EXOold"), Name: ptr(fmt.Sprintf("vault-tidy-ci-test-%d", time.Now().Add(-48*time.Hour).UnixNano()))},
+				{Key: ptr("EXOmanual"), Name: ptr("ci")},
+			}},
+		}, nil)
+	mockClient.
+		On("ListAccessKeysWithResponse", mock.Anything).
+		Return(&oapi.ListAccessKeysResponse{
+			JSON200: &struct {
+				AccessKeys *[]oapi.AccessKey `json:"access-keys,omitempty"`
+			}{AccessKeys: &[]oapi.AccessKey{
+				{Key: ptr("EXOorphanv2"), Name: ptr(*name("legacy") + v2KeyNameSuffix)},
+			}},
+		}, nil)
+}
+
+func (ts *testSuite) TestPathTidyDryRun() {
+	ts.mockTidyKeys()
+
+	res, err := ts.backend.HandleRequest(context.Background(), &logical.Request{
+		Storage:   ts.storage,
+		Operation: logical.UpdateOperation,
+		Path:      "tidy",
+	})
+	ts.Require().NoError(err)
+	ts.Require().False(res.IsError())
+	ts.Require().Equal(true, res.Data[configTidyDryRun])
+	ts.Require().NotContains(res.Data, "deleted")
+
+	var keys []string
+	for _, k := range res.Data["orphans"].([]map[string]interface{}) {
+		keys = append(keys, k["api_key"].(string))
+	}
+	ts.Require().Equal([]string{"EXOorphan", "EXOexpired", "EXOorphanv2"}, keys)
+
+	mockClient := ts.backend.(*exoscaleBackend).exo.egoscaleClient.(*mockEgoscaleClient)
+	mockClient.AssertNotCalled(ts.T(), "DeleteApiKeyWithResponse", mock.Anything, mock.Anything)
+}
+
+func (ts *testSuite) TestPathTidy() {
+	ts.mockTidyKeys()
+
+	state := oapi.OperationStateSuccess
+	exo := ts.backend.(*exoscaleBackend).exo
+	mockClient := exo.egoscaleClient.(*mockEgoscaleClient)
+	mockClient.
+		On("DeleteApiKeyWithResponse", mock.Anything, "EXOorphan").
+		Return(&oapi.DeleteApiKeyResponse{JSON200: &oapi.Operation{State: &state}}, nil).
+		Once()
+	mockClient.
+		On("DeleteApiKeyWithResponse", mock.Anything, "EXOexpired").
+		Return(&oapi.DeleteApiKeyResponse{JSON200: &oapi.Operation{State: &state}}, nil).
+		Once()
+	mockClient.
+		On("RevokeIAMAccessKey", mock.Anything, mock.Anything, mock.Anything).
+		Return(nil).
+		Once()
+
+	// api_key_name_prefix is not set in the test configuration, orphaned keys are only reported
+	res, err := ts.backend.HandleRequest(context.Background(), &logical.Request{
+		Storage:   ts.storage,
+		Operation: logical.UpdateOperation,
+		Path:      "tidy",
+		Data:      map[string]interface{}{configTidyDryRun: false},
+	})
+	ts.Require().NoError(err)
+	ts.Require().Len(res.Data["orphans"], 3)
+	ts.Require().Empty(res.Data["deleted"])
+	ts.Require().Equal([]string{configRootStoragePath + ": " + errTidyNoKeyNamePrefix.Error() + ", they are only reported"},
+		res.Warnings)
+	mockClient.AssertNotCalled(ts.T(), "DeleteApiKeyWithResponse", mock.Anything, mock.Anything)
+	mockClient.AssertNotCalled(ts.T(), "RevokeIAMAccessKey", mock.Anything, mock.Anything, mock.Anything)
+
+	exo.Lock()
+	exo.apiKeyNamePrefix = "tidy"
+	exo.Unlock()
+
+	res, err = ts.backend.HandleRequest(context.Background(), &logical.Request{
+		Storage:   ts.storage,
+		Operation: logical.UpdateOperation,
+		Path:      "tidy",
+		Data:      map[string]interface{}{configTidyDryRun: false},
+	})
+	ts.Require().NoError(err)
+	ts.Require().Empty(res.Warnings)
+	ts.Require().Equal([]string{"EXOorphan", "EXOexpired", "EXOorphanv2"}, res.Data["deleted"])
+	mockClient.AssertExpectations(ts.T())
+}
+
+func (ts *testSuite) TestPeriodicTidy() {
+	ts.mockTidyKeys()
+
+	res, err := ts.backend.HandleRequest(context.Background(), &logical.Request{
+		Storage:   ts.storage,
+		Operation: logical.UpdateOperation,
+		Path:      configTidyStoragePath,
+		Data:      map[string]interface{}{configTidyInterval: "1m"},
+	})
+	ts.Require().NoError(err)
+	ts.Require().True(res.IsError())
+
+	res, err = ts.backend.HandleRequest(context.Background(), &logical.Request{
+		Storage:   ts.storage,
+		Operation: logical.UpdateOperation,
+		Path:      configTidyStoragePath,
+		Data:      map[string]interface{}{configTidyInterval: "24h", configTidyDryRun: false},
+	})
+	ts.Require().NoError(err)
+	// api_key_name_prefix is not set in the test configuration, orphaned keys are only logged
+	ts.Require().Len(res.Warnings, 1)
+
+	config, err := getTidyConfig(context.Background(), ts.storage)
+	ts.Require().NoError(err)
+	ts.Require().True(config.LastRun.IsZero())
+
+	ts.Require().NoError(ts.backend.(*exoscaleBackend).tidyIfDue(context.Background(), ts.storage))
+
+	config, err = getTidyConfig(context.Background(), ts.storage)
+	ts.Require().NoError(err)
+	ts.Require().False(config.LastRun.IsZero())
+
+	mockClient := ts.backend.(*exoscaleBackend).exo.egoscaleClient.(*mockEgoscaleClient)
+	mockClient.AssertCalled(ts.T(), "ListApiKeysWithResponse", mock.Anything)
+	mockClient.AssertNotCalled(ts.T(), "DeleteApiKeyWithResponse", mock.Anything, mock.Anything)
+}
+
+func (ts *testSuite) TestPathTidyAccountWithoutPrefix() {
+	ts.mockTidyKeys()
+	exo := ts.backend.(*exoscaleBackend).exo
+	exo.Lock()
+	exo.apiKeyNamePrefix = "tidy"
+	exo.Unlock()
+
+	// the account has no api_key_name_prefix, unlike config/root
+	clients := ts.mockAccountClients()
+	accountClient := new(mockEgoscaleClient)
+	clients["EXOstaging"] = accountClient
+	ts.writeTestAccount("EXOstaging")
+
+	created := time.Now().Add(-2 * time.Hour).UnixNano()
+	accountClient.
+		On("ListApiKeysWithResponse", mock.Anything).
+		Return(&oapi.ListApiKeysResponse{
+			JSON200: &struct {
+				ApiKeys *[]oapi.IamApiKey `json:"api-keys,omitempty"`
+			}{ApiKeys: &[]oapi.IamApiKey{
+				{Key: ptr("EXOothermount"), Name: ptr(fmt.Sprintf("vault-ci-test-%d", created))},
+			}},
+		}, nil)
+	accountClient.
+		On("ListAccessKeysWithResponse", mock.Anything).
+		Return(&oapi.ListAccessKeysResponse{}, nil)
+
+	state := oapi.OperationStateSuccess
+	mockClient := exo.egoscaleClient.(*mockEgoscaleClient)
+	mockClient.
+		On("DeleteApiKeyWithResponse", mock.Anything, mock.Anything).
+		Return(&oapi.DeleteApiKeyResponse{JSON200: &oapi.Operation{State: &state}}, nil)
+	mockClient.
+		On("RevokeIAMAccessKey", mock.Anything, mock.Anything, mock.Anything).
+		Return(nil)
+
+	res, err := ts.backend.HandleRequest(context.Background(), &logical.Request{
+		Storage:   ts.storage,
+		Operation: logical.UpdateOperation,
+		Path:      "tidy",
+		Data:      map[string]interface{}{configTidyDryRun: false},
+	})
+	ts.Require().NoError(err)
+	ts.Require().Equal([]string{"EXOorphan", "EXOexpired", "EXOorphanv2"}, res.Data["deleted"])
+	ts.Require().Len(res.Data["orphans"], 4)
+	ts.Require().Len(res.Warnings, 1)
+	ts.Require().Contains(res.Warnings[0], configAccountStoragePathPrefix+testAccountName)
+	accountClient.AssertNotCalled(ts.T(), "DeleteApiKeyWithResponse", mock.Anything, mock.Anything)
+
+	// the periodic tidy leaves the keys of the account as well
+	ts.Require().NoError(putTidyConfig(context.Background(), ts.storage, &tidyConfig{
+		Interval:     24 * time.Hour,
+		SafetyBuffer: defaultTidySafetyBuffer,
+	}))
+	ts.Require().NoError(ts.backend.(*exoscaleBackend).tidyIfDue(context.Background(), ts.storage))
+	accountClient.AssertNotCalled(ts.T(), "DeleteApiKeyWithResponse", mock.Anything, mock.Anything)
+}
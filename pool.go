@@ -0,0 +1,302 @@
+package exoscale
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/vault/sdk/helper/consts"
+	"github.com/hashicorp/vault/sdk/logical"
+)
+
+const (
+	poolStoragePathPrefix = "pool/"
+
+	maxPoolSize = 100
+
+	// poolKeyMaxAge is the age after which pooled keys are replaced, to limit how long
+	// unused secrets stay in storage
+	poolKeyMaxAge = 24 * time.Hour
+
+	// poolDisplayName replaces the display name of the requester in the name of pooled keys,
+	// the requester is recorded in the inventory once the key is handed out
+	poolDisplayName = "pool"
+)
+
+// pooledKey is an API key created in advance for a role, handed out by the next apikey/ read
+type pooledKey struct {
+	Key       string    `json:"key"`
+	Name      string    `json:"name"`
+	Secret    string    `json:"secret"`
+	IAMRoleID string    `json:"iam_role_id"`
+	Account   string    `json:"account,omitempty"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// fresh returns whether the key can still be handed out for the role
+func (k *pooledKey) fresh(role *Role) bool {
+	return k.IAMRoleID == role.IAMRoleID &&
+		k.Account == role.Account &&
+		time.Since(k.CreatedAt) < poolKeyMaxAge
+}
+
+// poolable returns whether API keys of the role can be created in advance
+func (role *Role) poolable() bool {
+	return role.Version == "v3" && role.IAMPolicy == nil && role.IAMRoleID != ""
+}
+
+func poolStoragePath(roleName string) string {
+	return poolStoragePathPrefix + roleName + "/"
+}
+
+func listPooledKeys(ctx context.Context, storage logical.Storage, roleName string) ([]*pooledKey, error) {
+	keys, err := storage.List(ctx, poolStoragePath(roleName))
+	if err != nil {
+		return nil, err
+	}
+
+	pooled := make([]*pooledKey, 0, len(keys))
+	for _, key := range keys {
+		entry, err := storage.Get(ctx, poolStoragePath(roleName)+key)
+		if err != nil {
+			return nil, err
+		}
+		if entry == nil {
+			continue
+		}
+
+		var k pooledKey
+		if err := entry.DecodeJSON(&k); err != nil {
+			return nil, err
+		}
+		pooled = append(pooled, &k)
+	}
+
+	return pooled, nil
+}
+
+// canManagePools returns whether this node can write to storage, performance standbys can't
+func (b *exoscaleBackend) canManagePools() bool {
+	return !b.System().ReplicationState().HasState(consts.ReplicationPerformanceStandby)
+}
+
+// takePooledKey removes a key from the pool of a role and returns it, or nil if the pool is empty
+func (b *exoscaleBackend) takePooledKey(
+	ctx context.Context,
+	storage logical.Storage,
+	roleName string,
+	role *Role,
+) (*pooledKey, error) {
+	b.poolLock.Lock()
+	defer b.poolLock.Unlock()
+
+	pooled, err := listPooledKeys(ctx, storage, roleName)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, k := range pooled {
+		if !k.fresh(role) {
+			continue
+		}
+
+		if err := storage.Delete(ctx, poolStoragePath(roleName)+k.Key); err != nil {
+			return nil, err
+		}
+
+		return k, nil
+	}
+
+	return nil, nil
+}
+
+// refillPoolAsync refills the pool of a role in the background
+func (b *exoscaleBackend) refillPoolAsync(roleName string) {
+	if b.storage == nil || !b.canManagePools() {
+		return
+	}
+
+	b.bgWG.Add(1)
+	go func() {
+		defer b.bgWG.Done()
+
+		if err := b.refillPool(b.bgCtx, b.storage, roleName); err != nil {
+			b.Logger().Error("Failed to refill pool", "role", roleName, "err", err)
+		}
+	}()
+}
+
+// refillPool replaces the stale keys of the pool of a role and creates keys until it is full
+func (b *exoscaleBackend) refillPool(ctx context.Context, storage logical.Storage, roleName string) error {
+	b.poolLock.Lock()
+	if b.refilling[roleName] {
+		b.poolLock.Unlock()
+		return nil
+	}
+	b.refilling[roleName] = true
+	b.poolLock.Unlock()
+
+	defer func() {
+		b.poolLock.Lock()
+		delete(b.refilling, roleName)
+		b.poolLock.Unlock()
+	}()
+
+	role, err := getRole(ctx, storage, roleName)
+	if err != nil {
+		return err
+	}
+	if role == nil || role.PoolSize == 0 || !role.poolable() {
+		return b.drainPool(ctx, storage, roleName, nil)
+	}
+	if ok, err := b.accountConfigured(ctx, storage, role.Account); !ok || err != nil {
+		return err
+	}
+
+	if err := b.drainPool(ctx, storage, roleName, role); err != nil {
+		return err
+	}
+
+	pooled, err := listPooledKeys(ctx, storage, roleName)
+	if err != nil {
+		return err
+	}
+
+	exo, err := b.exoscale(ctx, storage, role.Account)
+	if err != nil {
+		return err
+	}
+
+	for i := len(pooled); i < role.PoolSize; i++ {
+		name, err := exo.resourceName(nameData{RoleName: roleName, DisplayName: poolDisplayName}, role.NameTemplate)
+		if err != nil {
+			return err
+		}
+
+		apikey, err := exo.V3CreateAPIKey(ctx, name, *role)
+		if err != nil {
+			return err
+		}
+
+		k := pooledKey{
+			Key:       *apikey.Key,
+			Name:      *apikey.Name,
+			Secret:    *apikey.Secret,
+			IAMRoleID: role.IAMRoleID,
+			Account:   role.Account,
+			CreatedAt: time.Now(),
+		}
+		entry, err := logical.StorageEntryJSON(poolStoragePath(roleName)+k.Key, k)
+		if err != nil {
+			return err
+		}
+
+		b.poolLock.Lock()
+		err = storage.Put(ctx, entry)
+		b.poolLock.Unlock()
+		if err != nil {
+			if err := exo.V3DeleteAPIKey(ctx, k.Key); err != nil {
+				b.Logger().Warn("Failed to clean up unrecorded pooled API key", "role", roleName, "iam_key", k.Key, "err", err)
+			}
+			return err
+		}
+
+		b.Logger().Debug("Pooled API key created", "role", roleName, "iam_key", k.Key)
+	}
+
+	return nil
+}
+
+// drainPool deletes the keys of the pool of a role which can't be handed out for role anymore,
+// all of them if role is nil
+func (b *exoscaleBackend) drainPool(ctx context.Context, storage logical.Storage, roleName string, role *Role) error {
+	b.poolLock.Lock()
+	pooled, err := listPooledKeys(ctx, storage, roleName)
+	if err != nil {
+		b.poolLock.Unlock()
+		return err
+	}
+
+	var stale []*pooledKey
+	for _, k := range pooled {
+		if role != nil && k.fresh(role) {
+			continue
+		}
+		if err := storage.Delete(ctx, poolStoragePath(roleName)+k.Key); err != nil {
+			b.poolLock.Unlock()
+			return err
+		}
+		stale = append(stale, k)
+	}
+	b.poolLock.Unlock()
+
+	// the API keys are deleted once their records are gone, so that they can't be handed out
+	var errs error
+	for _, k := range stale {
+		exo, err := b.exoscale(ctx, storage, k.Account)
+		if err == nil {
+			err = exo.V3DeleteAPIKey(ctx, k.Key)
+		}
+		if err != nil && !errors.Is(err, ErrNotFound) {
+			b.Logger().Warn("Failed to delete pooled API key", "role", roleName, "iam_key", k.Key, "err", err)
+			errs = errors.Join(errs, err)
+			continue
+		}
+		b.Logger().Debug("Pooled API key deleted", "role", roleName, "iam_key", k.Key)
+	}
+
+	return errs
+}
+
+// refillPools refills the pools of all the roles, and drains the pools of the roles
+// which don't use one anymore
+func (b *exoscaleBackend) refillPools(ctx context.Context, storage logical.Storage) error {
+	if !b.canManagePools() {
+		return nil
+	}
+
+	names, err := b.pooledRoles(ctx, storage)
+	if err != nil {
+		return err
+	}
+
+	roles, err := storage.List(ctx, roleStoragePathPrefix)
+	if err != nil {
+		return err
+	}
+	for _, name := range roles {
+		role, err := getRole(ctx, storage, name)
+		if err != nil {
+			return err
+		}
+		if role != nil && role.PoolSize > 0 {
+			names[name] = true
+		}
+	}
+
+	var errs error
+	for name := range names {
+		if err := b.refillPool(ctx, storage, name); err != nil {
+			b.Logger().Error("Failed to refill pool", "role", name, "err", err)
+			errs = errors.Join(errs, err)
+		}
+	}
+
+	return errs
+}
+
+// pooledRoles returns the names of the roles having pooled keys
+func (b *exoscaleBackend) pooledRoles(ctx context.Context, storage logical.Storage) (map[string]bool, error) {
+	prefixes, err := storage.List(ctx, poolStoragePathPrefix)
+	if err != nil {
+		return nil, err
+	}
+
+	names := make(map[string]bool, len(prefixes))
+	for _, p := range prefixes {
+		names[strings.TrimSuffix(p, "/")] = true
+	}
+
+	return names, nil
+}
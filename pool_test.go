@@ -0,0 +1,164 @@
+package exoscale
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/hashicorp/vault/sdk/logical"
+	"github.com/stretchr/testify/mock"
+
+	"github.com/exoscale/egoscale/v2/oapi"
+)
+
+func (ts *testSuite) TestPool() {
+	iamRoleID := ts.randomID()
+	ts.storeEntry(roleStoragePathPrefix+testRoleName, Role{
+		IAMRoleID:   iamRoleID,
+		IAMRoleName: "ci",
+		Version:     "v3",
+		Renewable:   true,
+		PoolSize:    2,
+	})
+
+	created := 0
+	state := oapi.OperationStateSuccess
+	mockClient := ts.backend.(*exoscaleBackend).exo.egoscaleClient.(*mockEgoscaleClient)
+	mockClient.
+		On("CreateApiKeyWithResponse", mock.Anything, mock.Anything).
+		Return(func(_ context.Context, body oapi.CreateApiKeyJSONRequestBody, _ ...oapi.RequestEditorFn) (*oapi.CreateApiKeyResponse, error) {
+			created++
+			return &oapi.CreateApiKeyResponse{
+				JSON200: &oapi.IamApiKeyCreated{
+					Key:    ptr(fmt.Sprintf("EXO%d", created)),
+					Name:   &body.Name,
+					RoleId: &body.RoleId,
+					Secret: &testIAMAccessKeySecret,
+				},
+			}, nil
+		})
+	mockClient.
+		On("DeleteApiKeyWithResponse", mock.Anything, mock.Anything).
+		Return(&oapi.DeleteApiKeyResponse{JSON200: &oapi.Operation{State: &state}}, nil)
+
+	b := ts.backend.(*exoscaleBackend)
+	ts.Require().NoError(b.refillPool(context.Background(), ts.storage, testRoleName))
+	ts.Require().Equal(2, created)
+
+	// a key created for a previous IAM role of the role isn't handed out
+	ts.storeEntry(poolStoragePath(testRoleName)+"EXOstale", pooledKey{
+		Key:       "EXOstale",
+		IAMRoleID: ts.randomID(),
+		CreatedAt: time.Now(),
+	})
+
+	res, err := ts.backend.HandleRequest(context.Background(), &logical.Request{
+		Storage:     ts.storage,
+		Operation:   logical.ReadOperation,
+		Path:        "apikey/" + testRoleName,
+		DisplayName: "test",
+	})
+	ts.Require().NoError(err)
+	ts.Require().Equal("EXO1", res.Data[apiKeySecretDataAPIKey])
+	ts.Require().Regexp("^vault-"+testRoleName+"-pool-[0-9]{19}$", res.Data[apiKeySecretDataName])
+
+	issued, err := getIssuedKey(context.Background(), ts.storage, "EXO1")
+	ts.Require().NoError(err)
+	ts.Require().Equal("test", issued.DisplayName)
+
+	// the pool is refilled in the background, the stale key is replaced
+	b.bgWG.Wait()
+	ts.Require().Equal(3, created)
+	mockClient.AssertCalled(ts.T(), "DeleteApiKeyWithResponse", mock.Anything, "EXOstale")
+
+	pooled, err := listPooledKeys(context.Background(), ts.storage, testRoleName)
+	ts.Require().NoError(err)
+	ts.Require().Len(pooled, 2)
+
+	_, err = ts.backend.HandleRequest(context.Background(), &logical.Request{
+		Storage:   ts.storage,
+		Operation: logical.DeleteOperation,
+		Path:      roleStoragePathPrefix + testRoleName,
+	})
+	ts.Require().NoError(err)
+
+	for _, k := range pooled {
+		mockClient.AssertCalled(ts.T(), "DeleteApiKeyWithResponse", mock.Anything, k.Key)
+	}
+	pooled, err = listPooledKeys(context.Background(), ts.storage, testRoleName)
+	ts.Require().NoError(err)
+	ts.Require().Empty(pooled)
+}
+
+func (ts *testSuite) TestPathRolePoolSizeRequiresIAMRole() {
+	_, err := ts.backend.HandleRequest(context.Background(), &logical.Request{
+		Storage:   ts.storage,
+		Operation: logical.CreateOperation,
+		Path:      roleStoragePathPrefix + testRoleName,
+		Data: map[string]interface{}{
+			configIAMPolicy:    `{"default-service-strategy":"deny"}`,
+			configRolePoolSize: 2,
+		},
+	})
+	ts.Require().EqualError(err, "pool_size can only be used in conjunction with iam-role")
+}
+
+func (ts *testSuite) TestPoolKeptOnRoleUpdate() {
+	iamRoleID := ts.randomID()
+	ts.storeEntry(roleStoragePathPrefix+testRoleName, Role{
+		IAMRoleID:   iamRoleID,
+		IAMRoleName: "ci",
+		Version:     "v3",
+		Renewable:   true,
+		PoolSize:    2,
+	})
+
+	created := 0
+	mockClient := ts.backend.(*exoscaleBackend).exo.egoscaleClient.(*mockEgoscaleClient)
+	mockClient.
+		On("CreateApiKeyWithResponse", mock.Anything, mock.Anything).
+		Return(func(_ context.Context, body oapi.CreateApiKeyJSONRequestBody, _ ...oapi.RequestEditorFn) (*oapi.CreateApiKeyResponse, error) {
+			created++
+			return &oapi.CreateApiKeyResponse{
+				JSON200: &oapi.IamApiKeyCreated{
+					Key:    ptr(fmt.Sprintf("EXO%d", created)),
+					Name:   &body.Name,
+					RoleId: &body.RoleId,
+					Secret: &testIAMAccessKeySecret,
+				},
+			}, nil
+		})
+
+	mockClient.
+		On("GetIamRoleWithResponse", mock.Anything, iamRoleID).
+		Return(&oapi.GetIamRoleResponse{
+			JSON200: &oapi.IamRole{Id: &iamRoleID, Name: ptr("ci")},
+		}, nil)
+
+	b := ts.backend.(*exoscaleBackend)
+	ts.Require().NoError(b.refillPool(context.Background(), ts.storage, testRoleName))
+	ts.Require().Equal(2, created)
+
+	// the pooled keys still match the role once its TTL is changed
+	_, err := ts.backend.HandleRequest(context.Background(), &logical.Request{
+		Storage:   ts.storage,
+		Operation: logical.UpdateOperation,
+		Path:      roleStoragePathPrefix + testRoleName,
+		Data: map[string]interface{}{
+			configIAMRole:      iamRoleID,
+			configRolePoolSize: 2,
+			configRoleTTL:      "1h",
+		},
+	})
+	ts.Require().NoError(err)
+	b.bgWG.Wait()
+
+	// nor are they deleted when the backend is unloaded
+	ts.backend.Cleanup(context.Background())
+
+	mockClient.AssertNotCalled(ts.T(), "DeleteApiKeyWithResponse", mock.Anything, mock.Anything)
+	ts.Require().Equal(2, created)
+	pooled, err := listPooledKeys(context.Background(), ts.storage, testRoleName)
+	ts.Require().NoError(err)
+	ts.Require().Len(pooled, 2)
+}
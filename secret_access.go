@@ -0,0 +1,95 @@
+package exoscale
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/hashicorp/vault/sdk/framework"
+	"github.com/hashicorp/vault/sdk/logical"
+)
+
+const SecretTypeAccess = "access"
+
+func (b *exoscaleBackend) secretAccess() *framework.Secret {
+	return &framework.Secret{
+		Type: SecretTypeAccess,
+		Fields: map[string]*framework.FieldSchema{
+			configAccessSecurityGroupID: {
+				Type:        framework.TypeString,
+				Description: "ID of the security group",
+			},
+			"rule_id": {
+				Type:        framework.TypeString,
+				Description: "ID of the rule of the security group",
+			},
+			configAccessCIDR: {
+				Type:        framework.TypeString,
+				Description: "Source network allowed by the rule",
+			},
+		},
+
+		Renew:  b.secretAccessRenew,
+		Revoke: b.secretAccessRevoke,
+	}
+}
+
+func (b *exoscaleBackend) secretAccessRenew(
+	ctx context.Context,
+	req *logical.Request,
+	_ *framework.FieldData,
+) (*logical.Response, error) {
+	roleName, ok := req.Secret.InternalData["role"].(string)
+	if !ok {
+		return nil, errors.New("'role' is missing from the secret's internal data")
+	}
+
+	role, err := getAccessRole(ctx, req.Storage, roleName)
+	if err != nil {
+		return nil, err
+	}
+	if role == nil {
+		return nil, fmt.Errorf("unable to renew: access role %q not found", roleName)
+	}
+
+	res := &logical.Response{Secret: req.Secret}
+	res.Secret.TTL = role.TTL
+	res.Secret.MaxTTL = role.MaxTTL
+
+	return res, nil
+}
+
+func (b *exoscaleBackend) secretAccessRevoke(
+	ctx context.Context,
+	req *logical.Request,
+	_ *framework.FieldData,
+) (*logical.Response, error) {
+	internal := make(map[string]string)
+	for _, k := range []string{configAccessSecurityGroupID, "rule_id", configRoleAccount} {
+		v, ok := req.Secret.InternalData[k].(string)
+		if !ok {
+			return nil, fmt.Errorf("'%s' is missing from the secret's internal data", k)
+		}
+		internal[k] = v
+	}
+
+	exo, err := b.exoscale(ctx, req.Storage, internal[configRoleAccount])
+	if err != nil {
+		return nil, err
+	}
+
+	err = exo.V3DeleteSecurityGroupRule(ctx, internal[configAccessSecurityGroupID], internal["rule_id"])
+	if err != nil && !errors.Is(err, ErrNotFound) {
+		return nil, err
+	}
+
+	if err := deleteAccountLease(ctx, req.Storage, internal[configRoleAccount], SecretTypeAccess, internal["rule_id"]); err != nil {
+		return nil, err
+	}
+
+	b.Logger().Info("Security group rule deleted",
+		"security_group_id", internal[configAccessSecurityGroupID],
+		"rule_id", internal["rule_id"])
+
+	return nil, nil
+}
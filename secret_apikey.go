@@ -4,8 +4,6 @@ import (
 	"context"
 	"errors"
 	"fmt"
-	"net/url"
-	"strings"
 	"time"
 
 	"github.com/hashicorp/vault/sdk/framework"
@@ -75,6 +73,9 @@ func (b *exoscaleBackend) secretAPIKeyRenew(
 	if ttl == req.Secret.TTL {
 		res.Secret.TTL = ttl
 		res.Secret.InternalData["expireTime"] = time.Now().Add(res.Secret.TTL)
+		if err := renewIssuedKey(ctx, req.Storage, iamKey.(string), req.Secret.LeaseID, res.Secret.TTL); err != nil {
+			return nil, err
+		}
 		b.Logger().Info("Renewing",
 			"ttl", fmt.Sprint(res.Secret.TTL),
 			"role", req.Secret.InternalData["role"],
@@ -113,34 +114,64 @@ func (b *exoscaleBackend) secretAPIKeyRevoke(
 		return nil, errors.New("API key is missing from the secret")
 	}
 
+	var account string
+	if a, ok := req.Secret.InternalData["account"]; ok {
+		account = a.(string)
+	}
+	exo, err := b.exoscale(ctx, req.Storage, account)
+	if err != nil {
+		return nil, err
+	}
+
 	version := "v2"
 	if v, ok := req.Secret.InternalData["version"]; ok {
 		version = v.(string)
 	}
 
-	var err error
 	if version == "v2" {
-		err = b.exo.V2RevokeAccessKey(ctx, key.(string))
-		if err != nil && strings.HasSuffix(err.Error(), ": resource not found") {
+		err = exo.V2RevokeAccessKey(ctx, key.(string))
+		if errors.Is(err, ErrNotFound) {
 			b.Logger().Warn("IAMv2 key deosn't exist anymore, cleaning up secret", "key", key, "lease_id", req.Secret.LeaseID)
-			return nil, nil
 		} else if err != nil {
 			b.Logger().Warn("Failed to revoke IAM key", "key", key, "lease_id", req.Secret.LeaseID, "err", err)
 			return nil, fmt.Errorf("unable to revoke the API key: %w", err)
 		}
 	} else {
-		err = b.exo.V3DeleteAPIKey(ctx, key.(string))
-
-		uerr := &url.Error{}
-		if errors.As(err, &uerr) && uerr.Err.Error() == "invalid request: API Key not in organization" {
+		err = exo.V3DeleteAPIKey(ctx, key.(string))
+		if errors.Is(err, ErrNotFound) {
 			b.Logger().Warn("IAMv3 key deosn't exist anymore, cleaning up secret", "key", key, "lease_id", req.Secret.LeaseID)
-			return nil, nil
-		}
-
-		if err != nil {
+		} else if err != nil {
 			b.Logger().Warn("Failed to revoke IAM key", "key", key, "lease_id", req.Secret.LeaseID, "err", err)
 			return nil, fmt.Errorf("unable to revoke the API key: %w", err)
 		}
+
+		// the dynamic IAM role is deleted once its key is gone, a failure
+		// is retried by Vault along with the lease revocation
+		if roleID, ok := req.Secret.InternalData["dynamic_iam_role_id"]; ok {
+			err := exo.V3DeleteRole(ctx, roleID.(string))
+			if errors.Is(err, ErrNotFound) {
+				b.Logger().Warn("IAM role doesn't exist anymore", "iam_role_id", roleID, "lease_id", req.Secret.LeaseID)
+			} else if err != nil {
+				b.Logger().Warn("Failed to delete IAM role", "iam_role_id", roleID, "lease_id", req.Secret.LeaseID, "err", err)
+				return nil, fmt.Errorf("unable to delete the IAM role: %w", err)
+			} else {
+				b.Logger().Info("IAM role deleted", "iam_role_id", roleID.(string), "lease_id", req.Secret.LeaseID)
+			}
+		}
+	}
+
+	issued, err := getIssuedKey(ctx, req.Storage, key.(string))
+	if err != nil {
+		return nil, err
+	}
+	if issued != nil {
+		// keys issued before the inventory existed weren't counted
+		if err := b.releaseIssuedKey(ctx, req.Storage, issued); err != nil {
+			return nil, err
+		}
+		if err := revokeIssuedKey(ctx, req.Storage, issued, req.Secret.LeaseID); err != nil {
+			return nil, err
+		}
 	}
 
 	b.Logger().Info("IAM key revoked", "key", key.(string), "lease_id", req.Secret.LeaseID)
@@ -2,6 +2,8 @@ package exoscale
 
 import (
 	"context"
+	"net/http"
+	"net/url"
 	"time"
 
 	"github.com/hashicorp/vault/sdk/logical"
@@ -200,3 +202,213 @@ func (ts *testSuite) TestSecretAPIKeyV3RenewAboveSystemMaxTTL() {
 	ts.Require().NoError(err)
 	ts.Require().LessOrEqual(resp.Secret.TTL, 1*time.Minute)
 }
+
+func (ts *testSuite) TestSecretAPIKeyV3RevokeDynamicRole() {
+	var keyRevoked, roleDeleted bool
+
+	dynamicRoleID := ts.randomID()
+	testSecret := &logical.Secret{
+		InternalData: map[string]interface{}{
+			"api_key":             testIAMAccessKeyKey,
+			"secret_type":         SecretTypeAPIKey,
+			"version":             "v3",
+			"dynamic_iam_role_id": dynamicRoleID,
+		},
+		LeaseID: ts.randomID(),
+	}
+
+	state := oapi.OperationStateSuccess
+	mockClient := ts.backend.(*exoscaleBackend).exo.egoscaleClient.(*mockEgoscaleClient)
+	mockClient.
+		On("DeleteApiKeyWithResponse", mock.Anything, testIAMAccessKeyKey).
+		Run(func(args mock.Arguments) {
+			keyRevoked = true
+		}).
+		Return(&oapi.DeleteApiKeyResponse{JSON200: &oapi.Operation{State: &state}}, nil)
+	mockClient.
+		On("DeleteIamRoleWithResponse", mock.Anything, dynamicRoleID).
+		Run(func(args mock.Arguments) {
+			ts.Require().True(keyRevoked, "IAM role deleted before its API key")
+			roleDeleted = true
+		}).
+		Return(&oapi.DeleteIamRoleResponse{JSON200: &oapi.Operation{State: &state}}, nil)
+
+	_, err := ts.backend.HandleRequest(context.Background(), &logical.Request{
+		Storage:   ts.storage,
+		Operation: logical.RevokeOperation,
+		Path:      testSecret.LeaseID,
+		Secret:    testSecret,
+	})
+	ts.Require().NoError(err)
+	ts.Require().True(keyRevoked)
+	ts.Require().True(roleDeleted)
+}
+
+func (ts *testSuite) TestSecretAPIKeyV3RevokePendingOperation() {
+	tests := []struct {
+		name    string
+		states  []oapi.OperationState
+		wantErr string
+	}{
+		{
+			name:   "success",
+			states: []oapi.OperationState{oapi.OperationStatePending, oapi.OperationStateSuccess},
+		},
+		{
+			name:    "failure",
+			states:  []oapi.OperationState{oapi.OperationStatePending, oapi.OperationStateFailure},
+			wantErr: "operation \"op-id\" failure (busy): try again later",
+		},
+	}
+
+	for _, tt := range tests {
+		ts.Run(tt.name, func() {
+			exo := ts.backend.(*exoscaleBackend).exo
+			exo.operationPollInterval = time.Millisecond
+			mockClient := exo.egoscaleClient.(*mockEgoscaleClient)
+			mockClient.ExpectedCalls = nil
+
+			opID := "op-id"
+			pending := oapi.OperationStatePending
+			mockClient.
+				On("DeleteApiKeyWithResponse", mock.Anything, testIAMAccessKeyKey).
+				Return(&oapi.DeleteApiKeyResponse{JSON200: &oapi.Operation{Id: &opID, State: &pending}}, nil)
+
+			polls := 0
+			mockClient.
+				On("GetOperationWithResponse", mock.Anything, opID).
+				Return(func(context.Context, string, ...oapi.RequestEditorFn) (*oapi.GetOperationResponse, error) {
+					state := tt.states[polls]
+					polls++
+					reason := oapi.OperationReasonBusy
+					return &oapi.GetOperationResponse{
+						JSON200: &oapi.Operation{Id: &opID, State: &state, Reason: &reason, Message: ptr("try again later")},
+					}, nil
+				})
+
+			testSecret := &logical.Secret{
+				InternalData: map[string]interface{}{
+					"api_key":     testIAMAccessKeyKey,
+					"secret_type": SecretTypeAPIKey,
+					"version":     "v3",
+				},
+				LeaseID: ts.randomID(),
+			}
+
+			_, err := ts.backend.HandleRequest(context.Background(), &logical.Request{
+				Storage:   ts.storage,
+				Operation: logical.RevokeOperation,
+				Path:      testSecret.LeaseID,
+				Secret:    testSecret,
+			})
+			if tt.wantErr != "" {
+				ts.Require().ErrorContains(err, tt.wantErr)
+			} else {
+				ts.Require().NoError(err)
+			}
+			ts.Require().Equal(len(tt.states), polls)
+		})
+	}
+}
+
+func (ts *testSuite) TestSecretAPIKeyV3RevokePollingReleasesLock() {
+	exo := ts.backend.(*exoscaleBackend).exo
+	exo.operationPollInterval = time.Millisecond
+	mockClient := exo.egoscaleClient.(*mockEgoscaleClient)
+
+	opID := "op-id"
+	pending := oapi.OperationStatePending
+	mockClient.
+		On("DeleteApiKeyWithResponse", mock.Anything, testIAMAccessKeyKey).
+		Return(&oapi.DeleteApiKeyResponse{JSON200: &oapi.Operation{Id: &opID, State: &pending}}, nil)
+
+	// the configuration can be reloaded while an operation is polled
+	var reloadable bool
+	mockClient.
+		On("GetOperationWithResponse", mock.Anything, opID).
+		Return(func(context.Context, string, ...oapi.RequestEditorFn) (*oapi.GetOperationResponse, error) {
+			if reloadable = exo.TryLock(); reloadable {
+				exo.Unlock()
+			}
+			state := oapi.OperationStateSuccess
+			return &oapi.GetOperationResponse{JSON200: &oapi.Operation{Id: &opID, State: &state}}, nil
+		})
+
+	ts.Require().NoError(exo.V3DeleteAPIKey(context.Background(), testIAMAccessKeyKey))
+	ts.Require().True(reloadable)
+}
+
+func (ts *testSuite) TestSecretAPIKeyRevokeNotFound() {
+	tests := []struct {
+		name    string
+		version string
+		err     error
+	}{
+		{
+			name:    "v2",
+			version: "v2",
+			err:     &url.Error{Op: "Post", URL: "https://api", Err: &APIError{StatusCode: http.StatusNotFound}},
+		},
+		{
+			name:    "v3 key not in organization",
+			version: "v3",
+			err: &url.Error{Op: "Delete", URL: "https://api", Err: &APIError{
+				StatusCode: http.StatusBadRequest,
+				Message:    "API Key not in organization",
+			}},
+		},
+	}
+
+	for _, tt := range tests {
+		ts.Run(tt.name, func() {
+			mockClient := ts.backend.(*exoscaleBackend).exo.egoscaleClient.(*mockEgoscaleClient)
+			mockClient.ExpectedCalls = nil
+			mockClient.
+				On("RevokeIAMAccessKey", mock.Anything, mock.Anything, mock.Anything).
+				Return(tt.err)
+			mockClient.
+				On("DeleteApiKeyWithResponse", mock.Anything, testIAMAccessKeyKey).
+				Return(nil, tt.err)
+
+			testSecret := &logical.Secret{
+				InternalData: map[string]interface{}{
+					"api_key":     testIAMAccessKeyKey,
+					"secret_type": SecretTypeAPIKey,
+					"version":     tt.version,
+				},
+				LeaseID: ts.randomID(),
+			}
+
+			_, err := ts.backend.HandleRequest(context.Background(), &logical.Request{
+				Storage:   ts.storage,
+				Operation: logical.RevokeOperation,
+				Path:      testSecret.LeaseID,
+				Secret:    testSecret,
+			})
+			ts.Require().NoError(err)
+		})
+	}
+}
+
+func (ts *testSuite) TestSecretAPIKeyV3RevokeForbidden() {
+	ts.backend.(*exoscaleBackend).exo.egoscaleClient.(*mockEgoscaleClient).
+		On("DeleteApiKeyWithResponse", mock.Anything, testIAMAccessKeyKey).
+		Return(nil, &APIError{StatusCode: http.StatusForbidden, Message: "API Key not in organization"})
+
+	testSecret := &logical.Secret{
+		InternalData: map[string]interface{}{
+			"api_key":     testIAMAccessKeyKey,
+			"secret_type": SecretTypeAPIKey,
+			"version":     "v3",
+		},
+		LeaseID: ts.randomID(),
+	}
+
+	_, err := ts.backend.HandleRequest(context.Background(), &logical.Request{
+		Storage:   ts.storage,
+		Operation: logical.RevokeOperation,
+		Path:      testSecret.LeaseID,
+		Secret:    testSecret,
+	})
+	ts.Require().ErrorIs(err, ErrForbidden)
+}
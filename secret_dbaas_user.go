@@ -0,0 +1,112 @@
+package exoscale
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/hashicorp/vault/sdk/framework"
+	"github.com/hashicorp/vault/sdk/logical"
+)
+
+const SecretTypeDBaaSUser = "dbaas_user"
+
+func (b *exoscaleBackend) secretDBaaSUser() *framework.Secret {
+	return &framework.Secret{
+		Type: SecretTypeDBaaSUser,
+		Fields: map[string]*framework.FieldSchema{
+			"username": {
+				Type:        framework.TypeString,
+				Description: "Username",
+			},
+			"password": {
+				Type:        framework.TypeString,
+				Description: "Password",
+			},
+			"uri": {
+				Type:        framework.TypeString,
+				Description: "Connection URI of the service",
+			},
+			"access_cert": {
+				Type:        framework.TypeString,
+				Description: "Client certificate of the Kafka user",
+			},
+			"access_key": {
+				Type:        framework.TypeString,
+				Description: "Client key of the Kafka user",
+			},
+		},
+
+		Renew:  b.secretDBaaSUserRenew,
+		Revoke: b.secretDBaaSUserRevoke,
+	}
+}
+
+func (b *exoscaleBackend) secretDBaaSUserRenew(
+	ctx context.Context,
+	req *logical.Request,
+	_ *framework.FieldData,
+) (*logical.Response, error) {
+	roleName, ok := req.Secret.InternalData["role"].(string)
+	if !ok {
+		return nil, errors.New("'role' is missing from the secret's internal data")
+	}
+
+	role, err := getDBaaSRole(ctx, req.Storage, roleName)
+	if err != nil {
+		return nil, err
+	}
+	if role == nil {
+		return nil, fmt.Errorf("unable to renew: DBaaS role %q not found", roleName)
+	}
+
+	ttl, warnings, err := framework.CalculateTTL(b.System(), 0, role.TTL, 0, role.MaxTTL, 0, req.Secret.IssueTime)
+	if err != nil {
+		return nil, err
+	}
+
+	res := &logical.Response{Secret: req.Secret, Warnings: warnings}
+	res.Secret.TTL = ttl
+	res.Secret.MaxTTL = role.MaxTTL
+
+	return res, nil
+}
+
+func (b *exoscaleBackend) secretDBaaSUserRevoke(
+	ctx context.Context,
+	req *logical.Request,
+	_ *framework.FieldData,
+) (*logical.Response, error) {
+	internal := make(map[string]string)
+	for _, k := range []string{configDBaaSServiceType, configDBaaSService, configZone, configRoleAccount, "username"} {
+		v, ok := req.Secret.InternalData[k].(string)
+		if !ok {
+			return nil, fmt.Errorf("'%s' is missing from the secret's internal data", k)
+		}
+		internal[k] = v
+	}
+
+	exo, err := b.exoscale(ctx, req.Storage, internal[configRoleAccount])
+	if err != nil {
+		return nil, err
+	}
+
+	err = deleteDBaaSUser(ctx, exo,
+		internal[configZone],
+		internal[configDBaaSServiceType],
+		internal[configDBaaSService],
+		internal["username"])
+	if err != nil {
+		return nil, err
+	}
+
+	if err := deleteAccountLease(ctx, req.Storage, internal[configRoleAccount], SecretTypeDBaaSUser, internal["username"]); err != nil {
+		return nil, err
+	}
+
+	b.Logger().Info("DBaaS user deleted",
+		"service", internal[configDBaaSService],
+		"username", internal["username"])
+
+	return nil, nil
+}
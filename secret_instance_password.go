@@ -0,0 +1,45 @@
+package exoscale
+
+import (
+	"context"
+
+	"github.com/hashicorp/vault/sdk/framework"
+	"github.com/hashicorp/vault/sdk/logical"
+)
+
+const SecretTypeInstancePassword = "instance_password"
+
+func (b *exoscaleBackend) secretInstancePassword() *framework.Secret {
+	return &framework.Secret{
+		Type: SecretTypeInstancePassword,
+		Fields: map[string]*framework.FieldSchema{
+			"password": {
+				Type:        framework.TypeString,
+				Description: "Password of the instance",
+			},
+			configInstanceID: {
+				Type:        framework.TypeString,
+				Description: "ID of the instance",
+			},
+		},
+
+		Revoke: b.secretInstancePasswordRevoke,
+	}
+}
+
+// secretInstancePasswordRevoke only logs the end of the lease: the password of an
+// instance can't be reset without reinstalling it
+func (b *exoscaleBackend) secretInstancePasswordRevoke(
+	_ context.Context,
+	req *logical.Request,
+	_ *framework.FieldData,
+) (*logical.Response, error) {
+	b.Logger().Info("Instance password lease ended, the password remains unchanged",
+		"role", req.Secret.InternalData["role"],
+		"instance_id", req.Secret.InternalData[configInstanceID],
+		"zone", req.Secret.InternalData[configZone],
+		"display_name", req.Secret.InternalData["display_name"],
+		"entity_id", req.Secret.InternalData["entity_id"])
+
+	return nil, nil
+}
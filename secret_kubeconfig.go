@@ -0,0 +1,43 @@
+package exoscale
+
+import (
+	"context"
+
+	"github.com/hashicorp/vault/sdk/framework"
+	"github.com/hashicorp/vault/sdk/logical"
+)
+
+const SecretTypeKubeconfig = "kubeconfig"
+
+func (b *exoscaleBackend) secretKubeconfig() *framework.Secret {
+	return &framework.Secret{
+		Type: SecretTypeKubeconfig,
+		Fields: map[string]*framework.FieldSchema{
+			"kubeconfig": {
+				Type:        framework.TypeString,
+				Description: "Kubeconfig",
+			},
+			"expires_at": {
+				Type:        framework.TypeString,
+				Description: "Expiry of the certificate of the kubeconfig",
+			},
+		},
+
+		Revoke: b.secretKubeconfigRevoke,
+	}
+}
+
+// secretKubeconfigRevoke only logs the revocation: SKS certificates can't be revoked individually
+func (b *exoscaleBackend) secretKubeconfigRevoke(
+	_ context.Context,
+	req *logical.Request,
+	_ *framework.FieldData,
+) (*logical.Response, error) {
+	b.Logger().Info("Kubeconfig lease revoked, its certificate remains valid until it expires",
+		"role", req.Secret.InternalData["role"],
+		"cluster_id", req.Secret.InternalData[configSKSRoleClusterID],
+		"user", req.Secret.InternalData[configSKSRoleUser],
+		"expires_at", req.Secret.InternalData["expires_at"])
+
+	return nil, nil
+}
@@ -0,0 +1,93 @@
+package exoscale
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/hashicorp/vault/sdk/framework"
+	"github.com/hashicorp/vault/sdk/logical"
+)
+
+const SecretTypeSSHKey = "ssh_key"
+
+func (b *exoscaleBackend) secretSSHKey() *framework.Secret {
+	return &framework.Secret{
+		Type: SecretTypeSSHKey,
+		Fields: map[string]*framework.FieldSchema{
+			"name": {
+				Type:        framework.TypeString,
+				Description: "Name of the SSH key in Exoscale",
+			},
+			configSSHKeyPublicKey: {
+				Type:        framework.TypeString,
+				Description: "SSH public key",
+			},
+			"private_key": {
+				Type:        framework.TypeString,
+				Description: "SSH private key, if generated",
+			},
+			"fingerprint": {
+				Type:        framework.TypeString,
+				Description: "SHA256 fingerprint of the SSH key",
+			},
+		},
+
+		Renew:  b.secretSSHKeyRenew,
+		Revoke: b.secretSSHKeyRevoke,
+	}
+}
+
+func (b *exoscaleBackend) secretSSHKeyRenew(
+	ctx context.Context,
+	req *logical.Request,
+	_ *framework.FieldData,
+) (*logical.Response, error) {
+	roleName, ok := req.Secret.InternalData["role"].(string)
+	if !ok {
+		return nil, errors.New("'role' is missing from the secret's internal data")
+	}
+
+	role, err := getSSHKeyRole(ctx, req.Storage, roleName)
+	if err != nil {
+		return nil, err
+	}
+	if role == nil {
+		return nil, fmt.Errorf("unable to renew: SSH key role %q not found", roleName)
+	}
+
+	res := &logical.Response{Secret: req.Secret}
+	res.Secret.TTL = role.TTL
+	res.Secret.MaxTTL = role.MaxTTL
+
+	return res, nil
+}
+
+func (b *exoscaleBackend) secretSSHKeyRevoke(
+	ctx context.Context,
+	req *logical.Request,
+	_ *framework.FieldData,
+) (*logical.Response, error) {
+	name, ok := req.Secret.InternalData["name"].(string)
+	if !ok {
+		return nil, errors.New("'name' is missing from the secret's internal data")
+	}
+	account, _ := req.Secret.InternalData[configRoleAccount].(string)
+
+	exo, err := b.exoscale(ctx, req.Storage, account)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := exo.V3DeleteSSHKey(ctx, name); err != nil && !errors.Is(err, ErrNotFound) {
+		return nil, err
+	}
+
+	if err := deleteAccountLease(ctx, req.Storage, account, SecretTypeSSHKey, name); err != nil {
+		return nil, err
+	}
+
+	b.Logger().Info("SSH key deleted", "name", name)
+
+	return nil, nil
+}
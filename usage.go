@@ -0,0 +1,145 @@
+package exoscale
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/hashicorp/vault/sdk/logical"
+)
+
+const (
+	roleUsageStoragePathPrefix = "usage/"
+
+	// issueRateWindow is the period max_issue_rate applies to
+	issueRateWindow = time.Minute
+)
+
+// roleUsage tracks the API keys issued for a role, to enforce its quotas
+type roleUsage struct {
+	// Active is the number of issued keys whose lease hasn't been revoked yet
+	Active int `json:"active"`
+	// Issued holds the issue times within the last issueRateWindow
+	Issued []time.Time `json:"issued,omitempty"`
+}
+
+// trim forgets the issue times out of the rate window
+func (u *roleUsage) trim(now time.Time) {
+	i := 0
+	for i < len(u.Issued) && now.Sub(u.Issued[i]) >= issueRateWindow {
+		i++
+	}
+	u.Issued = u.Issued[i:]
+}
+
+func getRoleUsage(ctx context.Context, storage logical.Storage, roleName string) (*roleUsage, error) {
+	entry, err := storage.Get(ctx, roleUsageStoragePathPrefix+roleName)
+	if err != nil {
+		return nil, fmt.Errorf("unable to retrieve usage of role %q: %w", roleName, err)
+	}
+
+	var usage roleUsage
+	if entry != nil {
+		if err := entry.DecodeJSON(&usage); err != nil {
+			return nil, err
+		}
+	}
+	usage.trim(time.Now())
+
+	return &usage, nil
+}
+
+func putRoleUsage(ctx context.Context, storage logical.Storage, roleName string, usage *roleUsage) error {
+	entry, err := logical.StorageEntryJSON(roleUsageStoragePathPrefix+roleName, usage)
+	if err != nil {
+		return err
+	}
+
+	return storage.Put(ctx, entry)
+}
+
+// acquireKey reserves an API key in the quotas of a role, an error response is returned
+// if a quota is exceeded. The reservation must be released with releaseKey if the key
+// isn't issued.
+func (b *exoscaleBackend) acquireKey(
+	ctx context.Context,
+	storage logical.Storage,
+	roleName string,
+	role *Role,
+) (*logical.Response, error) {
+	b.usageLock.Lock()
+	defer b.usageLock.Unlock()
+
+	usage, err := getRoleUsage(ctx, storage, roleName)
+	if err != nil {
+		return nil, err
+	}
+
+	if role.MaxActiveKeys > 0 && usage.Active >= role.MaxActiveKeys {
+		return logical.ErrorResponse("role %q reached its maximum of %d active API keys, revoke unused leases or raise %s",
+			roleName, role.MaxActiveKeys, configRoleMaxActiveKeys), nil
+	}
+	if role.MaxIssueRate > 0 && len(usage.Issued) >= role.MaxIssueRate {
+		return logical.ErrorResponse("role %q reached its maximum of %d API keys issued per minute, retry in %s",
+			roleName, role.MaxIssueRate, issueRateWindow-time.Since(usage.Issued[0]).Truncate(time.Second)), nil
+	}
+
+	usage.Active++
+	usage.Issued = append(usage.Issued, time.Now())
+
+	return nil, putRoleUsage(ctx, storage, roleName, usage)
+}
+
+// releaseKey decrements the active keys of a role, once a lease is revoked or if the
+// key reserved with acquireKey wasn't issued
+func (b *exoscaleBackend) releaseKey(ctx context.Context, storage logical.Storage, roleName string) error {
+	b.usageLock.Lock()
+	defer b.usageLock.Unlock()
+
+	usage, err := getRoleUsage(ctx, storage, roleName)
+	if err != nil {
+		return err
+	}
+	if usage.Active > 0 {
+		usage.Active--
+	}
+
+	return putRoleUsage(ctx, storage, roleName, usage)
+}
+
+// releaseIssuedKey releases the key of a revoked lease once: the record of the key is flagged
+// before the active keys of its role are decremented, so that a retried revocation doesn't
+// release it again.
+func (b *exoscaleBackend) releaseIssuedKey(ctx context.Context, storage logical.Storage, issued *issuedKey) error {
+	b.usageLock.Lock()
+	defer b.usageLock.Unlock()
+
+	if issued.Released {
+		return nil
+	}
+
+	usage, err := getRoleUsage(ctx, storage, issued.Role)
+	if err != nil {
+		return err
+	}
+
+	issued.Released = true
+	if err := putIssuedKey(ctx, storage, issued); err != nil {
+		issued.Released = false
+		return err
+	}
+
+	if usage.Active > 0 {
+		usage.Active--
+	}
+	if err := putRoleUsage(ctx, storage, issued.Role, usage); err != nil {
+		// the release is retried along with the revocation
+		issued.Released = false
+		if err := putIssuedKey(ctx, storage, issued); err != nil {
+			b.Logger().Warn("Failed to reset the release of API key", "iam_key", issued.Key, "role", issued.Role, "err", err)
+		}
+		return err
+	}
+
+	return nil
+}
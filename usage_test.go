@@ -0,0 +1,217 @@
+package exoscale
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/vault/sdk/logical"
+	"github.com/stretchr/testify/mock"
+
+	"github.com/exoscale/egoscale/v2/oapi"
+)
+
+func (ts *testSuite) mockCreateAPIKey() {
+	state := oapi.OperationStateSuccess
+	mockClient := ts.backend.(*exoscaleBackend).exo.egoscaleClient.(*mockEgoscaleClient)
+	mockClient.
+		On("CreateApiKeyWithResponse", mock.Anything, mock.Anything).
+		Return(&oapi.CreateApiKeyResponse{
+			JSON200: &oapi.IamApiKeyCreated{
+				Key:    &testIAMAccessKeyKey,
+				Name:   ptr("vault-" + testRoleName),
+				RoleId: ptr(ts.randomID()),
+				Secret: &testIAMAccessKeySecret,
+			},
+		}, nil)
+	mockClient.
+		On("DeleteApiKeyWithResponse", mock.Anything, testIAMAccessKeyKey).
+		Return(&oapi.DeleteApiKeyResponse{JSON200: &oapi.Operation{State: &state}}, nil)
+}
+
+func (ts *testSuite) issueAPIKey() *logical.Response {
+	res, err := ts.backend.HandleRequest(context.Background(), &logical.Request{
+		Storage:     ts.storage,
+		Operation:   logical.ReadOperation,
+		Path:        "apikey/" + testRoleName,
+		DisplayName: "test",
+	})
+	ts.Require().NoError(err)
+
+	return res
+}
+
+func (ts *testSuite) TestRoleMaxActiveKeys() {
+	ts.storeEntry(roleStoragePathPrefix+testRoleName, Role{
+		IAMRoleID:     ts.randomID(),
+		Version:       "v3",
+		Renewable:     true,
+		MaxActiveKeys: 1,
+	})
+	ts.mockCreateAPIKey()
+
+	secret := ts.issueAPIKey()
+	ts.Require().False(secret.IsError())
+
+	res := ts.issueAPIKey()
+	ts.Require().EqualError(res.Error(),
+		`role "`+testRoleName+`" reached its maximum of 1 active API keys, revoke unused leases or raise max_active_keys`)
+
+	res, err := ts.backend.HandleRequest(context.Background(), &logical.Request{
+		Storage:   ts.storage,
+		Operation: logical.ReadOperation,
+		Path:      roleStoragePathPrefix + testRoleName,
+	})
+	ts.Require().NoError(err)
+	ts.Require().Equal(1, res.Data["active_keys"])
+	ts.Require().Equal(1, res.Data[configRoleMaxActiveKeys])
+
+	_, err = ts.backend.HandleRequest(context.Background(), &logical.Request{
+		Storage:   ts.storage,
+		Operation: logical.RevokeOperation,
+		Path:      secret.Secret.LeaseID,
+		Secret:    secret.Secret,
+	})
+	ts.Require().NoError(err)
+
+	res = ts.issueAPIKey()
+	ts.Require().False(res.IsError())
+}
+
+func (ts *testSuite) TestRoleMaxIssueRate() {
+	ts.storeEntry(roleStoragePathPrefix+testRoleName, Role{
+		IAMRoleID:    ts.randomID(),
+		Version:      "v3",
+		Renewable:    true,
+		MaxIssueRate: 1,
+	})
+	ts.mockCreateAPIKey()
+
+	res := ts.issueAPIKey()
+	ts.Require().False(res.IsError())
+
+	res = ts.issueAPIKey()
+	ts.Require().True(res.IsError())
+	ts.Require().Contains(res.Error().Error(), "reached its maximum of 1 API keys issued per minute")
+
+	// issue times out of the rate window are forgotten
+	ts.storeEntry(roleUsageStoragePathPrefix+testRoleName, roleUsage{
+		Active: 1,
+		Issued: []time.Time{time.Now().Add(-issueRateWindow)},
+	})
+	res = ts.issueAPIKey()
+	ts.Require().False(res.IsError())
+}
+
+func (ts *testSuite) TestRoleQuotaReleasedOnFailure() {
+	ts.storeEntry(roleStoragePathPrefix+testRoleName, Role{
+		IAMRoleID:     ts.randomID(),
+		Version:       "v3",
+		Renewable:     true,
+		MaxActiveKeys: 1,
+	})
+	mockClient := ts.backend.(*exoscaleBackend).exo.egoscaleClient.(*mockEgoscaleClient)
+	mockClient.
+		On("CreateApiKeyWithResponse", mock.Anything, mock.Anything).
+		Return(nil, &APIError{StatusCode: 500, Message: "internal error"})
+
+	_, err := ts.backend.HandleRequest(context.Background(), &logical.Request{
+		Storage:     ts.storage,
+		Operation:   logical.ReadOperation,
+		Path:        "apikey/" + testRoleName,
+		DisplayName: "test",
+	})
+	ts.Require().Error(err)
+
+	usage, err := getRoleUsage(context.Background(), ts.storage, testRoleName)
+	ts.Require().NoError(err)
+	ts.Require().Equal(0, usage.Active)
+}
+
+// failingPutStorage fails the writes of the entries under a prefix
+type failingPutStorage struct {
+	logical.Storage
+	prefix string
+}
+
+func (s *failingPutStorage) Put(ctx context.Context, entry *logical.StorageEntry) error {
+	if strings.HasPrefix(entry.Key, s.prefix) {
+		return errors.New("storage unavailable")
+	}
+
+	return s.Storage.Put(ctx, entry)
+}
+
+func (ts *testSuite) TestRoleQuotaReleasedOnRevokeRetry() {
+	ts.storeEntry(roleStoragePathPrefix+testRoleName, Role{
+		IAMRoleID:     ts.randomID(),
+		Version:       "v3",
+		Renewable:     true,
+		MaxActiveKeys: 1,
+	})
+	ts.mockCreateAPIKey()
+
+	secret := ts.issueAPIKey()
+	ts.Require().False(secret.IsError())
+
+	// the key record is kept when the key can't be released, for the retried revocation
+	_, err := ts.backend.HandleRequest(context.Background(), &logical.Request{
+		Storage:   &failingPutStorage{Storage: ts.storage, prefix: roleUsageStoragePathPrefix},
+		Operation: logical.RevokeOperation,
+		Path:      secret.Secret.LeaseID,
+		Secret:    secret.Secret,
+	})
+	ts.Require().Error(err)
+
+	issued, err := getIssuedKey(context.Background(), ts.storage, testIAMAccessKeyKey)
+	ts.Require().NoError(err)
+	ts.Require().NotNil(issued)
+
+	_, err = ts.backend.HandleRequest(context.Background(), &logical.Request{
+		Storage:   ts.storage,
+		Operation: logical.RevokeOperation,
+		Path:      secret.Secret.LeaseID,
+		Secret:    secret.Secret,
+	})
+	ts.Require().NoError(err)
+
+	usage, err := getRoleUsage(context.Background(), ts.storage, testRoleName)
+	ts.Require().NoError(err)
+	ts.Require().Equal(0, usage.Active)
+}
+
+func (ts *testSuite) TestRoleQuotaReleasedOnceOnRevokeRetry() {
+	ts.storeEntry(roleStoragePathPrefix+testRoleName, Role{
+		IAMRoleID:     ts.randomID(),
+		Version:       "v3",
+		Renewable:     true,
+		MaxActiveKeys: 2,
+	})
+	ts.mockCreateAPIKey()
+
+	ts.Require().False(ts.issueAPIKey().IsError())
+	secret := ts.issueAPIKey()
+	ts.Require().False(secret.IsError())
+
+	// the record of the key can't be moved to revoked/, Vault retries the revocation
+	_, err := ts.backend.HandleRequest(context.Background(), &logical.Request{
+		Storage:   &failingPutStorage{Storage: ts.storage, prefix: revokedKeyStoragePathPrefix},
+		Operation: logical.RevokeOperation,
+		Path:      secret.Secret.LeaseID,
+		Secret:    secret.Secret,
+	})
+	ts.Require().Error(err)
+
+	_, err = ts.backend.HandleRequest(context.Background(), &logical.Request{
+		Storage:   ts.storage,
+		Operation: logical.RevokeOperation,
+		Path:      secret.Secret.LeaseID,
+		Secret:    secret.Secret,
+	})
+	ts.Require().NoError(err)
+
+	usage, err := getRoleUsage(context.Background(), ts.storage, testRoleName)
+	ts.Require().NoError(err)
+	ts.Require().Equal(1, usage.Active)
+}